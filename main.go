@@ -18,89 +18,286 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	docs "gnosql/docs"
 	pb "gnosql/proto"
+	"gnosql/src/auth"
+	"gnosql/src/commands"
 	"gnosql/src/common"
+	"gnosql/src/config"
+	"gnosql/src/global_constants"
 	"gnosql/src/grpc_handler"
 	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
+	"gnosql/src/metrics"
+	"gnosql/src/raft"
+	"gnosql/src/replication"
 	"gnosql/src/router"
+	"gnosql/src/service"
+	"gnosql/src/sharding"
+	"gnosql/src/tls_config"
 	"html/template"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-var (
-	// Port for gRPC server to listen to
-	GRPC_PORT = "5455"
-	GIN_PORT  = "5454"
-)
+var logger = logging.Get(global_constants.LOG_SERVER)
 
 // @BasePath /api/v1
 func main() {
-	ginRouter := gin.Default()
-	ginRouter.SetHTMLTemplate(template.Must(template.ParseGlob("./src/templates/*")))
+	// Settings file, then env, then flags, Ex: gnosql -config gnosql.yaml -gin-port 6454
+	settings, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("settings: %v", err)
+	}
 
-	if port := os.Getenv("GIN_PORT"); port != "" {
-		GIN_PORT = port
+	// JSON logs by default, the level of each subsystem can be set apart, Ex: -log-levels raft=debug
+	if err := logging.Configure(settings.LogLevel, settings.LogFormat, settings.LogLevels); err != nil {
+		fatal("settings", err)
 	}
-	if port := os.Getenv("GRPC_PORT"); port != "" {
-		GRPC_PORT = port
+
+	// Encryption at rest, the key is given directly or in a file
+	masterKey, err := common.LoadMasterKey(settings.MasterKey, settings.MasterKeyFile)
+	if err != nil {
+		fatal("master key", err)
+	}
+	if masterKey != nil {
+		if err := common.SetMasterKey(masterKey); err != nil {
+			fatal("master key", err)
+		}
+	}
+
+	if err := auth.Configure(settings.Auth, settings.TokenSecret, settings.TokenTTL); err != nil {
+		fatal("auth", err)
 	}
 
-	fmt.Printf("\n GIN_PORT: %v", GIN_PORT)
-	fmt.Printf("\n GRPC_PORT: %v", GRPC_PORT)
+	// TLS for both listeners, the certificates are read again on SIGHUP
+	if err := tls_config.Configure(settings.TLSCertFile, settings.TLSKeyFile, settings.TLSCAFile, settings.TLSClientAuth); err != nil {
+		fatal("tls", err)
+	}
+
+	// Offline commands work on the data folder and exit, Ex: gnosql restore -archive backup.tar.gz
+	if len(args) > 0 && args[0] == "restore" {
+		if err := commands.Restore(settings.DataPath, args[1:]); err != nil {
+			fatal("restore failed", err)
+		}
+		return
+	}
+	if len(args) > 0 && args[0] == "check" {
+		if err := commands.Check(settings.DataPath, args[1:]); err != nil {
+			fatal("check failed", err)
+		}
+		return
+	}
+
+	logger.Info("starting", "ginPort", settings.GinPort, "grpcPort", settings.GrpcPort, "role", settings.Role)
 
 	// Creating gnosql/db folder
-	common.CreateDatabaseFolder()
+	common.CreateDatabaseFolder(settings.DataPath)
 
 	// Creating Gnosql
-	var gnoSQL *in_memory_database.GnoSQL = in_memory_database.CreateGnoSQL()
+	var gnoSQL *in_memory_database.GnoSQL = in_memory_database.CreateGnoSQL(&settings)
+	var raftNode *raft.Node
 
-	// Load existing database
-	gnoSQL.LoadAllDBs()
+	// requests are logged by the router with their request id, gin only recovers from panics
+	if settings.LogLevel != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	ginRouter := gin.New()
+	ginRouter.Use(gin.Recovery())
+	ginRouter.SetHTMLTemplate(template.Must(template.ParseGlob("./src/templates/*")))
 
 	router.RouterInit(ginRouter, gnoSQL)
+	metrics.Register(gnoSQL.MetricsCollector())
 
 	docs.SwaggerInfo.BasePath = "/"
-	docs.SwaggerInfo.Host = "localhost:" + GIN_PORT
+	docs.SwaggerInfo.Host = "localhost:" + settings.GinPort
 
 	// Swagger handler
 	ginRouter.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	// EX: localhost:5454/swagger/index.html
 
-	// Start the server in a separate goroutine
+	httpServer := &http.Server{Addr: ":" + settings.GinPort, Handler: ginRouter, TLSConfig: tls_config.ServerConfig(false)}
+
+	// Start the server in a separate goroutine, before databases are loaded so /health/ready can report the progress
 	go func() {
-		if err := ginRouter.Run(":" + GIN_PORT); err != nil {
-			fmt.Printf("Error starting server: %v\n", err)
+		var err error
+		if httpServer.TLSConfig != nil {
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("http server error", "error", err)
 		}
 	}()
 
-	go func() {
-		lis, err := net.Listen("tcp", ":"+GRPC_PORT)
+	if tls_config.IsEnabled() {
+		go tls_config.ReloadOnSignal()
+	}
+
+	if settings.Role == global_constants.ROLE_RAFT {
+		raftPeers, err := replication.ParseRaftPeers(settings.RaftPeers)
+		if err != nil {
+			fatal("raftPeers", err)
+		}
+
+		// Raft storage lives next to the data folder, every folder inside it is treated as a database
+		raftNode, err = replication.StartRaft(gnoSQL, settings.RaftId, raftPeers, settings.DataPath+"-raft")
+		if err != nil {
+			fatal("failed to start raft node", err)
+		}
+
+		// databases are rebuilt from the raft log while serving
+		gnoSQL.SetReady(true)
+	} else if settings.Role == global_constants.ROLE_ROUTER {
+		shards, err := sharding.ParseShards(settings.Shards)
+		if err != nil {
+			fatal("shards", err)
+		}
 
+		// Router keeps only the shard map, databases live on the shards
+		shardRouter, err := sharding.NewRouter(settings.DataPath, shards, settings.FilterLimit)
 		if err != nil {
-			log.Fatalf("failed connection: %v", err)
+			fatal("failed to start router", err)
 		}
 
-		s := grpc.NewServer()
+		// the router keeps the system database with the users itself
+		gnoSQL.LoadAllDBs()
 
-		pb.RegisterGnoSQLServiceServer(s, &grpc_handler.GnoSQLServer{GnoSQL: gnoSQL})
+		gnoSQL.Role = global_constants.ROLE_ROUTER
+		gnoSQL.ShardRouter = shardRouter
+		gnoSQL.SetReady(true)
+	} else if settings.Role == global_constants.ROLE_REPLICA {
+		if settings.LeaderAddr == "" {
+			fatal("settings", fmt.Errorf("leaderAddr is required for %v role", settings.Role))
+		}
 
-		fmt.Println("GRPC server started successfully ", lis.Addr())
+		// Replica loads databases from the primary's snapshot, then follows its mutation log
+		gnoSQL.StartAsReplica(settings.LeaderAddr)
+		go replication.StartReplica(gnoSQL, settings.LeaderAddr)
+	} else {
+		if settings.IntegrityCheck != "" {
+			report := in_memory_database.CheckIntegrity(in_memory_database.IntegrityOptions{
+				DataPath:   settings.DataPath,
+				Repair:     strings.Contains(settings.IntegrityCheck, "repair"),
+				Quarantine: strings.Contains(settings.IntegrityCheck, "quarantine"),
+			})
+			report.Log()
+		}
 
-		if err := s.Serve(lis); err != nil {
-			log.Fatalf("failed to server: %v", err)
+		// Load existing database
+		switch settings.LoadMode {
+		case global_constants.LOAD_MODE_EAGER:
+			gnoSQL.LoadAllDBs()
+		case global_constants.LOAD_MODE_ON_ACCESS:
+			gnoSQL.RegisterAllDBs()
+		case global_constants.LOAD_MODE_WARM_UP:
+			gnoSQL.RegisterAllDBs()
+			go gnoSQL.WarmUp()
+		default:
+			fatal("settings", fmt.Errorf("loadMode: unknown load mode %v", settings.LoadMode))
 		}
+	}
+
+	// a replica gets the users from its primary
+	if settings.Auth && settings.Role != global_constants.ROLE_REPLICA {
+		go func() {
+			generatedPassword, err := service.BootstrapAdmin(gnoSQL, settings.AdminPassword)
+			if err != nil {
+				logger.Error("admin user not created", "error", err)
+			} else if generatedPassword != "" {
+				logger.Info("admin user created", "username", global_constants.ADMIN_USERNAME, "password", generatedPassword)
+			}
+		}()
+	}
+
+	if settings.CompactionInterval > 0 && gnoSQL.ShardRouter == nil {
+		go gnoSQL.StartBatchCompaction(settings.CompactionInterval)
+	}
+
+	var serverOptions = []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(grpc_handler.RequestIdUnaryInterceptor(), grpc_handler.MetricsUnaryInterceptor(), grpc_handler.AuthUnaryInterceptor(gnoSQL)),
+		grpc.ChainStreamInterceptor(grpc_handler.RequestIdStreamInterceptor(), grpc_handler.MetricsStreamInterceptor(), grpc_handler.AuthStreamInterceptor(gnoSQL)),
+	}
+	if tlsConfig := tls_config.ServerConfig(true); tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(serverOptions...)
+
+	pb.RegisterGnoSQLServiceServer(grpcServer, &grpc_handler.GnoSQLServer{GnoSQL: gnoSQL})
+	pb.RegisterReplicationServiceServer(grpcServer, &grpc_handler.ReplicationServer{GnoSQL: gnoSQL})
+
+	go func() {
+		lis, err := net.Listen("tcp", ":"+settings.GrpcPort)
+
+		if err != nil {
+			fatal("gRPC listen", err)
+		}
+
+		if raftNode != nil {
+			pb.RegisterRaftServiceServer(grpcServer, &grpc_handler.RaftServer{Node: raftNode})
+			raftNode.Start()
+		}
+
+		logger.Info("gRPC server started", "address", lis.Addr().String())
+
+		if err := grpcServer.Serve(lis); err != nil {
+			fatal("gRPC server", err)
+		}
+	}()
+
+	// Run until SIGINT or SIGTERM, Ex: docker stop
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	shutdown(httpServer, grpcServer, gnoSQL)
+}
+
+// shutdown stops taking requests, lets the ones in flight finish and writes every collection to disk
+func shutdown(httpServer *http.Server, grpcServer *grpc.Server, gnoSQL *in_memory_database.GnoSQL) {
+	logger.Info("shutting down, new requests are refused")
+
+	ctx, cancel := context.WithTimeout(context.Background(), global_constants.SERVER_SHUTDOWN_TIMEOUT)
+	defer cancel()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logger.Error("http server stop error", "error", err)
+	}
+
+	// replication streams stay open until the replica disconnects, they are cut after the timeout
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
 	}()
 
-	// Ensure the main goroutine doesn't exit immediately
-	select {}
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+
+	gnoSQL.Shutdown()
+}
 
+// fatal logs an error the server can't run with and exits
+func fatal(message string, err error) {
+	logger.Error(message, "error", err)
+	os.Exit(1)
 }
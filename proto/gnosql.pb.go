@@ -63,8 +63,9 @@ type DatabaseCreateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DatabaseName string             `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
-	Collections  []*CollectionInput `protobuf:"bytes,2,rep,name=collections,proto3" json:"collections,omitempty"`
+	DatabaseName string               `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
+	Collections  []*CollectionInput   `protobuf:"bytes,2,rep,name=collections,proto3" json:"collections,omitempty"`
+	Config       *DatabaseConfigInput `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
 }
 
 func (x *DatabaseCreateRequest) Reset() {
@@ -113,6 +114,76 @@ func (x *DatabaseCreateRequest) GetCollections() []*CollectionInput {
 	return nil
 }
 
+func (x *DatabaseCreateRequest) GetConfig() *DatabaseConfigInput {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type DatabaseConfigInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StorageEngine  string `protobuf:"bytes,1,opt,name=storageEngine,proto3" json:"storageEngine,omitempty"`
+	MemoryBudgetMB int32  `protobuf:"varint,2,opt,name=memoryBudgetMB,proto3" json:"memoryBudgetMB,omitempty"`
+	Compression    string `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"`
+}
+
+func (x *DatabaseConfigInput) Reset() {
+	*x = DatabaseConfigInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatabaseConfigInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseConfigInput) ProtoMessage() {}
+
+func (x *DatabaseConfigInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseConfigInput.ProtoReflect.Descriptor instead.
+func (*DatabaseConfigInput) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DatabaseConfigInput) GetStorageEngine() string {
+	if x != nil {
+		return x.StorageEngine
+	}
+	return ""
+}
+
+func (x *DatabaseConfigInput) GetMemoryBudgetMB() int32 {
+	if x != nil {
+		return x.MemoryBudgetMB
+	}
+	return 0
+}
+
+func (x *DatabaseConfigInput) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
 type DatabaseCreateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -124,7 +195,7 @@ type DatabaseCreateResponse struct {
 func (x *DatabaseCreateResponse) Reset() {
 	*x = DatabaseCreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[2]
+		mi := &file_proto_gnosql_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -137,7 +208,7 @@ func (x *DatabaseCreateResponse) String() string {
 func (*DatabaseCreateResponse) ProtoMessage() {}
 
 func (x *DatabaseCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[2]
+	mi := &file_proto_gnosql_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -150,7 +221,7 @@ func (x *DatabaseCreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseCreateResponse.ProtoReflect.Descriptor instead.
 func (*DatabaseCreateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{2}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DatabaseCreateResponse) GetData() string {
@@ -172,7 +243,7 @@ type DatabaseResponse struct {
 func (x *DatabaseResponse) Reset() {
 	*x = DatabaseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[3]
+		mi := &file_proto_gnosql_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -185,7 +256,7 @@ func (x *DatabaseResponse) String() string {
 func (*DatabaseResponse) ProtoMessage() {}
 
 func (x *DatabaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[3]
+	mi := &file_proto_gnosql_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +269,7 @@ func (x *DatabaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseResponse.ProtoReflect.Descriptor instead.
 func (*DatabaseResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{3}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DatabaseResponse) GetDatabaseName() string {
@@ -226,7 +297,7 @@ type DatabaseConnectResponse struct {
 func (x *DatabaseConnectResponse) Reset() {
 	*x = DatabaseConnectResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[4]
+		mi := &file_proto_gnosql_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -239,7 +310,7 @@ func (x *DatabaseConnectResponse) String() string {
 func (*DatabaseConnectResponse) ProtoMessage() {}
 
 func (x *DatabaseConnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[4]
+	mi := &file_proto_gnosql_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -252,7 +323,7 @@ func (x *DatabaseConnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseConnectResponse.ProtoReflect.Descriptor instead.
 func (*DatabaseConnectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{4}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DatabaseConnectResponse) GetData() *DatabaseResponse {
@@ -273,7 +344,7 @@ type DatabaseDeleteRequest struct {
 func (x *DatabaseDeleteRequest) Reset() {
 	*x = DatabaseDeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[5]
+		mi := &file_proto_gnosql_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -286,7 +357,7 @@ func (x *DatabaseDeleteRequest) String() string {
 func (*DatabaseDeleteRequest) ProtoMessage() {}
 
 func (x *DatabaseDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[5]
+	mi := &file_proto_gnosql_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +370,7 @@ func (x *DatabaseDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseDeleteRequest.ProtoReflect.Descriptor instead.
 func (*DatabaseDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{5}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DatabaseDeleteRequest) GetDatabaseName() string {
@@ -320,7 +391,7 @@ type DatabaseDeleteResponse struct {
 func (x *DatabaseDeleteResponse) Reset() {
 	*x = DatabaseDeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[6]
+		mi := &file_proto_gnosql_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -333,7 +404,7 @@ func (x *DatabaseDeleteResponse) String() string {
 func (*DatabaseDeleteResponse) ProtoMessage() {}
 
 func (x *DatabaseDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[6]
+	mi := &file_proto_gnosql_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -346,7 +417,7 @@ func (x *DatabaseDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseDeleteResponse.ProtoReflect.Descriptor instead.
 func (*DatabaseDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{6}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DatabaseDeleteResponse) GetData() string {
@@ -367,7 +438,7 @@ type DatabaseGetAllResponse struct {
 func (x *DatabaseGetAllResponse) Reset() {
 	*x = DatabaseGetAllResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[7]
+		mi := &file_proto_gnosql_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -380,7 +451,7 @@ func (x *DatabaseGetAllResponse) String() string {
 func (*DatabaseGetAllResponse) ProtoMessage() {}
 
 func (x *DatabaseGetAllResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[7]
+	mi := &file_proto_gnosql_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,7 +464,7 @@ func (x *DatabaseGetAllResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseGetAllResponse.ProtoReflect.Descriptor instead.
 func (*DatabaseGetAllResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{7}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DatabaseGetAllResponse) GetData() []string {
@@ -414,7 +485,7 @@ type LoadToDiskResponse struct {
 func (x *LoadToDiskResponse) Reset() {
 	*x = LoadToDiskResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[8]
+		mi := &file_proto_gnosql_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -427,7 +498,7 @@ func (x *LoadToDiskResponse) String() string {
 func (*LoadToDiskResponse) ProtoMessage() {}
 
 func (x *LoadToDiskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[8]
+	mi := &file_proto_gnosql_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +511,7 @@ func (x *LoadToDiskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadToDiskResponse.ProtoReflect.Descriptor instead.
 func (*LoadToDiskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{8}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LoadToDiskResponse) GetData() string {
@@ -457,12 +528,14 @@ type CollectionInput struct {
 
 	CollectionName string   `protobuf:"bytes,1,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
 	IndexKeys      []string `protobuf:"bytes,2,rep,name=indexKeys,proto3" json:"indexKeys,omitempty"`
+	ShardKey       string   `protobuf:"bytes,3,opt,name=shardKey,proto3" json:"shardKey,omitempty"`
+	QueryCacheMB   int32    `protobuf:"varint,4,opt,name=queryCacheMB,proto3" json:"queryCacheMB,omitempty"`
 }
 
 func (x *CollectionInput) Reset() {
 	*x = CollectionInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[9]
+		mi := &file_proto_gnosql_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -475,7 +548,7 @@ func (x *CollectionInput) String() string {
 func (*CollectionInput) ProtoMessage() {}
 
 func (x *CollectionInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[9]
+	mi := &file_proto_gnosql_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -488,7 +561,7 @@ func (x *CollectionInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionInput.ProtoReflect.Descriptor instead.
 func (*CollectionInput) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{9}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CollectionInput) GetCollectionName() string {
@@ -505,6 +578,20 @@ func (x *CollectionInput) GetIndexKeys() []string {
 	return nil
 }
 
+func (x *CollectionInput) GetShardKey() string {
+	if x != nil {
+		return x.ShardKey
+	}
+	return ""
+}
+
+func (x *CollectionInput) GetQueryCacheMB() int32 {
+	if x != nil {
+		return x.QueryCacheMB
+	}
+	return 0
+}
+
 type CollectionCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -517,7 +604,7 @@ type CollectionCreateRequest struct {
 func (x *CollectionCreateRequest) Reset() {
 	*x = CollectionCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[10]
+		mi := &file_proto_gnosql_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -530,7 +617,7 @@ func (x *CollectionCreateRequest) String() string {
 func (*CollectionCreateRequest) ProtoMessage() {}
 
 func (x *CollectionCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[10]
+	mi := &file_proto_gnosql_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +630,7 @@ func (x *CollectionCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionCreateRequest.ProtoReflect.Descriptor instead.
 func (*CollectionCreateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{10}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CollectionCreateRequest) GetDatabaseName() string {
@@ -571,7 +658,7 @@ type CollectionCreateResponse struct {
 func (x *CollectionCreateResponse) Reset() {
 	*x = CollectionCreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[11]
+		mi := &file_proto_gnosql_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -584,7 +671,7 @@ func (x *CollectionCreateResponse) String() string {
 func (*CollectionCreateResponse) ProtoMessage() {}
 
 func (x *CollectionCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[11]
+	mi := &file_proto_gnosql_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,7 +684,7 @@ func (x *CollectionCreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionCreateResponse.ProtoReflect.Descriptor instead.
 func (*CollectionCreateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{11}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CollectionCreateResponse) GetData() string {
@@ -619,7 +706,7 @@ type CollectionDeleteRequest struct {
 func (x *CollectionDeleteRequest) Reset() {
 	*x = CollectionDeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[12]
+		mi := &file_proto_gnosql_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -632,7 +719,7 @@ func (x *CollectionDeleteRequest) String() string {
 func (*CollectionDeleteRequest) ProtoMessage() {}
 
 func (x *CollectionDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[12]
+	mi := &file_proto_gnosql_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +732,7 @@ func (x *CollectionDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionDeleteRequest.ProtoReflect.Descriptor instead.
 func (*CollectionDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{12}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CollectionDeleteRequest) GetDatabaseName() string {
@@ -673,7 +760,7 @@ type CollectionDeleteResponse struct {
 func (x *CollectionDeleteResponse) Reset() {
 	*x = CollectionDeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[13]
+		mi := &file_proto_gnosql_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -686,7 +773,7 @@ func (x *CollectionDeleteResponse) String() string {
 func (*CollectionDeleteResponse) ProtoMessage() {}
 
 func (x *CollectionDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[13]
+	mi := &file_proto_gnosql_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,7 +786,7 @@ func (x *CollectionDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionDeleteResponse.ProtoReflect.Descriptor instead.
 func (*CollectionDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{13}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CollectionDeleteResponse) GetData() string {
@@ -720,7 +807,7 @@ type CollectionGetAllRequest struct {
 func (x *CollectionGetAllRequest) Reset() {
 	*x = CollectionGetAllRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[14]
+		mi := &file_proto_gnosql_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -733,7 +820,7 @@ func (x *CollectionGetAllRequest) String() string {
 func (*CollectionGetAllRequest) ProtoMessage() {}
 
 func (x *CollectionGetAllRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[14]
+	mi := &file_proto_gnosql_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,7 +833,7 @@ func (x *CollectionGetAllRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionGetAllRequest.ProtoReflect.Descriptor instead.
 func (*CollectionGetAllRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{14}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CollectionGetAllRequest) GetDatabaseName() string {
@@ -767,7 +854,7 @@ type CollectionGetAllResponse struct {
 func (x *CollectionGetAllResponse) Reset() {
 	*x = CollectionGetAllResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[15]
+		mi := &file_proto_gnosql_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -780,7 +867,7 @@ func (x *CollectionGetAllResponse) String() string {
 func (*CollectionGetAllResponse) ProtoMessage() {}
 
 func (x *CollectionGetAllResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[15]
+	mi := &file_proto_gnosql_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -793,7 +880,7 @@ func (x *CollectionGetAllResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionGetAllResponse.ProtoReflect.Descriptor instead.
 func (*CollectionGetAllResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{15}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CollectionGetAllResponse) GetData() []string {
@@ -815,7 +902,7 @@ type CollectionStatsRequest struct {
 func (x *CollectionStatsRequest) Reset() {
 	*x = CollectionStatsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[16]
+		mi := &file_proto_gnosql_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -828,7 +915,7 @@ func (x *CollectionStatsRequest) String() string {
 func (*CollectionStatsRequest) ProtoMessage() {}
 
 func (x *CollectionStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[16]
+	mi := &file_proto_gnosql_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,7 +928,7 @@ func (x *CollectionStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionStatsRequest.ProtoReflect.Descriptor instead.
 func (*CollectionStatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{16}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *CollectionStatsRequest) GetDatabaseName() string {
@@ -869,7 +956,7 @@ type CollectionStatsResponse struct {
 func (x *CollectionStatsResponse) Reset() {
 	*x = CollectionStatsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[17]
+		mi := &file_proto_gnosql_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -882,7 +969,7 @@ func (x *CollectionStatsResponse) String() string {
 func (*CollectionStatsResponse) ProtoMessage() {}
 
 func (x *CollectionStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[17]
+	mi := &file_proto_gnosql_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,7 +982,7 @@ func (x *CollectionStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionStatsResponse.ProtoReflect.Descriptor instead.
 func (*CollectionStatsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{17}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CollectionStatsResponse) GetData() *CollectionStats {
@@ -910,15 +997,30 @@ type CollectionStats struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName string   `protobuf:"bytes,1,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
-	IndexKeys      []string `protobuf:"bytes,2,rep,name=indexKeys,proto3" json:"indexKeys,omitempty"`
-	Documents      int32    `protobuf:"varint,3,opt,name=documents,proto3" json:"documents,omitempty"`
+	CollectionName          string   `protobuf:"bytes,1,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	IndexKeys               []string `protobuf:"bytes,2,rep,name=indexKeys,proto3" json:"indexKeys,omitempty"`
+	Documents               int32    `protobuf:"varint,3,opt,name=documents,proto3" json:"documents,omitempty"`
+	ResidentBatches         int32    `protobuf:"varint,4,opt,name=residentBatches,proto3" json:"residentBatches,omitempty"`
+	EvictedBatches          int32    `protobuf:"varint,5,opt,name=evictedBatches,proto3" json:"evictedBatches,omitempty"`
+	CacheHits               uint64   `protobuf:"varint,6,opt,name=cacheHits,proto3" json:"cacheHits,omitempty"`
+	CacheMisses             uint64   `protobuf:"varint,7,opt,name=cacheMisses,proto3" json:"cacheMisses,omitempty"`
+	CacheHitRate            float64  `protobuf:"fixed64,8,opt,name=cacheHitRate,proto3" json:"cacheHitRate,omitempty"`
+	Ready                   bool     `protobuf:"varint,9,opt,name=ready,proto3" json:"ready,omitempty"`
+	StoredBytes             int64    `protobuf:"varint,10,opt,name=storedBytes,proto3" json:"storedBytes,omitempty"`
+	UncompressedBytes       int64    `protobuf:"varint,11,opt,name=uncompressedBytes,proto3" json:"uncompressedBytes,omitempty"`
+	CompressionRatio        float64  `protobuf:"fixed64,12,opt,name=compressionRatio,proto3" json:"compressionRatio,omitempty"`
+	QueryCacheHits          uint64   `protobuf:"varint,13,opt,name=queryCacheHits,proto3" json:"queryCacheHits,omitempty"`
+	QueryCacheMisses        uint64   `protobuf:"varint,14,opt,name=queryCacheMisses,proto3" json:"queryCacheMisses,omitempty"`
+	QueryCacheHitRate       float64  `protobuf:"fixed64,15,opt,name=queryCacheHitRate,proto3" json:"queryCacheHitRate,omitempty"`
+	QueryCacheInvalidations uint64   `protobuf:"varint,16,opt,name=queryCacheInvalidations,proto3" json:"queryCacheInvalidations,omitempty"`
+	QueryCacheEntries       int32    `protobuf:"varint,17,opt,name=queryCacheEntries,proto3" json:"queryCacheEntries,omitempty"`
+	QueryCacheBytes         int64    `protobuf:"varint,18,opt,name=queryCacheBytes,proto3" json:"queryCacheBytes,omitempty"`
 }
 
 func (x *CollectionStats) Reset() {
 	*x = CollectionStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[18]
+		mi := &file_proto_gnosql_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -931,7 +1033,7 @@ func (x *CollectionStats) String() string {
 func (*CollectionStats) ProtoMessage() {}
 
 func (x *CollectionStats) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[18]
+	mi := &file_proto_gnosql_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -944,7 +1046,7 @@ func (x *CollectionStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionStats.ProtoReflect.Descriptor instead.
 func (*CollectionStats) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{18}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CollectionStats) GetCollectionName() string {
@@ -968,6 +1070,111 @@ func (x *CollectionStats) GetDocuments() int32 {
 	return 0
 }
 
+func (x *CollectionStats) GetResidentBatches() int32 {
+	if x != nil {
+		return x.ResidentBatches
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetEvictedBatches() int32 {
+	if x != nil {
+		return x.EvictedBatches
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetCacheHits() uint64 {
+	if x != nil {
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetCacheMisses() uint64 {
+	if x != nil {
+		return x.CacheMisses
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetCacheHitRate() float64 {
+	if x != nil {
+		return x.CacheHitRate
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *CollectionStats) GetStoredBytes() int64 {
+	if x != nil {
+		return x.StoredBytes
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetUncompressedBytes() int64 {
+	if x != nil {
+		return x.UncompressedBytes
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetCompressionRatio() float64 {
+	if x != nil {
+		return x.CompressionRatio
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetQueryCacheHits() uint64 {
+	if x != nil {
+		return x.QueryCacheHits
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetQueryCacheMisses() uint64 {
+	if x != nil {
+		return x.QueryCacheMisses
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetQueryCacheHitRate() float64 {
+	if x != nil {
+		return x.QueryCacheHitRate
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetQueryCacheInvalidations() uint64 {
+	if x != nil {
+		return x.QueryCacheInvalidations
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetQueryCacheEntries() int32 {
+	if x != nil {
+		return x.QueryCacheEntries
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetQueryCacheBytes() int64 {
+	if x != nil {
+		return x.QueryCacheBytes
+	}
+	return 0
+}
+
 type DocumentCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -981,7 +1188,7 @@ type DocumentCreateRequest struct {
 func (x *DocumentCreateRequest) Reset() {
 	*x = DocumentCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[19]
+		mi := &file_proto_gnosql_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -994,7 +1201,7 @@ func (x *DocumentCreateRequest) String() string {
 func (*DocumentCreateRequest) ProtoMessage() {}
 
 func (x *DocumentCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[19]
+	mi := &file_proto_gnosql_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1007,7 +1214,7 @@ func (x *DocumentCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentCreateRequest.ProtoReflect.Descriptor instead.
 func (*DocumentCreateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{19}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DocumentCreateRequest) GetDatabaseName() string {
@@ -1042,7 +1249,7 @@ type DocumentCreateResponse struct {
 func (x *DocumentCreateResponse) Reset() {
 	*x = DocumentCreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[20]
+		mi := &file_proto_gnosql_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1055,7 +1262,7 @@ func (x *DocumentCreateResponse) String() string {
 func (*DocumentCreateResponse) ProtoMessage() {}
 
 func (x *DocumentCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[20]
+	mi := &file_proto_gnosql_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1068,7 +1275,7 @@ func (x *DocumentCreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentCreateResponse.ProtoReflect.Descriptor instead.
 func (*DocumentCreateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{20}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DocumentCreateResponse) GetData() string {
@@ -1091,7 +1298,7 @@ type DocumentReadRequest struct {
 func (x *DocumentReadRequest) Reset() {
 	*x = DocumentReadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[21]
+		mi := &file_proto_gnosql_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1104,7 +1311,7 @@ func (x *DocumentReadRequest) String() string {
 func (*DocumentReadRequest) ProtoMessage() {}
 
 func (x *DocumentReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[21]
+	mi := &file_proto_gnosql_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1117,7 +1324,7 @@ func (x *DocumentReadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentReadRequest.ProtoReflect.Descriptor instead.
 func (*DocumentReadRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{21}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DocumentReadRequest) GetDatabaseName() string {
@@ -1152,7 +1359,7 @@ type DocumentReadResponse struct {
 func (x *DocumentReadResponse) Reset() {
 	*x = DocumentReadResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[22]
+		mi := &file_proto_gnosql_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1165,7 +1372,7 @@ func (x *DocumentReadResponse) String() string {
 func (*DocumentReadResponse) ProtoMessage() {}
 
 func (x *DocumentReadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[22]
+	mi := &file_proto_gnosql_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +1385,7 @@ func (x *DocumentReadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentReadResponse.ProtoReflect.Descriptor instead.
 func (*DocumentReadResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{22}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *DocumentReadResponse) GetData() string {
@@ -1196,12 +1403,13 @@ type DocumentFilterRequest struct {
 	DatabaseName   string `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
 	CollectionName string `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
 	Filter         string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Explain        bool   `protobuf:"varint,4,opt,name=explain,proto3" json:"explain,omitempty"` // return the plan of the filter as JSON in DocumentFilterResponse.plan
 }
 
 func (x *DocumentFilterRequest) Reset() {
 	*x = DocumentFilterRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[23]
+		mi := &file_proto_gnosql_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1214,7 +1422,7 @@ func (x *DocumentFilterRequest) String() string {
 func (*DocumentFilterRequest) ProtoMessage() {}
 
 func (x *DocumentFilterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[23]
+	mi := &file_proto_gnosql_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1435,7 @@ func (x *DocumentFilterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentFilterRequest.ProtoReflect.Descriptor instead.
 func (*DocumentFilterRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{23}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *DocumentFilterRequest) GetDatabaseName() string {
@@ -1251,18 +1459,26 @@ func (x *DocumentFilterRequest) GetFilter() string {
 	return ""
 }
 
+func (x *DocumentFilterRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
 type DocumentFilterResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Plan string `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
 }
 
 func (x *DocumentFilterResponse) Reset() {
 	*x = DocumentFilterResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[24]
+		mi := &file_proto_gnosql_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1275,7 +1491,7 @@ func (x *DocumentFilterResponse) String() string {
 func (*DocumentFilterResponse) ProtoMessage() {}
 
 func (x *DocumentFilterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[24]
+	mi := &file_proto_gnosql_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1288,7 +1504,7 @@ func (x *DocumentFilterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentFilterResponse.ProtoReflect.Descriptor instead.
 func (*DocumentFilterResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{24}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *DocumentFilterResponse) GetData() string {
@@ -1298,6 +1514,13 @@ func (x *DocumentFilterResponse) GetData() string {
 	return ""
 }
 
+func (x *DocumentFilterResponse) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
 type DocumentUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1312,7 +1535,7 @@ type DocumentUpdateRequest struct {
 func (x *DocumentUpdateRequest) Reset() {
 	*x = DocumentUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[25]
+		mi := &file_proto_gnosql_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1325,7 +1548,7 @@ func (x *DocumentUpdateRequest) String() string {
 func (*DocumentUpdateRequest) ProtoMessage() {}
 
 func (x *DocumentUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[25]
+	mi := &file_proto_gnosql_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,7 +1561,7 @@ func (x *DocumentUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentUpdateRequest.ProtoReflect.Descriptor instead.
 func (*DocumentUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{25}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DocumentUpdateRequest) GetDatabaseName() string {
@@ -1380,7 +1603,7 @@ type DocumentUpdateResponse struct {
 func (x *DocumentUpdateResponse) Reset() {
 	*x = DocumentUpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[26]
+		mi := &file_proto_gnosql_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1393,7 +1616,7 @@ func (x *DocumentUpdateResponse) String() string {
 func (*DocumentUpdateResponse) ProtoMessage() {}
 
 func (x *DocumentUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[26]
+	mi := &file_proto_gnosql_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1406,7 +1629,7 @@ func (x *DocumentUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentUpdateResponse.ProtoReflect.Descriptor instead.
 func (*DocumentUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{26}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DocumentUpdateResponse) GetData() string {
@@ -1429,7 +1652,7 @@ type DocumentDeleteRequest struct {
 func (x *DocumentDeleteRequest) Reset() {
 	*x = DocumentDeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[27]
+		mi := &file_proto_gnosql_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1442,7 +1665,7 @@ func (x *DocumentDeleteRequest) String() string {
 func (*DocumentDeleteRequest) ProtoMessage() {}
 
 func (x *DocumentDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[27]
+	mi := &file_proto_gnosql_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1455,7 +1678,7 @@ func (x *DocumentDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentDeleteRequest.ProtoReflect.Descriptor instead.
 func (*DocumentDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{27}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DocumentDeleteRequest) GetDatabaseName() string {
@@ -1490,7 +1713,7 @@ type DocumentDeleteResponse struct {
 func (x *DocumentDeleteResponse) Reset() {
 	*x = DocumentDeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[28]
+		mi := &file_proto_gnosql_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1503,7 +1726,7 @@ func (x *DocumentDeleteResponse) String() string {
 func (*DocumentDeleteResponse) ProtoMessage() {}
 
 func (x *DocumentDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[28]
+	mi := &file_proto_gnosql_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1516,7 +1739,7 @@ func (x *DocumentDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentDeleteResponse.ProtoReflect.Descriptor instead.
 func (*DocumentDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{28}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *DocumentDeleteResponse) GetData() string {
@@ -1538,7 +1761,7 @@ type DocumentGetAllRequest struct {
 func (x *DocumentGetAllRequest) Reset() {
 	*x = DocumentGetAllRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[29]
+		mi := &file_proto_gnosql_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1551,7 +1774,7 @@ func (x *DocumentGetAllRequest) String() string {
 func (*DocumentGetAllRequest) ProtoMessage() {}
 
 func (x *DocumentGetAllRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[29]
+	mi := &file_proto_gnosql_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1564,7 +1787,7 @@ func (x *DocumentGetAllRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentGetAllRequest.ProtoReflect.Descriptor instead.
 func (*DocumentGetAllRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{29}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *DocumentGetAllRequest) GetDatabaseName() string {
@@ -1592,7 +1815,7 @@ type DocumentGetAllResponse struct {
 func (x *DocumentGetAllResponse) Reset() {
 	*x = DocumentGetAllResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_gnosql_proto_msgTypes[30]
+		mi := &file_proto_gnosql_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1605,7 +1828,7 @@ func (x *DocumentGetAllResponse) String() string {
 func (*DocumentGetAllResponse) ProtoMessage() {}
 
 func (x *DocumentGetAllResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gnosql_proto_msgTypes[30]
+	mi := &file_proto_gnosql_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1618,7 +1841,7 @@ func (x *DocumentGetAllResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentGetAllResponse.ProtoReflect.Descriptor instead.
 func (*DocumentGetAllResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gnosql_proto_rawDescGZIP(), []int{30}
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *DocumentGetAllResponse) GetData() string {
@@ -1628,394 +1851,1495 @@ func (x *DocumentGetAllResponse) GetData() string {
 	return ""
 }
 
-var File_proto_gnosql_proto protoreflect.FileDescriptor
+type LoginRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_proto_gnosql_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6e, 0x6f, 0x73, 0x71, 0x6c, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x0f, 0x0a, 0x0d, 0x4e,
-	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x22, 0x75, 0x0a, 0x15,
-	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x2c, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0x58, 0x0a, 0x10, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x46, 0x0a, 0x17, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x3b, 0x0a, 0x15, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
-	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0x2c, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2c,
-	0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x28, 0x0a, 0x12,
-	0x4c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x57, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x22,
-	0x77, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61,
-	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38,
-	0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3d, 0x0a, 0x17, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61,
-	0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x64, 0x0a, 0x16, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x45,
-	0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x75, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x7f, 0x0a, 0x15,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x0a,
-	0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x77, 0x0a, 0x13, 0x44,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64,
-	0x6f, 0x63, 0x49, 0x64, 0x22, 0x2a, 0x0a, 0x14, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x22, 0x7b, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a,
-	0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2c, 0x0a,
-	0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x95, 0x01, 0x0a, 0x15,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x22, 0x2c, 0x0a, 0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0x79, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61,
-	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26,
-	0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x16,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x63, 0x0a, 0x15, 0x44, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22,
-	0x2c, 0x0a, 0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xb6, 0x09,
-	0x0a, 0x0d, 0x47, 0x6e, 0x6f, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x50, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x77, 0x44, 0x61, 0x74, 0x61,
-	0x62, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x4f, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x61, 0x74, 0x61,
-	0x62, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
-	0x62, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x46, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x47, 0x65, 0x74, 0x41, 0x6c,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x4c, 0x6f, 0x61,
-	0x64, 0x54, 0x6f, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x4e, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x1a, 0x19, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x44, 0x69, 0x73, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x54, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65,
-	0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65,
-	0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x12,
-	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x47, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x61,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0e, 0x5a, 0x0c, 0x67, 0x6e, 0x6f, 0x73, 0x71, 0x6c,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 }
 
-var (
-	file_proto_gnosql_proto_rawDescOnce sync.Once
-	file_proto_gnosql_proto_rawDescData = file_proto_gnosql_proto_rawDesc
-)
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_gnosql_proto_rawDescGZIP() []byte {
-	file_proto_gnosql_proto_rawDescOnce.Do(func() {
-		file_proto_gnosql_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_gnosql_proto_rawDescData)
-	})
-	return file_proto_gnosql_proto_rawDescData
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_gnosql_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
-var file_proto_gnosql_proto_goTypes = []any{
-	(*NoRequestBody)(nil),            // 0: proto.NoRequestBody
-	(*DatabaseCreateRequest)(nil),    // 1: proto.DatabaseCreateRequest
-	(*DatabaseCreateResponse)(nil),   // 2: proto.DatabaseCreateResponse
-	(*DatabaseResponse)(nil),         // 3: proto.DatabaseResponse
-	(*DatabaseConnectResponse)(nil),  // 4: proto.DatabaseConnectResponse
-	(*DatabaseDeleteRequest)(nil),    // 5: proto.DatabaseDeleteRequest
-	(*DatabaseDeleteResponse)(nil),   // 6: proto.DatabaseDeleteResponse
-	(*DatabaseGetAllResponse)(nil),   // 7: proto.DatabaseGetAllResponse
-	(*LoadToDiskResponse)(nil),       // 8: proto.LoadToDiskResponse
-	(*CollectionInput)(nil),          // 9: proto.CollectionInput
-	(*CollectionCreateRequest)(nil),  // 10: proto.CollectionCreateRequest
-	(*CollectionCreateResponse)(nil), // 11: proto.CollectionCreateResponse
-	(*CollectionDeleteRequest)(nil),  // 12: proto.CollectionDeleteRequest
-	(*CollectionDeleteResponse)(nil), // 13: proto.CollectionDeleteResponse
-	(*CollectionGetAllRequest)(nil),  // 14: proto.CollectionGetAllRequest
-	(*CollectionGetAllResponse)(nil), // 15: proto.CollectionGetAllResponse
-	(*CollectionStatsRequest)(nil),   // 16: proto.CollectionStatsRequest
-	(*CollectionStatsResponse)(nil),  // 17: proto.CollectionStatsResponse
-	(*CollectionStats)(nil),          // 18: proto.CollectionStats
-	(*DocumentCreateRequest)(nil),    // 19: proto.DocumentCreateRequest
-	(*DocumentCreateResponse)(nil),   // 20: proto.DocumentCreateResponse
-	(*DocumentReadRequest)(nil),      // 21: proto.DocumentReadRequest
-	(*DocumentReadResponse)(nil),     // 22: proto.DocumentReadResponse
-	(*DocumentFilterRequest)(nil),    // 23: proto.DocumentFilterRequest
-	(*DocumentFilterResponse)(nil),   // 24: proto.DocumentFilterResponse
-	(*DocumentUpdateRequest)(nil),    // 25: proto.DocumentUpdateRequest
-	(*DocumentUpdateResponse)(nil),   // 26: proto.DocumentUpdateResponse
-	(*DocumentDeleteRequest)(nil),    // 27: proto.DocumentDeleteRequest
-	(*DocumentDeleteResponse)(nil),   // 28: proto.DocumentDeleteResponse
-	(*DocumentGetAllRequest)(nil),    // 29: proto.DocumentGetAllRequest
-	(*DocumentGetAllResponse)(nil),   // 30: proto.DocumentGetAllResponse
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_gnosql_proto_depIdxs = []int32{
-	9,  // 0: proto.DatabaseCreateRequest.collections:type_name -> proto.CollectionInput
-	3,  // 1: proto.DatabaseConnectResponse.data:type_name -> proto.DatabaseResponse
-	9,  // 2: proto.CollectionCreateRequest.collections:type_name -> proto.CollectionInput
-	18, // 3: proto.CollectionStatsResponse.data:type_name -> proto.CollectionStats
-	1,  // 4: proto.GnoSQLService.CreateNewDatabase:input_type -> proto.DatabaseCreateRequest
-	1,  // 5: proto.GnoSQLService.ConnectDatabase:input_type -> proto.DatabaseCreateRequest
-	5,  // 6: proto.GnoSQLService.DeleteDatabase:input_type -> proto.DatabaseDeleteRequest
-	0,  // 7: proto.GnoSQLService.GetAllDatabases:input_type -> proto.NoRequestBody
-	0,  // 8: proto.GnoSQLService.LoadToDisk:input_type -> proto.NoRequestBody
-	10, // 9: proto.GnoSQLService.CreateNewCollection:input_type -> proto.CollectionCreateRequest
-	12, // 10: proto.GnoSQLService.DeleteCollections:input_type -> proto.CollectionDeleteRequest
-	14, // 11: proto.GnoSQLService.GetAllCollections:input_type -> proto.CollectionGetAllRequest
-	16, // 12: proto.GnoSQLService.GetCollectionStats:input_type -> proto.CollectionStatsRequest
-	19, // 13: proto.GnoSQLService.CreateDocument:input_type -> proto.DocumentCreateRequest
-	21, // 14: proto.GnoSQLService.ReadDocument:input_type -> proto.DocumentReadRequest
-	23, // 15: proto.GnoSQLService.FilterDocument:input_type -> proto.DocumentFilterRequest
-	25, // 16: proto.GnoSQLService.UpdateDocument:input_type -> proto.DocumentUpdateRequest
-	27, // 17: proto.GnoSQLService.DeleteDocument:input_type -> proto.DocumentDeleteRequest
-	29, // 18: proto.GnoSQLService.GetAllDocuments:input_type -> proto.DocumentGetAllRequest
-	2,  // 19: proto.GnoSQLService.CreateNewDatabase:output_type -> proto.DatabaseCreateResponse
-	4,  // 20: proto.GnoSQLService.ConnectDatabase:output_type -> proto.DatabaseConnectResponse
-	6,  // 21: proto.GnoSQLService.DeleteDatabase:output_type -> proto.DatabaseDeleteResponse
-	7,  // 22: proto.GnoSQLService.GetAllDatabases:output_type -> proto.DatabaseGetAllResponse
-	8,  // 23: proto.GnoSQLService.LoadToDisk:output_type -> proto.LoadToDiskResponse
-	11, // 24: proto.GnoSQLService.CreateNewCollection:output_type -> proto.CollectionCreateResponse
-	13, // 25: proto.GnoSQLService.DeleteCollections:output_type -> proto.CollectionDeleteResponse
-	15, // 26: proto.GnoSQLService.GetAllCollections:output_type -> proto.CollectionGetAllResponse
-	17, // 27: proto.GnoSQLService.GetCollectionStats:output_type -> proto.CollectionStatsResponse
-	20, // 28: proto.GnoSQLService.CreateDocument:output_type -> proto.DocumentCreateResponse
-	22, // 29: proto.GnoSQLService.ReadDocument:output_type -> proto.DocumentReadResponse
-	24, // 30: proto.GnoSQLService.FilterDocument:output_type -> proto.DocumentFilterResponse
-	26, // 31: proto.GnoSQLService.UpdateDocument:output_type -> proto.DocumentUpdateResponse
-	28, // 32: proto.GnoSQLService.DeleteDocument:output_type -> proto.DocumentDeleteResponse
-	30, // 33: proto.GnoSQLService.GetAllDocuments:output_type -> proto.DocumentGetAllResponse
-	19, // [19:34] is the sub-list for method output_type
-	4,  // [4:19] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{32}
 }
 
-func init() { file_proto_gnosql_proto_init() }
-func file_proto_gnosql_proto_init() {
-	if File_proto_gnosql_proto != nil {
-		return
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_gnosql_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*NoRequestBody); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_gnosql_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseCreateRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_gnosql_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseCreateResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_proto_gnosql_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LoginResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type SnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_proto_gnosql_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseConnectResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{34}
+}
+
+type SnapshotChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path  string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Seq   uint64 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	LogId string `protobuf:"bytes,4,opt,name=logId,proto3" json:"logId,omitempty"`
+}
+
+func (x *SnapshotChunk) Reset() {
+	*x = SnapshotChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChunk) ProtoMessage() {}
+
+func (x *SnapshotChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChunk.ProtoReflect.Descriptor instead.
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SnapshotChunk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SnapshotChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SnapshotChunk) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SnapshotChunk) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+type ReplicationStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromSeq uint64 `protobuf:"varint,1,opt,name=fromSeq,proto3" json:"fromSeq,omitempty"`
+	LogId   string `protobuf:"bytes,2,opt,name=logId,proto3" json:"logId,omitempty"`
+}
+
+func (x *ReplicationStreamRequest) Reset() {
+	*x = ReplicationStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStreamRequest) ProtoMessage() {}
+
+func (x *ReplicationStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStreamRequest.ProtoReflect.Descriptor instead.
+func (*ReplicationStreamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReplicationStreamRequest) GetFromSeq() uint64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *ReplicationStreamRequest) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+type ReplicationEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq       uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Data      []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	LeaderSeq uint64 `protobuf:"varint,3,opt,name=leaderSeq,proto3" json:"leaderSeq,omitempty"`
+	LogId     string `protobuf:"bytes,4,opt,name=logId,proto3" json:"logId,omitempty"`
+}
+
+func (x *ReplicationEntry) Reset() {
+	*x = ReplicationEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationEntry) ProtoMessage() {}
+
+func (x *ReplicationEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationEntry.ProtoReflect.Descriptor instead.
+func (*ReplicationEntry) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReplicationEntry) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ReplicationEntry) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReplicationEntry) GetLeaderSeq() uint64 {
+	if x != nil {
+		return x.LeaderSeq
+	}
+	return 0
+}
+
+func (x *ReplicationEntry) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+type RaftVoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term         uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	CandidateId  string `protobuf:"bytes,2,opt,name=candidateId,proto3" json:"candidateId,omitempty"`
+	LastLogIndex uint64 `protobuf:"varint,3,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"`
+	LastLogTerm  uint64 `protobuf:"varint,4,opt,name=lastLogTerm,proto3" json:"lastLogTerm,omitempty"`
+}
+
+func (x *RaftVoteRequest) Reset() {
+	*x = RaftVoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftVoteRequest) ProtoMessage() {}
+
+func (x *RaftVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftVoteRequest.ProtoReflect.Descriptor instead.
+func (*RaftVoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RaftVoteRequest) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftVoteRequest) GetCandidateId() string {
+	if x != nil {
+		return x.CandidateId
+	}
+	return ""
+}
+
+func (x *RaftVoteRequest) GetLastLogIndex() uint64 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+func (x *RaftVoteRequest) GetLastLogTerm() uint64 {
+	if x != nil {
+		return x.LastLogTerm
+	}
+	return 0
+}
+
+type RaftVoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term        uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	VoteGranted bool   `protobuf:"varint,2,opt,name=voteGranted,proto3" json:"voteGranted,omitempty"`
+}
+
+func (x *RaftVoteResponse) Reset() {
+	*x = RaftVoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftVoteResponse) ProtoMessage() {}
+
+func (x *RaftVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftVoteResponse.ProtoReflect.Descriptor instead.
+func (*RaftVoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RaftVoteResponse) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftVoteResponse) GetVoteGranted() bool {
+	if x != nil {
+		return x.VoteGranted
+	}
+	return false
+}
+
+type RaftLogEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Term  uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Data  []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RaftLogEntry) Reset() {
+	*x = RaftLogEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftLogEntry) ProtoMessage() {}
+
+func (x *RaftLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftLogEntry.ProtoReflect.Descriptor instead.
+func (*RaftLogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RaftLogEntry) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RaftLogEntry) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftLogEntry) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RaftAppendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term         uint64          `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId     string          `protobuf:"bytes,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	PrevLogIndex uint64          `protobuf:"varint,3,opt,name=prevLogIndex,proto3" json:"prevLogIndex,omitempty"`
+	PrevLogTerm  uint64          `protobuf:"varint,4,opt,name=prevLogTerm,proto3" json:"prevLogTerm,omitempty"`
+	Entries      []*RaftLogEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	LeaderCommit uint64          `protobuf:"varint,6,opt,name=leaderCommit,proto3" json:"leaderCommit,omitempty"`
+}
+
+func (x *RaftAppendRequest) Reset() {
+	*x = RaftAppendRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftAppendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftAppendRequest) ProtoMessage() {}
+
+func (x *RaftAppendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftAppendRequest.ProtoReflect.Descriptor instead.
+func (*RaftAppendRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RaftAppendRequest) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftAppendRequest) GetLeaderId() string {
+	if x != nil {
+		return x.LeaderId
+	}
+	return ""
+}
+
+func (x *RaftAppendRequest) GetPrevLogIndex() uint64 {
+	if x != nil {
+		return x.PrevLogIndex
+	}
+	return 0
+}
+
+func (x *RaftAppendRequest) GetPrevLogTerm() uint64 {
+	if x != nil {
+		return x.PrevLogTerm
+	}
+	return 0
+}
+
+func (x *RaftAppendRequest) GetEntries() []*RaftLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *RaftAppendRequest) GetLeaderCommit() uint64 {
+	if x != nil {
+		return x.LeaderCommit
+	}
+	return 0
+}
+
+type RaftAppendResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term          uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success       bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ConflictIndex uint64 `protobuf:"varint,3,opt,name=conflictIndex,proto3" json:"conflictIndex,omitempty"`
+}
+
+func (x *RaftAppendResponse) Reset() {
+	*x = RaftAppendResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftAppendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftAppendResponse) ProtoMessage() {}
+
+func (x *RaftAppendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftAppendResponse.ProtoReflect.Descriptor instead.
+func (*RaftAppendResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RaftAppendResponse) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftAppendResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RaftAppendResponse) GetConflictIndex() uint64 {
+	if x != nil {
+		return x.ConflictIndex
+	}
+	return 0
+}
+
+type RaftSnapshotChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term              uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId          string `protobuf:"bytes,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	LastIncludedIndex uint64 `protobuf:"varint,3,opt,name=lastIncludedIndex,proto3" json:"lastIncludedIndex,omitempty"`
+	LastIncludedTerm  uint64 `protobuf:"varint,4,opt,name=lastIncludedTerm,proto3" json:"lastIncludedTerm,omitempty"`
+	Data              []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RaftSnapshotChunk) Reset() {
+	*x = RaftSnapshotChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftSnapshotChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftSnapshotChunk) ProtoMessage() {}
+
+func (x *RaftSnapshotChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftSnapshotChunk.ProtoReflect.Descriptor instead.
+func (*RaftSnapshotChunk) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RaftSnapshotChunk) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftSnapshotChunk) GetLeaderId() string {
+	if x != nil {
+		return x.LeaderId
+	}
+	return ""
+}
+
+func (x *RaftSnapshotChunk) GetLastIncludedIndex() uint64 {
+	if x != nil {
+		return x.LastIncludedIndex
+	}
+	return 0
+}
+
+func (x *RaftSnapshotChunk) GetLastIncludedTerm() uint64 {
+	if x != nil {
+		return x.LastIncludedTerm
+	}
+	return 0
+}
+
+func (x *RaftSnapshotChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RaftSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+}
+
+func (x *RaftSnapshotResponse) Reset() {
+	*x = RaftSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftSnapshotResponse) ProtoMessage() {}
+
+func (x *RaftSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*RaftSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RaftSnapshotResponse) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+type RaftProposeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RaftProposeRequest) Reset() {
+	*x = RaftProposeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftProposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftProposeRequest) ProtoMessage() {}
+
+func (x *RaftProposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftProposeRequest.ProtoReflect.Descriptor instead.
+func (*RaftProposeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RaftProposeRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RaftProposeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *RaftProposeResponse) Reset() {
+	*x = RaftProposeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_gnosql_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftProposeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftProposeResponse) ProtoMessage() {}
+
+func (x *RaftProposeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gnosql_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftProposeResponse.ProtoReflect.Descriptor instead.
+func (*RaftProposeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gnosql_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RaftProposeResponse) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+var File_proto_gnosql_proto protoreflect.FileDescriptor
+
+var file_proto_gnosql_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6e, 0x6f, 0x73, 0x71, 0x6c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x0f, 0x0a, 0x0d, 0x4e,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x22, 0xa9, 0x01, 0x0a,
+	0x15, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x85, 0x01, 0x0a, 0x13, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x4d, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x4d, 0x42, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x2c, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x58,
+	0x0a, 0x10, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x46, 0x0a, 0x17, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x3b, 0x0a, 0x15, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2c, 0x0a,
+	0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2c, 0x0a, 0x16, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x28, 0x0a, 0x12, 0x4c, 0x6f, 0x61,
+	0x64, 0x54, 0x6f, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x97, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x68, 0x61, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x68, 0x61, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x42, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x42, 0x22, 0x77, 0x0a,
+	0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3d, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x64, 0x0a, 0x16, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x45, 0x0a, 0x17,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0xd1, 0x05, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72,
+	0x65, 0x73, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65,
+	0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x63, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x75, 0x6e, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61,
+	0x74, 0x69, 0x6f, 0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x48, 0x69, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x11, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69,
+	0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x17, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x2c, 0x0a, 0x11, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x0a, 0x16, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x77, 0x0a, 0x13, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x6f, 0x63,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x22,
+	0x2a, 0x0a, 0x14, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x95, 0x01, 0x0a, 0x15,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70,
+	0x6c, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c,
+	0x61, 0x69, 0x6e, 0x22, 0x40, 0x0a, 0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x6c, 0x61, 0x6e, 0x22, 0x95, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x6f, 0x63, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x49,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x0a,
+	0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x79, 0x0a, 0x15, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x63, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2c, 0x0a, 0x16, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x46, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22,
+	0x23, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x18, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x6f, 0x67, 0x49, 0x64, 0x22, 0x6c, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1c,
+	0x0a, 0x09, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x6f, 0x67, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67,
+	0x49, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x52, 0x61, 0x66, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x54, 0x65,
+	0x72, 0x6d, 0x22, 0x48, 0x0a, 0x10, 0x52, 0x61, 0x66, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x6f,
+	0x74, 0x65, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x76, 0x6f, 0x74, 0x65, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x22, 0x4c, 0x0a, 0x0c,
+	0x52, 0x61, 0x66, 0x74, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xdc, 0x01, 0x0a, 0x11, 0x52,
+	0x61, 0x66, 0x74, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x74, 0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x54,
+	0x65, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x4c,
+	0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x2d, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x61, 0x66, 0x74, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x68, 0x0a, 0x12, 0x52, 0x61, 0x66,
+	0x74, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0xb1, 0x01, 0x0a, 0x11, 0x52, 0x61, 0x66, 0x74, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x61, 0x73,
+	0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x49,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x54,
+	0x65, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2a, 0x0a, 0x14, 0x52, 0x61, 0x66, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x22, 0x28, 0x0a, 0x12, 0x52, 0x61, 0x66, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2b, 0x0a,
+	0x13, 0x52, 0x61, 0x66, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x32, 0xea, 0x09, 0x0a, 0x0d, 0x47,
+	0x6e, 0x6f, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x11,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x77, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x6f,
+	0x44, 0x69, 0x73, 0x6b, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e,
+	0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a,
+	0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d,
+	0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a,
+	0x0c, 0x52, 0x65, 0x61, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x9f, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3a,
+	0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0f, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x30, 0x01, 0x32, 0xa1, 0x02, 0x0a, 0x0b, 0x52, 0x61,
+	0x66, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x61, 0x66, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x56, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0d, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x66,
+	0x74, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4a, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x40, 0x0a, 0x07, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
+	0x61, 0x66, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x50, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0e, 0x5a,
+	0x0c, 0x67, 0x6e, 0x6f, 0x73, 0x71, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_gnosql_proto_rawDescOnce sync.Once
+	file_proto_gnosql_proto_rawDescData = file_proto_gnosql_proto_rawDesc
+)
+
+func file_proto_gnosql_proto_rawDescGZIP() []byte {
+	file_proto_gnosql_proto_rawDescOnce.Do(func() {
+		file_proto_gnosql_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_gnosql_proto_rawDescData)
+	})
+	return file_proto_gnosql_proto_rawDescData
+}
+
+var file_proto_gnosql_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_proto_gnosql_proto_goTypes = []any{
+	(*NoRequestBody)(nil),            // 0: proto.NoRequestBody
+	(*DatabaseCreateRequest)(nil),    // 1: proto.DatabaseCreateRequest
+	(*DatabaseConfigInput)(nil),      // 2: proto.DatabaseConfigInput
+	(*DatabaseCreateResponse)(nil),   // 3: proto.DatabaseCreateResponse
+	(*DatabaseResponse)(nil),         // 4: proto.DatabaseResponse
+	(*DatabaseConnectResponse)(nil),  // 5: proto.DatabaseConnectResponse
+	(*DatabaseDeleteRequest)(nil),    // 6: proto.DatabaseDeleteRequest
+	(*DatabaseDeleteResponse)(nil),   // 7: proto.DatabaseDeleteResponse
+	(*DatabaseGetAllResponse)(nil),   // 8: proto.DatabaseGetAllResponse
+	(*LoadToDiskResponse)(nil),       // 9: proto.LoadToDiskResponse
+	(*CollectionInput)(nil),          // 10: proto.CollectionInput
+	(*CollectionCreateRequest)(nil),  // 11: proto.CollectionCreateRequest
+	(*CollectionCreateResponse)(nil), // 12: proto.CollectionCreateResponse
+	(*CollectionDeleteRequest)(nil),  // 13: proto.CollectionDeleteRequest
+	(*CollectionDeleteResponse)(nil), // 14: proto.CollectionDeleteResponse
+	(*CollectionGetAllRequest)(nil),  // 15: proto.CollectionGetAllRequest
+	(*CollectionGetAllResponse)(nil), // 16: proto.CollectionGetAllResponse
+	(*CollectionStatsRequest)(nil),   // 17: proto.CollectionStatsRequest
+	(*CollectionStatsResponse)(nil),  // 18: proto.CollectionStatsResponse
+	(*CollectionStats)(nil),          // 19: proto.CollectionStats
+	(*DocumentCreateRequest)(nil),    // 20: proto.DocumentCreateRequest
+	(*DocumentCreateResponse)(nil),   // 21: proto.DocumentCreateResponse
+	(*DocumentReadRequest)(nil),      // 22: proto.DocumentReadRequest
+	(*DocumentReadResponse)(nil),     // 23: proto.DocumentReadResponse
+	(*DocumentFilterRequest)(nil),    // 24: proto.DocumentFilterRequest
+	(*DocumentFilterResponse)(nil),   // 25: proto.DocumentFilterResponse
+	(*DocumentUpdateRequest)(nil),    // 26: proto.DocumentUpdateRequest
+	(*DocumentUpdateResponse)(nil),   // 27: proto.DocumentUpdateResponse
+	(*DocumentDeleteRequest)(nil),    // 28: proto.DocumentDeleteRequest
+	(*DocumentDeleteResponse)(nil),   // 29: proto.DocumentDeleteResponse
+	(*DocumentGetAllRequest)(nil),    // 30: proto.DocumentGetAllRequest
+	(*DocumentGetAllResponse)(nil),   // 31: proto.DocumentGetAllResponse
+	(*LoginRequest)(nil),             // 32: proto.LoginRequest
+	(*LoginResponse)(nil),            // 33: proto.LoginResponse
+	(*SnapshotRequest)(nil),          // 34: proto.SnapshotRequest
+	(*SnapshotChunk)(nil),            // 35: proto.SnapshotChunk
+	(*ReplicationStreamRequest)(nil), // 36: proto.ReplicationStreamRequest
+	(*ReplicationEntry)(nil),         // 37: proto.ReplicationEntry
+	(*RaftVoteRequest)(nil),          // 38: proto.RaftVoteRequest
+	(*RaftVoteResponse)(nil),         // 39: proto.RaftVoteResponse
+	(*RaftLogEntry)(nil),             // 40: proto.RaftLogEntry
+	(*RaftAppendRequest)(nil),        // 41: proto.RaftAppendRequest
+	(*RaftAppendResponse)(nil),       // 42: proto.RaftAppendResponse
+	(*RaftSnapshotChunk)(nil),        // 43: proto.RaftSnapshotChunk
+	(*RaftSnapshotResponse)(nil),     // 44: proto.RaftSnapshotResponse
+	(*RaftProposeRequest)(nil),       // 45: proto.RaftProposeRequest
+	(*RaftProposeResponse)(nil),      // 46: proto.RaftProposeResponse
+}
+var file_proto_gnosql_proto_depIdxs = []int32{
+	10, // 0: proto.DatabaseCreateRequest.collections:type_name -> proto.CollectionInput
+	2,  // 1: proto.DatabaseCreateRequest.config:type_name -> proto.DatabaseConfigInput
+	4,  // 2: proto.DatabaseConnectResponse.data:type_name -> proto.DatabaseResponse
+	10, // 3: proto.CollectionCreateRequest.collections:type_name -> proto.CollectionInput
+	19, // 4: proto.CollectionStatsResponse.data:type_name -> proto.CollectionStats
+	40, // 5: proto.RaftAppendRequest.entries:type_name -> proto.RaftLogEntry
+	1,  // 6: proto.GnoSQLService.CreateNewDatabase:input_type -> proto.DatabaseCreateRequest
+	1,  // 7: proto.GnoSQLService.ConnectDatabase:input_type -> proto.DatabaseCreateRequest
+	6,  // 8: proto.GnoSQLService.DeleteDatabase:input_type -> proto.DatabaseDeleteRequest
+	0,  // 9: proto.GnoSQLService.GetAllDatabases:input_type -> proto.NoRequestBody
+	0,  // 10: proto.GnoSQLService.LoadToDisk:input_type -> proto.NoRequestBody
+	11, // 11: proto.GnoSQLService.CreateNewCollection:input_type -> proto.CollectionCreateRequest
+	13, // 12: proto.GnoSQLService.DeleteCollections:input_type -> proto.CollectionDeleteRequest
+	15, // 13: proto.GnoSQLService.GetAllCollections:input_type -> proto.CollectionGetAllRequest
+	17, // 14: proto.GnoSQLService.GetCollectionStats:input_type -> proto.CollectionStatsRequest
+	20, // 15: proto.GnoSQLService.CreateDocument:input_type -> proto.DocumentCreateRequest
+	22, // 16: proto.GnoSQLService.ReadDocument:input_type -> proto.DocumentReadRequest
+	24, // 17: proto.GnoSQLService.FilterDocument:input_type -> proto.DocumentFilterRequest
+	26, // 18: proto.GnoSQLService.UpdateDocument:input_type -> proto.DocumentUpdateRequest
+	28, // 19: proto.GnoSQLService.DeleteDocument:input_type -> proto.DocumentDeleteRequest
+	30, // 20: proto.GnoSQLService.GetAllDocuments:input_type -> proto.DocumentGetAllRequest
+	32, // 21: proto.GnoSQLService.Login:input_type -> proto.LoginRequest
+	34, // 22: proto.ReplicationService.Snapshot:input_type -> proto.SnapshotRequest
+	36, // 23: proto.ReplicationService.StreamMutations:input_type -> proto.ReplicationStreamRequest
+	38, // 24: proto.RaftService.RequestVote:input_type -> proto.RaftVoteRequest
+	41, // 25: proto.RaftService.AppendEntries:input_type -> proto.RaftAppendRequest
+	43, // 26: proto.RaftService.InstallSnapshot:input_type -> proto.RaftSnapshotChunk
+	45, // 27: proto.RaftService.Propose:input_type -> proto.RaftProposeRequest
+	3,  // 28: proto.GnoSQLService.CreateNewDatabase:output_type -> proto.DatabaseCreateResponse
+	5,  // 29: proto.GnoSQLService.ConnectDatabase:output_type -> proto.DatabaseConnectResponse
+	7,  // 30: proto.GnoSQLService.DeleteDatabase:output_type -> proto.DatabaseDeleteResponse
+	8,  // 31: proto.GnoSQLService.GetAllDatabases:output_type -> proto.DatabaseGetAllResponse
+	9,  // 32: proto.GnoSQLService.LoadToDisk:output_type -> proto.LoadToDiskResponse
+	12, // 33: proto.GnoSQLService.CreateNewCollection:output_type -> proto.CollectionCreateResponse
+	14, // 34: proto.GnoSQLService.DeleteCollections:output_type -> proto.CollectionDeleteResponse
+	16, // 35: proto.GnoSQLService.GetAllCollections:output_type -> proto.CollectionGetAllResponse
+	18, // 36: proto.GnoSQLService.GetCollectionStats:output_type -> proto.CollectionStatsResponse
+	21, // 37: proto.GnoSQLService.CreateDocument:output_type -> proto.DocumentCreateResponse
+	23, // 38: proto.GnoSQLService.ReadDocument:output_type -> proto.DocumentReadResponse
+	25, // 39: proto.GnoSQLService.FilterDocument:output_type -> proto.DocumentFilterResponse
+	27, // 40: proto.GnoSQLService.UpdateDocument:output_type -> proto.DocumentUpdateResponse
+	29, // 41: proto.GnoSQLService.DeleteDocument:output_type -> proto.DocumentDeleteResponse
+	31, // 42: proto.GnoSQLService.GetAllDocuments:output_type -> proto.DocumentGetAllResponse
+	33, // 43: proto.GnoSQLService.Login:output_type -> proto.LoginResponse
+	35, // 44: proto.ReplicationService.Snapshot:output_type -> proto.SnapshotChunk
+	37, // 45: proto.ReplicationService.StreamMutations:output_type -> proto.ReplicationEntry
+	39, // 46: proto.RaftService.RequestVote:output_type -> proto.RaftVoteResponse
+	42, // 47: proto.RaftService.AppendEntries:output_type -> proto.RaftAppendResponse
+	44, // 48: proto.RaftService.InstallSnapshot:output_type -> proto.RaftSnapshotResponse
+	46, // 49: proto.RaftService.Propose:output_type -> proto.RaftProposeResponse
+	28, // [28:50] is the sub-list for method output_type
+	6,  // [6:28] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_gnosql_proto_init() }
+func file_proto_gnosql_proto_init() {
+	if File_proto_gnosql_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_gnosql_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*NoRequestBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*DatabaseCreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*DatabaseConfigInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*DatabaseCreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*DatabaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseDeleteRequest); i {
+			switch v := v.(*DatabaseConnectResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2027,7 +3351,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseDeleteResponse); i {
+			switch v := v.(*DatabaseDeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2039,7 +3363,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*DatabaseGetAllResponse); i {
+			switch v := v.(*DatabaseDeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2051,7 +3375,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*LoadToDiskResponse); i {
+			switch v := v.(*DatabaseGetAllResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2063,7 +3387,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionInput); i {
+			switch v := v.(*LoadToDiskResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2075,7 +3399,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionCreateRequest); i {
+			switch v := v.(*CollectionInput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2087,7 +3411,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionCreateResponse); i {
+			switch v := v.(*CollectionCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2099,7 +3423,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionDeleteRequest); i {
+			switch v := v.(*CollectionCreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2111,7 +3435,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionDeleteResponse); i {
+			switch v := v.(*CollectionDeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2123,7 +3447,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionGetAllRequest); i {
+			switch v := v.(*CollectionDeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2135,7 +3459,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionGetAllResponse); i {
+			switch v := v.(*CollectionGetAllRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2147,7 +3471,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[16].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionStatsRequest); i {
+			switch v := v.(*CollectionGetAllResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2159,7 +3483,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[17].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionStatsResponse); i {
+			switch v := v.(*CollectionStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2171,7 +3495,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[18].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectionStats); i {
+			switch v := v.(*CollectionStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2183,7 +3507,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[19].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentCreateRequest); i {
+			switch v := v.(*CollectionStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2195,7 +3519,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[20].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentCreateResponse); i {
+			switch v := v.(*DocumentCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2207,7 +3531,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[21].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentReadRequest); i {
+			switch v := v.(*DocumentCreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2219,7 +3543,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[22].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentReadResponse); i {
+			switch v := v.(*DocumentReadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2231,7 +3555,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[23].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentFilterRequest); i {
+			switch v := v.(*DocumentReadResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2243,7 +3567,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[24].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentFilterResponse); i {
+			switch v := v.(*DocumentFilterRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2255,7 +3579,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[25].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentUpdateRequest); i {
+			switch v := v.(*DocumentFilterResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2267,7 +3591,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[26].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentUpdateResponse); i {
+			switch v := v.(*DocumentUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2279,7 +3603,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[27].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentDeleteRequest); i {
+			switch v := v.(*DocumentUpdateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2291,7 +3615,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[28].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentDeleteResponse); i {
+			switch v := v.(*DocumentDeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2303,7 +3627,7 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[29].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentGetAllRequest); i {
+			switch v := v.(*DocumentDeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2315,6 +3639,18 @@ func file_proto_gnosql_proto_init() {
 			}
 		}
 		file_proto_gnosql_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*DocumentGetAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[31].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentGetAllResponse); i {
 			case 0:
 				return &v.state
@@ -2326,6 +3662,186 @@ func file_proto_gnosql_proto_init() {
 				return nil
 			}
 		}
+		file_proto_gnosql_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*LoginRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*LoginResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*ReplicationStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*ReplicationEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftVoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftVoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftLogEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftAppendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftAppendResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftSnapshotChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftProposeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_gnosql_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*RaftProposeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2333,9 +3849,9 @@ func file_proto_gnosql_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_gnosql_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   47,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_gnosql_proto_goTypes,
 		DependencyIndexes: file_proto_gnosql_proto_depIdxs,
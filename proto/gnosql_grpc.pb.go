@@ -34,6 +34,7 @@ const (
 	GnoSQLService_UpdateDocument_FullMethodName      = "/proto.GnoSQLService/UpdateDocument"
 	GnoSQLService_DeleteDocument_FullMethodName      = "/proto.GnoSQLService/DeleteDocument"
 	GnoSQLService_GetAllDocuments_FullMethodName     = "/proto.GnoSQLService/GetAllDocuments"
+	GnoSQLService_Login_FullMethodName               = "/proto.GnoSQLService/Login"
 )
 
 // GnoSQLServiceClient is the client API for GnoSQLService service.
@@ -55,6 +56,7 @@ type GnoSQLServiceClient interface {
 	UpdateDocument(ctx context.Context, in *DocumentUpdateRequest, opts ...grpc.CallOption) (*DocumentUpdateResponse, error)
 	DeleteDocument(ctx context.Context, in *DocumentDeleteRequest, opts ...grpc.CallOption) (*DocumentDeleteResponse, error)
 	GetAllDocuments(ctx context.Context, in *DocumentGetAllRequest, opts ...grpc.CallOption) (*DocumentGetAllResponse, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 }
 
 type gnoSQLServiceClient struct {
@@ -215,6 +217,16 @@ func (c *gnoSQLServiceClient) GetAllDocuments(ctx context.Context, in *DocumentG
 	return out, nil
 }
 
+func (c *gnoSQLServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, GnoSQLService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GnoSQLServiceServer is the server API for GnoSQLService service.
 // All implementations must embed UnimplementedGnoSQLServiceServer
 // for forward compatibility
@@ -234,6 +246,7 @@ type GnoSQLServiceServer interface {
 	UpdateDocument(context.Context, *DocumentUpdateRequest) (*DocumentUpdateResponse, error)
 	DeleteDocument(context.Context, *DocumentDeleteRequest) (*DocumentDeleteResponse, error)
 	GetAllDocuments(context.Context, *DocumentGetAllRequest) (*DocumentGetAllResponse, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	mustEmbedUnimplementedGnoSQLServiceServer()
 }
 
@@ -286,6 +299,9 @@ func (UnimplementedGnoSQLServiceServer) DeleteDocument(context.Context, *Documen
 func (UnimplementedGnoSQLServiceServer) GetAllDocuments(context.Context, *DocumentGetAllRequest) (*DocumentGetAllResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllDocuments not implemented")
 }
+func (UnimplementedGnoSQLServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
 func (UnimplementedGnoSQLServiceServer) mustEmbedUnimplementedGnoSQLServiceServer() {}
 
 // UnsafeGnoSQLServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -569,6 +585,24 @@ func _GnoSQLService_GetAllDocuments_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GnoSQLService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnoSQLServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GnoSQLService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnoSQLServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GnoSQLService_ServiceDesc is the grpc.ServiceDesc for GnoSQLService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -636,7 +670,434 @@ var GnoSQLService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllDocuments",
 			Handler:    _GnoSQLService_GetAllDocuments_Handler,
 		},
+		{
+			MethodName: "Login",
+			Handler:    _GnoSQLService_Login_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/gnosql.proto",
 }
+
+const (
+	ReplicationService_Snapshot_FullMethodName        = "/proto.ReplicationService/Snapshot"
+	ReplicationService_StreamMutations_FullMethodName = "/proto.ReplicationService/StreamMutations"
+)
+
+// ReplicationServiceClient is the client API for ReplicationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplicationServiceClient interface {
+	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (ReplicationService_SnapshotClient, error)
+	StreamMutations(ctx context.Context, in *ReplicationStreamRequest, opts ...grpc.CallOption) (ReplicationService_StreamMutationsClient, error)
+}
+
+type replicationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationServiceClient(cc grpc.ClientConnInterface) ReplicationServiceClient {
+	return &replicationServiceClient{cc}
+}
+
+func (c *replicationServiceClient) Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (ReplicationService_SnapshotClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReplicationService_ServiceDesc.Streams[0], ReplicationService_Snapshot_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationServiceSnapshotClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReplicationService_SnapshotClient interface {
+	Recv() (*SnapshotChunk, error)
+	grpc.ClientStream
+}
+
+type replicationServiceSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicationServiceSnapshotClient) Recv() (*SnapshotChunk, error) {
+	m := new(SnapshotChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *replicationServiceClient) StreamMutations(ctx context.Context, in *ReplicationStreamRequest, opts ...grpc.CallOption) (ReplicationService_StreamMutationsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReplicationService_ServiceDesc.Streams[1], ReplicationService_StreamMutations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationServiceStreamMutationsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReplicationService_StreamMutationsClient interface {
+	Recv() (*ReplicationEntry, error)
+	grpc.ClientStream
+}
+
+type replicationServiceStreamMutationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicationServiceStreamMutationsClient) Recv() (*ReplicationEntry, error) {
+	m := new(ReplicationEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReplicationServiceServer is the server API for ReplicationService service.
+// All implementations must embed UnimplementedReplicationServiceServer
+// for forward compatibility
+type ReplicationServiceServer interface {
+	Snapshot(*SnapshotRequest, ReplicationService_SnapshotServer) error
+	StreamMutations(*ReplicationStreamRequest, ReplicationService_StreamMutationsServer) error
+	mustEmbedUnimplementedReplicationServiceServer()
+}
+
+// UnimplementedReplicationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReplicationServiceServer struct {
+}
+
+func (UnimplementedReplicationServiceServer) Snapshot(*SnapshotRequest, ReplicationService_SnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedReplicationServiceServer) StreamMutations(*ReplicationStreamRequest, ReplicationService_StreamMutationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMutations not implemented")
+}
+func (UnimplementedReplicationServiceServer) mustEmbedUnimplementedReplicationServiceServer() {}
+
+// UnsafeReplicationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicationServiceServer will
+// result in compilation errors.
+type UnsafeReplicationServiceServer interface {
+	mustEmbedUnimplementedReplicationServiceServer()
+}
+
+func RegisterReplicationServiceServer(s grpc.ServiceRegistrar, srv ReplicationServiceServer) {
+	s.RegisterService(&ReplicationService_ServiceDesc, srv)
+}
+
+func _ReplicationService_Snapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServiceServer).Snapshot(m, &replicationServiceSnapshotServer{ServerStream: stream})
+}
+
+type ReplicationService_SnapshotServer interface {
+	Send(*SnapshotChunk) error
+	grpc.ServerStream
+}
+
+type replicationServiceSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationServiceSnapshotServer) Send(m *SnapshotChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReplicationService_StreamMutations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplicationStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServiceServer).StreamMutations(m, &replicationServiceStreamMutationsServer{ServerStream: stream})
+}
+
+type ReplicationService_StreamMutationsServer interface {
+	Send(*ReplicationEntry) error
+	grpc.ServerStream
+}
+
+type replicationServiceStreamMutationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationServiceStreamMutationsServer) Send(m *ReplicationEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ReplicationService_ServiceDesc is the grpc.ServiceDesc for ReplicationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReplicationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ReplicationService",
+	HandlerType: (*ReplicationServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Snapshot",
+			Handler:       _ReplicationService_Snapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamMutations",
+			Handler:       _ReplicationService_StreamMutations_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/gnosql.proto",
+}
+
+const (
+	RaftService_RequestVote_FullMethodName     = "/proto.RaftService/RequestVote"
+	RaftService_AppendEntries_FullMethodName   = "/proto.RaftService/AppendEntries"
+	RaftService_InstallSnapshot_FullMethodName = "/proto.RaftService/InstallSnapshot"
+	RaftService_Propose_FullMethodName         = "/proto.RaftService/Propose"
+)
+
+// RaftServiceClient is the client API for RaftService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RaftServiceClient interface {
+	RequestVote(ctx context.Context, in *RaftVoteRequest, opts ...grpc.CallOption) (*RaftVoteResponse, error)
+	AppendEntries(ctx context.Context, in *RaftAppendRequest, opts ...grpc.CallOption) (*RaftAppendResponse, error)
+	InstallSnapshot(ctx context.Context, opts ...grpc.CallOption) (RaftService_InstallSnapshotClient, error)
+	Propose(ctx context.Context, in *RaftProposeRequest, opts ...grpc.CallOption) (*RaftProposeResponse, error)
+}
+
+type raftServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRaftServiceClient(cc grpc.ClientConnInterface) RaftServiceClient {
+	return &raftServiceClient{cc}
+}
+
+func (c *raftServiceClient) RequestVote(ctx context.Context, in *RaftVoteRequest, opts ...grpc.CallOption) (*RaftVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RaftVoteResponse)
+	err := c.cc.Invoke(ctx, RaftService_RequestVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftServiceClient) AppendEntries(ctx context.Context, in *RaftAppendRequest, opts ...grpc.CallOption) (*RaftAppendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RaftAppendResponse)
+	err := c.cc.Invoke(ctx, RaftService_AppendEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftServiceClient) InstallSnapshot(ctx context.Context, opts ...grpc.CallOption) (RaftService_InstallSnapshotClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RaftService_ServiceDesc.Streams[0], RaftService_InstallSnapshot_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &raftServiceInstallSnapshotClient{ClientStream: stream}
+	return x, nil
+}
+
+type RaftService_InstallSnapshotClient interface {
+	Send(*RaftSnapshotChunk) error
+	CloseAndRecv() (*RaftSnapshotResponse, error)
+	grpc.ClientStream
+}
+
+type raftServiceInstallSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftServiceInstallSnapshotClient) Send(m *RaftSnapshotChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *raftServiceInstallSnapshotClient) CloseAndRecv() (*RaftSnapshotResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RaftSnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *raftServiceClient) Propose(ctx context.Context, in *RaftProposeRequest, opts ...grpc.CallOption) (*RaftProposeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RaftProposeResponse)
+	err := c.cc.Invoke(ctx, RaftService_Propose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RaftServiceServer is the server API for RaftService service.
+// All implementations must embed UnimplementedRaftServiceServer
+// for forward compatibility
+type RaftServiceServer interface {
+	RequestVote(context.Context, *RaftVoteRequest) (*RaftVoteResponse, error)
+	AppendEntries(context.Context, *RaftAppendRequest) (*RaftAppendResponse, error)
+	InstallSnapshot(RaftService_InstallSnapshotServer) error
+	Propose(context.Context, *RaftProposeRequest) (*RaftProposeResponse, error)
+	mustEmbedUnimplementedRaftServiceServer()
+}
+
+// UnimplementedRaftServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRaftServiceServer struct {
+}
+
+func (UnimplementedRaftServiceServer) RequestVote(context.Context, *RaftVoteRequest) (*RaftVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedRaftServiceServer) AppendEntries(context.Context, *RaftAppendRequest) (*RaftAppendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (UnimplementedRaftServiceServer) InstallSnapshot(RaftService_InstallSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
+func (UnimplementedRaftServiceServer) Propose(context.Context, *RaftProposeRequest) (*RaftProposeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Propose not implemented")
+}
+func (UnimplementedRaftServiceServer) mustEmbedUnimplementedRaftServiceServer() {}
+
+// UnsafeRaftServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RaftServiceServer will
+// result in compilation errors.
+type UnsafeRaftServiceServer interface {
+	mustEmbedUnimplementedRaftServiceServer()
+}
+
+func RegisterRaftServiceServer(s grpc.ServiceRegistrar, srv RaftServiceServer) {
+	s.RegisterService(&RaftService_ServiceDesc, srv)
+}
+
+func _RaftService_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftServiceServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RaftService_RequestVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).RequestVote(ctx, req.(*RaftVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftService_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftAppendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftServiceServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RaftService_AppendEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).AppendEntries(ctx, req.(*RaftAppendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftService_InstallSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RaftServiceServer).InstallSnapshot(&raftServiceInstallSnapshotServer{ServerStream: stream})
+}
+
+type RaftService_InstallSnapshotServer interface {
+	SendAndClose(*RaftSnapshotResponse) error
+	Recv() (*RaftSnapshotChunk, error)
+	grpc.ServerStream
+}
+
+type raftServiceInstallSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *raftServiceInstallSnapshotServer) SendAndClose(m *RaftSnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *raftServiceInstallSnapshotServer) Recv() (*RaftSnapshotChunk, error) {
+	m := new(RaftSnapshotChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RaftService_Propose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftProposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Propose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RaftService_Propose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Propose(ctx, req.(*RaftProposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RaftService_ServiceDesc is the grpc.ServiceDesc for RaftService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RaftService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.RaftService",
+	HandlerType: (*RaftServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestVote",
+			Handler:    _RaftService_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _RaftService_AppendEntries_Handler,
+		},
+		{
+			MethodName: "Propose",
+			Handler:    _RaftService_Propose_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InstallSnapshot",
+			Handler:       _RaftService_InstallSnapshot_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/gnosql.proto",
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gnosql/src/global_constants"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+)
+
+// Claims carried by a token, signed with HS256 so any node sharing the token secret accepts it
+type Claims struct {
+	Username  string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	APIKeyId  string `json:"-"` // set instead of Username for a request made with an api key
+}
+
+var state = struct {
+	mu          sync.RWMutex
+	isEnabled   bool
+	tokenSecret []byte
+	tokenTTL    time.Duration
+}{}
+
+// ErrPermissionDenied is returned when the roles of a user don't grant the privilege a request needs
+var ErrPermissionDenied = errors.New(global_constants.PERMISSION_DENIED_MSG)
+
+// tokenHeader is the same for every token
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Configure turns authentication on, tokens are signed with tokenSecret. It is required while authentication is on,
+// a secret of its own would make a node reject the tokens of every other node
+func Configure(isEnabled bool, tokenSecret string, tokenTTL time.Duration) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if isEnabled && tokenSecret == "" {
+		return errors.New(global_constants.TOKEN_SECRET_REQUIRED_MSG)
+	}
+
+	state.isEnabled = isEnabled
+	state.tokenTTL = tokenTTL
+	state.tokenSecret = []byte(tokenSecret)
+
+	return nil
+}
+
+func IsEnabled() bool {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+
+	return state.isEnabled
+}
+
+// HashPassword returns a bcrypt hash of password, bcrypt keeps a random salt in the hash
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+func CheckPassword(hash string, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// GeneratePassword returns a random password for the bootstrap admin
+func GeneratePassword() string {
+	var password = make([]byte, 18)
+	rand.Read(password)
+	return base64.RawURLEncoding.EncodeToString(password)
+}
+
+// IssueToken returns a signed token for username, valid for the token TTL
+func IssueToken(username string) (string, error) {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+
+	payload, err := json.Marshal(Claims{Username: username, ExpiresAt: time.Now().Add(state.tokenTTL).Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	var unsigned = tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + sign(unsigned), nil
+}
+
+// VerifyToken checks the signature and the expiry of a token and returns its claims
+func VerifyToken(token string) (Claims, error) {
+	var claims Claims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return claims, errors.New(global_constants.INVALID_TOKEN_MSG)
+	}
+
+	state.mu.RLock()
+	var signature = sign(parts[0] + "." + parts[1])
+	state.mu.RUnlock()
+
+	if !hmac.Equal([]byte(signature), []byte(parts[2])) {
+		return claims, errors.New(global_constants.INVALID_TOKEN_MSG)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(payload, &claims) != nil {
+		return claims, errors.New(global_constants.INVALID_TOKEN_MSG)
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return claims, errors.New(global_constants.TOKEN_EXPIRED_MSG)
+	}
+
+	return claims, nil
+}
+
+// BearerToken returns the token of an Authorization header value like "Bearer <token>"
+func BearerToken(authorization string) string {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// GenerateAPIKey returns a new api key and its id, only a hash of the key is stored
+func GenerateAPIKey() (string, string) {
+	var keyId = make([]byte, 8)
+	var secret = make([]byte, 24)
+	rand.Read(keyId)
+	rand.Read(secret)
+
+	// hex so the id never holds the "_" separating the parts of a key
+	id := hex.EncodeToString(keyId)
+
+	return id, global_constants.API_KEY_PREFIX + "_" + id + "_" + base64.RawURLEncoding.EncodeToString(secret)
+}
+
+// APIKeyId returns the id part of an api key
+func APIKeyId(apiKey string) (string, bool) {
+	parts := strings.SplitN(apiKey, "_", 3)
+	if len(parts) != 3 || parts[0] != global_constants.API_KEY_PREFIX || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// HashAPIKey returns a SHA-256 hash of an api key, keys are random so unlike passwords they don't need bcrypt,
+// which would be too slow to run on every request
+func HashAPIKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func CheckAPIKey(hash string, apiKey string) bool {
+	return hmac.Equal([]byte(hash), []byte(HashAPIKey(apiKey)))
+}
+
+// sign returns the HS256 signature of an unsigned token, caller must hold state.mu
+func sign(unsigned string) string {
+	mac := hmac.New(sha256.New, state.tokenSecret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// nodeCredentials signs a token for every call a node makes to another node, like a replica streaming from its primary
+type nodeCredentials struct{}
+
+func (nodeCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	if !IsEnabled() {
+		return map[string]string{}, nil
+	}
+
+	token, err := IssueToken(global_constants.NODE_USERNAME)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{global_constants.AUTHORIZATION_METADATA: "Bearer " + token}, nil
+}
+
+// nodes talk over plain connections
+func (nodeCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// NodeDialOption authenticates the calls of a connection to another node
+func NodeDialOption() grpc.DialOption {
+	return grpc.WithPerRPCCredentials(nodeCredentials{})
+}
+
+type claimsKey struct{}
+
+// WithClaims returns a copy of ctx carrying the claims of the caller, the service checks its privileges from them
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFrom returns the claims of the caller, false when the request wasn't authenticated
+func ClaimsFrom(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
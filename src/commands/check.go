@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"gnosql/src/in_memory_database"
+)
+
+// Check verifies the data folder dataPath and logs a report, run it while the server is stopped.
+// Ex: gnosql check -repair -quarantine
+func Check(dataPath string, args []string) error {
+	flagSet := flag.NewFlagSet("check", flag.ContinueOnError)
+
+	repair := flagSet.Bool("repair", false, "rewrite collection files with the rebuilt index, remove leftover temp files and add missing checksums")
+	quarantine := flagSet.Bool("quarantine", false, "move unreadable and orphaned files to the quarantine folder")
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	report := in_memory_database.CheckIntegrity(in_memory_database.IntegrityOptions{DataPath: dataPath, Repair: *repair, Quarantine: *quarantine})
+	report.Log()
+
+	if unresolved := report.Unresolved(); unresolved > 0 {
+		return fmt.Errorf("%v issues not fixed", unresolved)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
+	"os"
+)
+
+var logger = logging.Get(global_constants.LOG_SERVER)
+
+// Restore loads a backup archive into the data folder dataPath, run it while the server is stopped.
+// Ex: gnosql restore -archive shop-backup.tar.gz -database shop-copy
+func Restore(dataPath string, args []string) error {
+	flagSet := flag.NewFlagSet("restore", flag.ContinueOnError)
+
+	archivePath := flagSet.String("archive", "", "path of the backup archive")
+	databaseName := flagSet.String("database", "", "name of the restored database, defaults to the source database name")
+	sourceDatabaseName := flagSet.String("source", "", "database of the archive to restore, required if it holds several databases")
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *archivePath == "" {
+		return errors.New("-archive is required")
+	}
+
+	archive, err := os.Open(*archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	backup, err := in_memory_database.ReadBackupArchive(archive)
+	if err != nil {
+		return err
+	}
+
+	if err := in_memory_database.RestoreBackup(dataPath, backup, *sourceDatabaseName, *databaseName); err != nil {
+		return err
+	}
+
+	logger.Info("backup restored", "databases", backup.Manifest.Databases, "takenAt", backup.Manifest.CreatedAt)
+	return nil
+}
@@ -2,9 +2,12 @@ package common
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/gob"
 	"fmt"
 	"gnosql/src/global_constants"
+	"gnosql/src/logging"
+	"hash/crc32"
 	"os"
 	"path/filepath"
 	"time"
@@ -12,6 +15,14 @@ import (
 	"github.com/google/uuid"
 )
 
+var logger = logging.Get(global_constants.LOG_STORAGE)
+
+func init() {
+	// Documents decoded from JSON hold nested objects and arrays as interface values
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
 func DeleteElement(array []string, elementToDelete string) []string {
 	// Find the index of the element
 	indexToDelete := -1
@@ -56,8 +67,8 @@ func TimeToString(time time.Time) string {
 	return time.UTC().Format("2006-01-02T15:04:05Z07:00")
 }
 
-func CreateDatabaseFolder() bool {
-	if _, err := CreateFolder(global_constants.GNOSQL_FULL_PATH); err == nil {
+func CreateDatabaseFolder(dataPath string) bool {
+	if _, err := CreateFolder(dataPath); err == nil {
 		return true
 	}
 	return false
@@ -71,12 +82,12 @@ func CreateFolder(nestedFolderPath string) (string, error) {
 		// Nested folders do not exist, create them
 		err := os.MkdirAll(nestedFolderPath, 0755) // 0755 is the permission mode for the new folders
 		if err != nil {
-			println("Error while create %s directory %v", nestedFolderPath, err)
+			logger.Error("data folder create error", "folder", nestedFolderPath, "error", err)
 			return "", err
 		}
-		println("GnoSQL database folder created successfully")
+		logger.Info("data folder created", "folder", nestedFolderPath)
 	} else {
-		println("GnoSQL database folder already exists")
+		logger.Debug("data folder exists", "folder", nestedFolderPath)
 	}
 
 	return nestedFolderPath, nil
@@ -88,7 +99,7 @@ func ReadFoldersInDirectory(directoryPath string) ([]string, error) {
 	// Read the directory
 	files, err := os.ReadDir(directoryPath)
 	if err != nil {
-		println("database file names reading, Error %v", err)
+		logger.Error("folder read error", "folder", directoryPath, "error", err)
 		return nil, err
 	}
 
@@ -115,7 +126,7 @@ func ReadFileNamesInDirectory(directoryPath string) ([]string, error) {
 	// Read the directory
 	files, err := os.ReadDir(directoryPath)
 	if err != nil {
-		println("database file names reading, Error %v", err)
+		logger.Error("folder read error", "folder", directoryPath, "error", err)
 		return nil, err
 	}
 
@@ -143,11 +154,11 @@ func ReadFile(filePath string) ([]byte, error) {
 func GetDatabaseFileName(databaseName string) string {
 	return databaseName + global_constants.DB_EXTENSION
 }
-func GetDatabaseFolderPath(databaseName string) string {
-	return filepath.Join(global_constants.GNOSQL_FULL_PATH, databaseName)
+func GetDatabaseFolderPath(dataPath string, databaseName string) string {
+	return filepath.Join(dataPath, databaseName)
 }
-func GetDatabaseFilePath(databaseName, fileName string) string {
-	return filepath.Join(global_constants.GNOSQL_FULL_PATH, databaseName+"/"+fileName)
+func GetDatabaseFilePath(dataPath string, databaseName, fileName string) string {
+	return filepath.Join(dataPath, databaseName+"/"+fileName)
 }
 
 func GetCollectionFileName(collectionName string) string {
@@ -157,17 +168,23 @@ func GetCollectionFileName(collectionName string) string {
 func GetCollectionBatchIdFileName() string {
 	return Generate16DigitUUID() + global_constants.COLLECTION_BATCH_EXTENSION
 }
-func GetCollectionFolderPath(databaseName string, collectionName string) string {
-	return filepath.Join(global_constants.GNOSQL_FULL_PATH, databaseName+"/"+collectionName)
+func GetCollectionSegmentFileName(seq uint64) string {
+	return fmt.Sprintf("%016d", seq) + global_constants.COLLECTION_SEGMENT_EXTENSION
+}
+func GetCollectionFolderPath(dataPath string, databaseName string, collectionName string) string {
+	return filepath.Join(dataPath, databaseName+"/"+collectionName)
 }
-func GetCollectionFilePath(databaseName string, collectionName string, fileName string) string {
-	return GetCollectionFolderPath(databaseName, collectionName) + "/" + fileName
+func GetCollectionFilePath(dataPath string, databaseName string, collectionName string, fileName string) string {
+	return GetCollectionFolderPath(dataPath, databaseName, collectionName) + "/" + fileName
 }
 
 func DeleteFolder(filePath string) bool {
+	// a database created again under the same name gets a new data key
+	forgetKeyrings(filePath)
+
 	err := os.RemoveAll(filePath)
 	if err != nil {
-		fmt.Println("Error deleting file or directory:", err)
+		logger.Error("folder delete error", "folder", filePath, "error", err)
 		return false
 	}
 
@@ -177,7 +194,7 @@ func DeleteFolder(filePath string) bool {
 func DeleteFile(filePath string) bool {
 	err := os.Remove(filePath)
 	if err != nil {
-		println("Error deleting file:", err)
+		logger.Error("file delete error", "file", filePath, "error", err)
 		return false
 	}
 
@@ -197,55 +214,165 @@ func DecodeGob(data []byte, target interface{}) error {
 	return dec.Decode(target)
 }
 
-func SaveToFile(filename string, data []byte) error {
+// SaveToFile writes data to a temp file next to filename, syncs it and renames it over filename,
+// a crash leaves either the old or the new file but never a partial one. Data is encrypted with the data key of
+// keyFolder when a master key is set
+func SaveToFile(keyFolder string, filename string, data []byte) error {
+	fileLock := lockFile(filename)
+	defer fileLock.Unlock()
+
+	fileData, err := encryptFileData(keyFolder, data)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, fileData)
+}
+
+func writeFileAtomic(filename string, data []byte) error {
 	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
-		fmt.Printf("\n err %v ", err)
+		logger.Error("folder create error", "file", filename, "error", err)
+
+		return err
+	}
+
+	var tempFileName = filename + global_constants.TEMP_FILE_EXTENSION
+
+	file, err := os.Create(tempFileName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tempFileName, filename); err != nil {
+		return err
+	}
 
+	return SyncFolder(filepath.Dir(filename))
+}
+
+// SyncFolder makes a rename in folderPath durable
+func SyncFolder(folderPath string) error {
+	folder, err := os.Open(folderPath)
+	if err != nil {
 		return err
 	}
+	defer folder.Close()
+
+	return folder.Sync()
+}
+
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+func Checksum(data []byte) uint32 {
+	return crc32.Checksum(data, checksumTable)
+}
+
+// SaveGobFile saves gob data followed by a trailer of FILE_CHECKSUM_MAGIC and the crc32 of the data
+func SaveGobFile(keyFolder string, filename string, gobData []byte) error {
+	var fileData = make([]byte, 0, len(gobData)+8)
+
+	fileData = append(fileData, gobData...)
+	fileData = append(fileData, global_constants.FILE_CHECKSUM_MAGIC...)
+	fileData = binary.BigEndian.AppendUint32(fileData, Checksum(gobData))
+
+	return SaveToFile(keyFolder, filename, fileData)
+}
+
+// ReadGobFile reads a file written by SaveGobFile and verifies its checksum,
+// files written before checksums were added have no trailer, they are returned as is and every such read is logged
+func ReadGobFile(keyFolder string, filename string) ([]byte, error) {
+	gobData, isLegacy, err := ReadLegacyGobFile(keyFolder, filename)
+
+	if err == nil && isLegacy {
+		logger.Warn("unverified read, file has no checksum", "file", filename)
+	}
 
-	return os.WriteFile(filename, data, 0644)
+	return gobData, err
+}
+
+// ReadLegacyGobFile reads a file like ReadGobFile without logging, isLegacy tells the integrity check
+// that the file has no checksum trailer so it can report it and add one on repair
+func ReadLegacyGobFile(keyFolder string, filename string) (gobData []byte, isLegacy bool, err error) {
+	fileData, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if fileData, err = decryptFileData(keyFolder, fileData); err != nil {
+		return nil, false, fmt.Errorf("%v: %v", err, filename)
+	}
+
+	var trailerStart = len(fileData) - 8
+	if trailerStart < 0 || string(fileData[trailerStart:trailerStart+4]) != global_constants.FILE_CHECKSUM_MAGIC {
+		return fileData, true, nil
+	}
+
+	gobData = fileData[:trailerStart]
+
+	if binary.BigEndian.Uint32(fileData[trailerStart+4:]) != Checksum(gobData) {
+		return nil, false, fmt.Errorf("%v: %v", global_constants.FILE_CHECKSUM_MISMATCH_MSG, filename)
+	}
+
+	return gobData, false, nil
 }
 
 func ReadFromFile(filename string) ([]byte, error) {
 	return os.ReadFile(filename)
 }
 
-func WriteGobDataToDisk(filePath string, data []byte) {
-	err := SaveToFile(filePath, data)
+func WriteGobDataToDisk(keyFolder string, filePath string, data []byte) {
+	err := SaveGobFile(keyFolder, filePath, data)
 
 	if err != nil {
-		fmt.Printf("\n filePath: %v Error saving collection GOB to file:", err)
+		logger.Error("file write error", "file", filePath, "error", err)
 	}
 }
 
-func EncodeGOBAndWriteFile[T any](filePath string, data T) (error, error) {
+func EncodeGOBAndWriteFile[T any](keyFolder string, filePath string, data T) (error, error) {
 	gobData, encodeErr := EncodeGob(data)
 	var writeErr error
 
 	if encodeErr == nil {
-		writeErr = SaveToFile(filePath, gobData)
+		writeErr = SaveGobFile(keyFolder, filePath, gobData)
 	}
 
-	fmt.Printf("\n Error while write file: %v \t EncodeError: %v \t WriteFileError: %v ", filePath, encodeErr, writeErr)
+	if encodeErr != nil || writeErr != nil {
+		logger.Error("file write error", "file", filePath, "encodeError", encodeErr, "writeError", writeErr)
+	}
 
 	return encodeErr, writeErr
 }
 
-func ReadFileAndDecodeGOB[T any](filePath string) (T, error) {
+func ReadFileAndDecodeGOB[T any](keyFolder string, filePath string) (T, error) {
 	var data T
 
-	fileData, err := os.ReadFile(filePath)
+	fileData, err := ReadGobFile(keyFolder, filePath)
+
+	if err == nil {
+		fileData, err = DecompressGob(fileData)
+	}
 
 	if err != nil {
-		fmt.Printf("\n Reading file %s, Error %v", filePath, err)
+		logger.Error("file read error", "file", filePath, "error", err)
 		return data, err
 	}
 
 	err = DecodeGob(fileData, &data)
 
 	if err != nil {
-		fmt.Printf("\n Decoding file %s, Error %v", filePath, err)
+		logger.Error("file decoding error", "file", filePath, "error", err)
 
 		return data, err
 	}
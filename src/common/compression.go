@@ -0,0 +1,83 @@
+package common
+
+import (
+	"errors"
+	"gnosql/src/global_constants"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// codec ids written after COMPRESSION_MAGIC
+var compressionCodecIds = map[string]byte{
+	global_constants.COMPRESSION_ZSTD:   1,
+	global_constants.COMPRESSION_SNAPPY: 2,
+}
+
+var zstdOnce sync.Once
+var zstdEncoder *zstd.Encoder
+var zstdDecoder *zstd.Decoder
+
+// zstdCodec returns the shared encoder and decoder, both are safe for concurrent EncodeAll and DecodeAll
+func zstdCodec() (*zstd.Encoder, *zstd.Decoder) {
+	zstdOnce.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil)
+		zstdDecoder, _ = zstd.NewReader(nil)
+	})
+	return zstdEncoder, zstdDecoder
+}
+
+func IsCompressionCodec(codec string) bool {
+	_, exists := compressionCodecIds[codec]
+	return codec == "" || exists
+}
+
+// CompressGob compresses gob data with codec behind a header of COMPRESSION_MAGIC and the codec id,
+// data is returned as is when codec is empty
+func CompressGob(codec string, gobData []byte) ([]byte, error) {
+	if codec == "" {
+		return gobData, nil
+	}
+
+	codecId, exists := compressionCodecIds[codec]
+	if !exists {
+		return nil, errors.New(global_constants.COMPRESSION_NOT_FOUND_MSG)
+	}
+
+	var data = make([]byte, 0, len(gobData)/2+len(global_constants.COMPRESSION_MAGIC)+1)
+	data = append(data, global_constants.COMPRESSION_MAGIC...)
+	data = append(data, codecId)
+
+	switch codec {
+	case global_constants.COMPRESSION_ZSTD:
+		encoder, _ := zstdCodec()
+		data = encoder.EncodeAll(gobData, data)
+	case global_constants.COMPRESSION_SNAPPY:
+		data = append(data, snappy.Encode(nil, gobData)...)
+	}
+
+	return data, nil
+}
+
+// DecompressGob reverses CompressGob, data without the header was written uncompressed and is returned as is.
+// A gob stream never starts with a zero byte, so the header can't be mistaken for gob data
+func DecompressGob(data []byte) ([]byte, error) {
+	var headerSize = len(global_constants.COMPRESSION_MAGIC) + 1
+
+	if len(data) < headerSize || string(data[:headerSize-1]) != global_constants.COMPRESSION_MAGIC {
+		return data, nil
+	}
+
+	var payload = data[headerSize:]
+
+	switch data[headerSize-1] {
+	case compressionCodecIds[global_constants.COMPRESSION_ZSTD]:
+		_, decoder := zstdCodec()
+		return decoder.DecodeAll(payload, nil)
+	case compressionCodecIds[global_constants.COMPRESSION_SNAPPY]:
+		return snappy.Decode(nil, payload)
+	}
+
+	return nil, errors.New(global_constants.COMPRESSION_NOT_FOUND_MSG)
+}
@@ -0,0 +1,431 @@
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gnosql/src/global_constants"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Files written by SaveToFile are sealed with AES-GCM under the data key of the key folder named by the caller, the
+// database folder for the files of a database and the data folder for files outside of them. Data keys are kept in
+// DATA_KEY_FILE_NAME of their folder, each one sealed by the master key. A sealed file starts with ENCRYPTION_MAGIC and
+// the id of its data key, so files written before encryption was enabled, or before a key rotation, still read.
+
+type keyring struct {
+	ActiveKeyId uint32           `json:"activeKeyId"`
+	Keys        []wrappedDataKey `json:"keys"`
+	dataKeys    map[uint32]cipher.AEAD
+}
+
+type wrappedDataKey struct {
+	Id         uint32 `json:"id"`
+	WrappedKey []byte `json:"wrappedKey"` // nonce followed by the data key sealed by the master key
+}
+
+var encryption = struct {
+	mu        sync.Mutex
+	masterKey cipher.AEAD
+	keyrings  map[string]*keyring // folder: keyring
+	rotating  map[string]bool     // folders being re-encrypted
+}{keyrings: make(map[string]*keyring), rotating: make(map[string]bool)}
+
+// fileLocks keep a key rotation from overwriting a file saved meanwhile
+var fileLocks [64]sync.Mutex
+
+func lockFile(filename string) *sync.Mutex {
+	var fileLock = &fileLocks[Checksum([]byte(filename))%uint32(len(fileLocks))]
+	fileLock.Lock()
+	return fileLock
+}
+
+// LoadMasterKey returns the base64 encoded 32 byte key given directly or in keyFilePath, nil when neither is set
+func LoadMasterKey(encodedKey string, keyFilePath string) ([]byte, error) {
+	if keyFilePath != "" {
+		keyFileData, err := os.ReadFile(keyFilePath)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey = string(keyFileData)
+	}
+
+	encodedKey = strings.TrimSpace(encodedKey)
+	if encodedKey == "" {
+		return nil, nil
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("master key is not base64: %v", err)
+	}
+	if len(masterKey) != 32 {
+		return nil, fmt.Errorf("master key must be 32 bytes, got %v", len(masterKey))
+	}
+
+	return masterKey, nil
+}
+
+// SetMasterKey enables encryption of every file written from now on
+func SetMasterKey(masterKey []byte) error {
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return err
+	}
+
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	encryption.masterKey = aead
+	encryption.keyrings = make(map[string]*keyring)
+
+	return nil
+}
+
+func IsEncryptionEnabled() bool {
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	return encryption.masterKey != nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, header []byte, data []byte) []byte {
+	var nonce = make([]byte, aead.NonceSize())
+	rand.Read(nonce)
+
+	var sealed = make([]byte, 0, len(header)+len(nonce)+len(data)+aead.Overhead())
+	sealed = append(sealed, header...)
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, data, nil)
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New(global_constants.FILE_DECRYPTION_FAILED_MSG)
+	}
+
+	data, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New(global_constants.FILE_DECRYPTION_FAILED_MSG)
+	}
+	return data, nil
+}
+
+// getKeyring returns the keyring of folder, a keyring with a new data key is created if folder has none and create is set.
+// Caller must hold encryption.mu
+func getKeyring(folder string, create bool) (*keyring, error) {
+	if ring, exists := encryption.keyrings[folder]; exists {
+		return ring, nil
+	}
+
+	var ring = &keyring{dataKeys: make(map[uint32]cipher.AEAD)}
+	var keyFilePath = filepath.Join(folder, global_constants.DATA_KEY_FILE_NAME)
+
+	keyFileData, err := os.ReadFile(keyFilePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if !create {
+			return nil, errors.New(global_constants.DATA_KEY_NOT_FOUND_MSG)
+		}
+		if err := ring.addDataKey(); err != nil {
+			return nil, err
+		}
+		if err := ring.save(folder); err != nil {
+			return nil, err
+		}
+		encryption.keyrings[folder] = ring
+		return ring, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(keyFileData, ring); err != nil {
+		return nil, fmt.Errorf("%v: %v", keyFilePath, err)
+	}
+
+	for _, wrappedKey := range ring.Keys {
+		dataKey, err := open(encryption.masterKey, wrappedKey.WrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("%v: data key %v can't be unwrapped with this master key", keyFilePath, wrappedKey.Id)
+		}
+		if ring.dataKeys[wrappedKey.Id], err = newAEAD(dataKey); err != nil {
+			return nil, err
+		}
+	}
+
+	encryption.keyrings[folder] = ring
+	return ring, nil
+}
+
+// addDataKey generates a data key and makes it the active one
+func (ring *keyring) addDataKey() error {
+	var dataKey = make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	ring.ActiveKeyId++
+	ring.Keys = append(ring.Keys, wrappedDataKey{Id: ring.ActiveKeyId, WrappedKey: seal(encryption.masterKey, nil, dataKey)})
+	ring.dataKeys[ring.ActiveKeyId] = aead
+
+	return nil
+}
+
+func (ring *keyring) save(folder string) error {
+	keyFileData, err := json.MarshalIndent(ring, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(folder, global_constants.DATA_KEY_FILE_NAME), keyFileData)
+}
+
+// encryptFileData seals data with the active data key of keyFolder, data is returned as is while encryption isn't enabled
+func encryptFileData(keyFolder string, data []byte) ([]byte, error) {
+	keyId, aead, err := activeDataKey(keyFolder)
+	if err != nil || aead == nil {
+		return data, err
+	}
+
+	var header = []byte(global_constants.ENCRYPTION_MAGIC)
+	header = binary.BigEndian.AppendUint32(header, keyId)
+
+	// AEADs are safe for concurrent use, files are sealed outside the lock
+	return seal(aead, header, data), nil
+}
+
+// EncryptData seals data kept inside a file, like a value of a key-value file or a record of a log, with the active
+// data key of keyFolder. Data is returned as is while encryption isn't enabled
+func EncryptData(keyFolder string, data []byte) ([]byte, error) {
+	return encryptFileData(keyFolder, data)
+}
+
+// DecryptData opens data sealed by EncryptData, data written without encryption is returned as is
+func DecryptData(keyFolder string, data []byte) ([]byte, error) {
+	return decryptFileData(keyFolder, data)
+}
+
+// IsSealedWithActiveKey tells whether data sealed with a data key of keyFolder needs no re-encryption, always true
+// while encryption isn't enabled
+func IsSealedWithActiveKey(keyFolder string, data []byte) (bool, error) {
+	activeKeyId, aead, err := activeDataKey(keyFolder)
+	if err != nil || aead == nil {
+		return aead == nil, err
+	}
+
+	keyId, isSealed := sealedKeyId(data)
+	return isSealed && keyId == activeKeyId, nil
+}
+
+// activeDataKey returns the active data key of keyFolder, nil while encryption isn't enabled
+func activeDataKey(keyFolder string) (uint32, cipher.AEAD, error) {
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	if encryption.masterKey == nil {
+		return 0, nil, nil
+	}
+
+	ring, err := getKeyring(keyFolder, true)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return ring.ActiveKeyId, ring.dataKeys[ring.ActiveKeyId], nil
+}
+
+// decryptFileData opens a file sealed by encryptFileData, files written without encryption are returned as is
+func decryptFileData(keyFolder string, fileData []byte) ([]byte, error) {
+	keyId, isSealed := sealedKeyId(fileData)
+	if !isSealed {
+		return fileData, nil
+	}
+
+	aead, err := dataKey(keyFolder, keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(aead, fileData[len(global_constants.ENCRYPTION_MAGIC)+4:])
+}
+
+// dataKey returns the data key keyId of keyFolder
+func dataKey(keyFolder string, keyId uint32) (cipher.AEAD, error) {
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	if encryption.masterKey == nil {
+		return nil, errors.New(global_constants.MASTER_KEY_REQUIRED_MSG)
+	}
+
+	ring, err := getKeyring(keyFolder, false)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, exists := ring.dataKeys[keyId]
+	if !exists {
+		return nil, errors.New(global_constants.DATA_KEY_NOT_FOUND_MSG)
+	}
+	return aead, nil
+}
+
+// sealedKeyId returns the data key id of a sealed file
+func sealedKeyId(fileData []byte) (uint32, bool) {
+	var headerSize = len(global_constants.ENCRYPTION_MAGIC) + 4
+
+	if len(fileData) < headerSize || string(fileData[:len(global_constants.ENCRYPTION_MAGIC)]) != global_constants.ENCRYPTION_MAGIC {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(fileData[len(global_constants.ENCRYPTION_MAGIC):headerSize]), true
+}
+
+// forgetKeyrings drops the cached keyrings of folder and the folders inside it once they are deleted
+func forgetKeyrings(folder string) {
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	for keyFolder := range encryption.keyrings {
+		if keyFolder == folder || strings.HasPrefix(keyFolder, folder+string(filepath.Separator)) {
+			delete(encryption.keyrings, keyFolder)
+		}
+	}
+}
+
+// RotateDataKey makes a new data key the active one of a database folder, files sealed with the previous keys
+// still read until ReencryptFolder rewrote them. A folder is rotated again only once ReencryptFolder finished
+func RotateDataKey(folder string) error {
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	if encryption.masterKey == nil {
+		return errors.New(global_constants.ENCRYPTION_NOT_ENABLED_MSG)
+	}
+	if encryption.rotating[folder] {
+		return errors.New(global_constants.KEY_ROTATION_IN_PROGRESS_MSG)
+	}
+
+	ring, err := getKeyring(folder, true)
+	if err != nil {
+		return err
+	}
+
+	if err := ring.addDataKey(); err != nil {
+		return err
+	}
+	if err := ring.save(folder); err != nil {
+		return err
+	}
+
+	encryption.rotating[folder] = true
+	return nil
+}
+
+// ReencryptFolder rewrites the gob files of a database folder not sealed with its active data key, files saved meanwhile
+// are sealed with the active key already. reencryptOthers rewrites the files holding sealed values, like the key-value
+// store. The previous keys are dropped once no file uses them, it returns the files rewritten
+func ReencryptFolder(folder string, reencryptOthers func() (int, error)) (int, error) {
+	var rewrittenFiles = 0
+
+	defer func() {
+		encryption.mu.Lock()
+		delete(encryption.rotating, folder)
+		encryption.mu.Unlock()
+	}()
+
+	err := filepath.WalkDir(folder, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// other files, like the key-value store, aren't written by SaveToFile and are left to reencryptOthers
+		if entry.IsDir() || !strings.HasSuffix(filePath, ".gob") {
+			return nil
+		}
+
+		isRewritten, err := reencryptFile(folder, filePath)
+		if isRewritten {
+			rewrittenFiles++
+		}
+		return err
+	})
+	if err != nil {
+		return rewrittenFiles, err
+	}
+
+	otherFiles, err := reencryptOthers()
+	rewrittenFiles += otherFiles
+	if err != nil {
+		return rewrittenFiles, err
+	}
+
+	encryption.mu.Lock()
+	defer encryption.mu.Unlock()
+
+	ring, err := getKeyring(folder, true)
+	if err != nil {
+		return rewrittenFiles, err
+	}
+
+	var activeKey []wrappedDataKey
+	for _, wrappedKey := range ring.Keys {
+		if wrappedKey.Id == ring.ActiveKeyId {
+			activeKey = append(activeKey, wrappedKey)
+		} else {
+			delete(ring.dataKeys, wrappedKey.Id)
+		}
+	}
+	ring.Keys = activeKey
+
+	return rewrittenFiles, ring.save(folder)
+}
+
+func reencryptFile(keyFolder string, filePath string) (bool, error) {
+	fileLock := lockFile(filePath)
+	defer fileLock.Unlock()
+
+	fileData, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		// deleted since the folder was listed
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if isActive, err := IsSealedWithActiveKey(keyFolder, fileData); isActive || err != nil {
+		return false, err
+	}
+
+	data, err := decryptFileData(keyFolder, fileData)
+	if err != nil {
+		return false, fmt.Errorf("%v: %v", err, filePath)
+	}
+
+	sealedData, err := encryptFileData(keyFolder, data)
+	if err != nil {
+		return false, err
+	}
+
+	return true, writeFileAtomic(filePath, sealedData)
+}
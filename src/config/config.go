@@ -0,0 +1,310 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gnosql/src/global_constants"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Settings of a server. Every setting is read from its default, then the settings file, then its env var,
+// then its flag, a later source overrides an earlier one
+type Settings struct {
+	DataPath string
+	GinPort  string
+	GrpcPort string
+
+	// Replication, raft and sharding, see README
+	Role               string
+	LeaderAddr         string
+	RaftId             string
+	RaftPeers          string
+	Shards             string
+	IntegrityCheck     string
+	CompactionInterval time.Duration // 0 disables scheduled compaction
+	LoadMode           string
+	LoadConcurrency    int
+
+	// Encryption at rest, the key itself is only taken from the env so it doesn't show in a process list
+	MasterKey     string
+	MasterKeyFile string
+
+	// TLS for both listeners, see README
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSCAFile     string
+	TLSClientAuth string
+
+	// Authentication, the token secret and the admin password are only taken from the env
+	Auth          bool
+	TokenTTL      time.Duration
+	TokenSecret   string
+	AdminPassword string
+
+	// Documents per batch file
+	BatchSize int
+	// Events buffered per collection before writers wait for its worker
+	CollectionChannelSize int
+	// How often changed collections are written to disk
+	SyncInterval time.Duration
+	// Documents returned by a filter without a limit
+	FilterLimit int
+	// Goroutines scanning a collection per filter
+	FilterWorkers int
+	// Filters taking longer are logged with their plan, 0 turns the slow query log off
+	SlowQueryThreshold time.Duration
+
+	// Logging, levels per subsystem override LogLevel, Ex: raft=debug,storage=warn
+	LogLevel  string
+	LogFormat string
+	LogLevels string
+}
+
+// option is a setting with its key in the settings file, its env var and its flag
+type option struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+	set   setter
+}
+
+// setter parses a value of an option into Settings, a bool option also takes its flag without a value, Ex: -auth
+type setter struct {
+	apply  func(settings *Settings, value string) error
+	isBool bool
+}
+
+// flagValue holds the value given to a flag until the env and the settings file are applied
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	return v.value
+}
+
+func (v *flagValue) Set(value string) error {
+	v.value = value
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+var options = []option{
+	{"dataPath", "GNOSQL_DATA_PATH", "data-path", "folder holding the databases", setString(func(s *Settings) *string { return &s.DataPath })},
+	{"ginPort", "GIN_PORT", "gin-port", "HTTP port", setString(func(s *Settings) *string { return &s.GinPort })},
+	{"grpcPort", "GRPC_PORT", "grpc-port", "gRPC port", setString(func(s *Settings) *string { return &s.GrpcPort })},
+	{"role", "GNOSQL_ROLE", "role", "primary, replica, raft or router", setString(func(s *Settings) *string { return &s.Role })},
+	{"leaderAddr", "GNOSQL_LEADER_ADDR", "leader-addr", "gRPC address of the primary a replica follows", setString(func(s *Settings) *string { return &s.LeaderAddr })},
+	{"raftId", "GNOSQL_RAFT_ID", "raft-id", "id of this node in the raft group", setString(func(s *Settings) *string { return &s.RaftId })},
+	{"raftPeers", "GNOSQL_RAFT_PEERS", "raft-peers", "every raft member as id=host:grpcPort", setString(func(s *Settings) *string { return &s.RaftPeers })},
+	{"shards", "GNOSQL_SHARDS", "shards", "every shard of a router as id=host:grpcPort", setString(func(s *Settings) *string { return &s.Shards })},
+	{"integrityCheck", "GNOSQL_INTEGRITY_CHECK", "integrity-check", "check the data folder on start: report, repair or repair,quarantine", setString(func(s *Settings) *string { return &s.IntegrityCheck })},
+	{"compactionInterval", "GNOSQL_COMPACTION_INTERVAL", "compaction-interval", "merge under-filled batches on this schedule, Ex: 6h", setDuration(func(s *Settings) *time.Duration { return &s.CompactionInterval }, 0)},
+	{"loadMode", "GNOSQL_LOAD_MODE", "load-mode", "eager, on-access or warm-up", setString(func(s *Settings) *string { return &s.LoadMode })},
+	{"loadConcurrency", "GNOSQL_LOAD_CONCURRENCY", "load-concurrency", "collections read at once on start", setInt(func(s *Settings) *int { return &s.LoadConcurrency })},
+	{"masterKeyFile", "GNOSQL_MASTER_KEY_FILE", "master-key-file", "file holding the base64 master key", setString(func(s *Settings) *string { return &s.MasterKeyFile })},
+	{"tlsCertFile", "GNOSQL_TLS_CERT_FILE", "tls-cert-file", "PEM certificate of both listeners, turns TLS on", setString(func(s *Settings) *string { return &s.TLSCertFile })},
+	{"tlsKeyFile", "GNOSQL_TLS_KEY_FILE", "tls-key-file", "PEM key of the certificate", setString(func(s *Settings) *string { return &s.TLSKeyFile })},
+	{"tlsCaFile", "GNOSQL_TLS_CA_FILE", "tls-ca-file", "PEM CA checking client certificates and the certificates of other nodes", setString(func(s *Settings) *string { return &s.TLSCAFile })},
+	{"tlsClientAuth", "GNOSQL_TLS_CLIENT_AUTH", "tls-client-auth", "require client certificates: none, grpc or all", setString(func(s *Settings) *string { return &s.TLSClientAuth })},
+	{"auth", "GNOSQL_AUTH", "auth", "require a token for every request", setBool(func(s *Settings) *bool { return &s.Auth })},
+	{"tokenTTL", "GNOSQL_TOKEN_TTL", "token-ttl", "how long a login token is valid, Ex: 12h", setDuration(func(s *Settings) *time.Duration { return &s.TokenTTL }, 0)},
+	{"batchSize", "GNOSQL_BATCH_SIZE", "batch-size", "documents per batch file", setInt(func(s *Settings) *int { return &s.BatchSize })},
+	{"collectionChannelSize", "GNOSQL_COLLECTION_CHANNEL_SIZE", "collection-channel-size", "events buffered per collection", setInt(func(s *Settings) *int { return &s.CollectionChannelSize })},
+	{"syncInterval", "GNOSQL_SYNC_INTERVAL", "sync-interval", "how often changed collections are written to disk, Ex: 30s", setDuration(func(s *Settings) *time.Duration { return &s.SyncInterval }, time.Second)},
+	{"filterLimit", "GNOSQL_FILTER_LIMIT", "filter-limit", "documents returned by a filter without a limit", setInt(func(s *Settings) *int { return &s.FilterLimit })},
+	{"filterWorkers", "GNOSQL_FILTER_WORKERS", "filter-workers", "goroutines scanning a collection per filter", setInt(func(s *Settings) *int { return &s.FilterWorkers })},
+	{"slowQueryThreshold", "GNOSQL_SLOW_QUERY_THRESHOLD", "slow-query-threshold", "log filters taking longer with their plan, Ex: 200ms, 0 turns it off", setDuration(func(s *Settings) *time.Duration { return &s.SlowQueryThreshold }, 0)},
+	{"logLevel", "GNOSQL_LOG_LEVEL", "log-level", "debug, info, warn or error", setString(func(s *Settings) *string { return &s.LogLevel })},
+	{"logFormat", "GNOSQL_LOG_FORMAT", "log-format", "json or text", setString(func(s *Settings) *string { return &s.LogFormat })},
+	{"logLevels", "GNOSQL_LOG_LEVELS", "log-levels", "level per subsystem, Ex: raft=debug,storage=warn", setString(func(s *Settings) *string { return &s.LogLevels })},
+}
+
+func Default() Settings {
+	usr, _ := os.UserHomeDir()
+
+	return Settings{
+		DataPath:              filepath.Join(usr, global_constants.GNOSQL_PATH),
+		GinPort:               "5454",
+		GrpcPort:              "5455",
+		Role:                  global_constants.ROLE_PRIMARY,
+		LoadMode:              global_constants.LOAD_MODE_EAGER,
+		LoadConcurrency:       runtime.NumCPU(),
+		TLSClientAuth:         global_constants.TLS_CLIENT_AUTH_NONE,
+		TokenTTL:              global_constants.TOKEN_TTL,
+		BatchSize:             global_constants.BATCH_SIZE,
+		CollectionChannelSize: global_constants.COLLECTION_CHANNEL_SIZE,
+		SyncInterval:          global_constants.TIME_INTERVAL_TO_SYNC_DISK,
+		FilterLimit:           global_constants.FILTER_DEFAULT_LIMIT,
+		FilterWorkers:         global_constants.FILTER_DEFAULT_WORKER_COUNT,
+		SlowQueryThreshold:    global_constants.SLOW_QUERY_THRESHOLD,
+		LogLevel:              "info",
+		LogFormat:             global_constants.LOG_FORMAT_JSON,
+	}
+}
+
+// Load reads the settings of a server from the settings file named by -config or GNOSQL_CONFIG, the env and the flags
+// in args. Flags stop at the first argument that isn't one, the rest is returned, Ex: -data-path /tmp/db check -repair
+func Load(args []string) (Settings, []string, error) {
+	var settings = Default()
+
+	flagSet := flag.NewFlagSet("gnosql", flag.ContinueOnError)
+
+	configFile := flagSet.String("config", os.Getenv("GNOSQL_CONFIG"), "settings file, .yaml, .yml or .toml")
+	for _, opt := range options {
+		flagSet.Var(&flagValue{isBool: opt.set.isBool}, opt.flag, opt.usage)
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return settings, nil, err
+	}
+
+	if *configFile != "" {
+		if err := settings.readFile(*configFile); err != nil {
+			return settings, nil, err
+		}
+	}
+
+	for _, opt := range options {
+		if value := os.Getenv(opt.env); value != "" {
+			if err := opt.set.apply(&settings, value); err != nil {
+				return settings, nil, fmt.Errorf("%v: %v", opt.env, err)
+			}
+		}
+	}
+	settings.MasterKey = os.Getenv("GNOSQL_MASTER_KEY")
+	settings.TokenSecret = os.Getenv("GNOSQL_TOKEN_SECRET")
+	settings.AdminPassword = os.Getenv("GNOSQL_ADMIN_PASSWORD")
+
+	var flagErr error
+	flagSet.Visit(func(f *flag.Flag) {
+		for _, opt := range options {
+			if opt.flag == f.Name && flagErr == nil {
+				if err := opt.set.apply(&settings, f.Value.String()); err != nil {
+					flagErr = fmt.Errorf("-%v: %v", opt.flag, err)
+				}
+			}
+		}
+	})
+	if flagErr != nil {
+		return settings, nil, flagErr
+	}
+
+	return settings, flagSet.Args(), settings.validate()
+}
+
+// readFile applies the settings file, unknown keys are an error so a typo doesn't go unnoticed
+func (settings *Settings) readFile(filePath string) error {
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var values = make(map[string]interface{})
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(fileData, &values)
+	case ".toml":
+		err = toml.Unmarshal(fileData, &values)
+	default:
+		return fmt.Errorf("%v: settings file must be .yaml, .yml or .toml", filePath)
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %v", filePath, err)
+	}
+
+outerLoop:
+	for key, value := range values {
+		for _, opt := range options {
+			if opt.key == key {
+				if err := opt.set.apply(settings, fmt.Sprint(value)); err != nil {
+					return fmt.Errorf("%v: %v: %v", filePath, key, err)
+				}
+				continue outerLoop
+			}
+		}
+		return fmt.Errorf("%v: unknown setting %v", filePath, key)
+	}
+
+	return nil
+}
+
+func (settings Settings) validate() error {
+	if settings.DataPath == "" {
+		return errors.New("dataPath can't be empty")
+	}
+	if settings.LoadConcurrency < 1 || settings.BatchSize < 1 || settings.CollectionChannelSize < 1 ||
+		settings.FilterLimit < 1 || settings.FilterWorkers < 1 {
+		return errors.New("loadConcurrency, batchSize, collectionChannelSize, filterLimit and filterWorkers must be positive numbers")
+	}
+	if settings.SyncInterval <= 0 || settings.TokenTTL <= 0 {
+		return errors.New("syncInterval and tokenTTL must be positive")
+	}
+	// nodes sign the calls they make to each other, replicas, raft members, routers and the primaries or shards they
+	// call only accept them with the same secret. Any primary may be followed by a replica, so every role needs it
+	if settings.Auth && settings.TokenSecret == "" {
+		return errors.New("GNOSQL_TOKEN_SECRET is required when auth is on, every node of a deployment must share it")
+	}
+	return nil
+}
+
+func setString(field func(*Settings) *string) setter {
+	return setter{apply: func(settings *Settings, value string) error {
+		*field(settings) = value
+		return nil
+	}}
+}
+
+func setBool(field func(*Settings) *bool) setter {
+	return setter{isBool: true, apply: func(settings *Settings, value string) error {
+		isSet, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%v is not true or false", value)
+		}
+		*field(settings) = isSet
+		return nil
+	}}
+}
+
+func setInt(field func(*Settings) *int) setter {
+	return setter{apply: func(settings *Settings, value string) error {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return fmt.Errorf("%v is not a positive number", value)
+		}
+		*field(settings) = number
+		return nil
+	}}
+}
+
+// setDuration takes a duration like 30s or 6h, plain numbers are counted in unit when unit isn't 0
+func setDuration(field func(*Settings) *time.Duration, unit time.Duration) setter {
+	return setter{apply: func(settings *Settings, value string) error {
+		if seconds, err := strconv.Atoi(value); err == nil && unit > 0 {
+			*field(settings) = time.Duration(seconds) * unit
+			return nil
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(settings) = duration
+		return nil
+	}}
+}
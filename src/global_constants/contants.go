@@ -1,22 +1,22 @@
 package global_constants
 
 import (
-	"os/user"
-	"path/filepath"
 	"time"
 )
 
-var usr, _ = user.Current()
-
-// Gnosql Path & Extensions
+// Gnosql Path & Extensions, the data folder defaults to GNOSQL_PATH under the home folder
 const GNOSQL_PATH = "gnosql/db/"
 
-var GNOSQL_FULL_PATH = filepath.Join(usr.HomeDir, GNOSQL_PATH)
-
 const DB_EXTENSION = "-db.gob"
 
 const COLLECTION_EXTENSION = "-collection.gob"
 const COLLECTION_BATCH_EXTENSION = "-data.gob"
+const COLLECTION_SEGMENT_EXTENSION = "-segment.gob"
+const TEMP_FILE_EXTENSION = ".tmp"
+const FILE_CHECKSUM_MAGIC = "GCRC"  // precedes the crc32 trailer of gob files
+const COMPRESSION_MAGIC = "\x00GNZ" // starts compressed batch and collection files, followed by the codec id
+const ENCRYPTION_MAGIC = "\x00GNE"  // starts encrypted files, followed by the id of the data key
+const DATA_KEY_FILE_NAME = "data-key.json"
 const DOC_ID = "docId"
 const DOC_INDEX = "docIndex"
 const DOC_CREATED_AT = "created"
@@ -25,14 +25,124 @@ const INDEX_KEYS_NAME = "IndexKeys"
 const FILTER_LIMIT = "limit"
 const FILTER_KEY = "key"
 const FILTER_VALUE = "value"
+const QUERY_STRATEGY_INDEX = "index" // index lookups narrowed the documents to compare
+const QUERY_STRATEGY_SCAN = "scan"   // every batch of the collection was compared
+const QUERY_STRATEGY_CACHE = "cache" // served from the query cache of the collection
+const QUERY_STAGE_PLAN = "plan"
+const QUERY_STAGE_INDEX = "index"
+const QUERY_STAGE_SCAN = "scan"
+const QUERY_STAGE_SORT = "sort"
+const QUERY_STAGE_CACHE = "cache"
+const CONFIG_WEBHOOKS = "webhooks"
+const CONFIG_STORAGE_ENGINE = "storageEngine"
+const STORAGE_ENGINE_GOB = "gob" // collection file and batch files in a folder per collection
+const STORAGE_ENGINE_KV = "kv"   // one append only key-value file per database
+const CONFIG_MEMORY_BUDGET = "memoryBudgetMB"
+const CONFIG_COMPRESSION = "compression"
+const CONFIG_QUERY_CACHES = "queryCaches" // collectionName: cap in MB of its query cache
+const COMPRESSION_ZSTD = "zstd"
+const COMPRESSION_SNAPPY = "snappy"
+const KV_STORE_FILE_NAME = "store.kv"
+const SHARD_MAP_FILE_NAME = "shard-map.gob"
+const BACKUP_MANIFEST_FILE_NAME = "manifest.json"
+const BACKUP_VERSION = 1
+
+// Webhook Headers
+const WEBHOOK_SIGNATURE_HEADER = "X-GnoSQL-Signature"
+const WEBHOOK_EVENT_HEADER = "X-GnoSQL-Event"
+const WEBHOOK_DELIVERY_HEADER = "X-GnoSQL-Delivery"
+
+// Authentication
+const AUTHORIZATION_HEADER = "Authorization"
+const AUTHORIZATION_METADATA = "authorization" // gRPC metadata keys are lower case
+const SYSTEM_DATABASE_NAME = "_system"         // holds the users, roles and api keys, reserved for the server
+const USERS_COLLECTION_NAME = "users"
+const USER_USERNAME = "username"
+const USER_PASSWORD_HASH = "passwordHash"
+const ADMIN_USERNAME = "admin"
+const NODE_USERNAME = "_node" // signs the calls nodes make to each other
+const USER_ROLES = "roles"
+const ROLES_COLLECTION_NAME = "roles"
+const ROLE_NAME = "name"
+const ROLE_GRANTS = "grants"
+const ADMIN_ROLE_NAME = "admin" // built in, admin on every database
+const GRANT_WILDCARD = "*"      // a grant on every database or every collection
+const API_KEY_HEADER = "X-API-Key"
+const API_KEY_METADATA = "x-api-key"
+const API_KEY_PREFIX = "gnk" // keys look like gnk_<keyId>_<secret>
+const API_KEYS_COLLECTION_NAME = "apiKeys"
+const API_KEY_ID = "keyId"
+const API_KEY_NAME = "name"
+const API_KEY_SECRET_HASH = "secretHash"
+const API_KEY_GRANTS = "grants"
+const API_KEY_EXPIRES_AT = "expiresAt"
+const API_KEY_LAST_USED_AT = "lastUsedAt"
+const API_KEY_LAST_USED_INTERVAL = time.Minute // lastUsedAt is written at most this often per key
+
+// Privileges, each one includes the ones before it
+const PRIVILEGE_READ = "read"
+const PRIVILEGE_WRITE = "write"
+const PRIVILEGE_ADMIN = "admin"
+const TOKEN_TTL = 12 * time.Hour
 
 // Size % Limits
 const INCOME_REQUEST_CHANNEL_SIZE = 100000
-const BATCH_SIZE = 10000
-const COLLECTION_CHANNEL_SIZE = 10000
-const TIME_INTERVAL_TO_SYNC_DISK = 30 * time.Second
-const FILTER_DEFAULT_LIMIT int = 1000
-const FILTER_DEFAULT_WORKER_COUNT int = 4
+const BATCH_SIZE = 10000 // default of the batchSize setting
+const BATCH_COMPACTION_FILL_RATIO = 0.5
+const KV_COMPACTION_MIN_SIZE = 4 * 1024 * 1024
+const SEGMENT_COMPACTION_COUNT = 20
+const SEGMENT_COMPACTION_SIZE = 16 * 1024 * 1024
+const COLLECTION_CHANNEL_SIZE = 10000               // default of the collectionChannelSize setting
+const TIME_INTERVAL_TO_SYNC_DISK = 30 * time.Second // default of the syncInterval setting
+const FILTER_DEFAULT_LIMIT int = 1000               // default of the filterLimit setting
+const FILTER_DEFAULT_WORKER_COUNT int = 4           // default of the filterWorkers setting
+const SLOW_QUERY_THRESHOLD = 200 * time.Millisecond // default of the slowQueryThreshold setting
+const WEBHOOK_CHANNEL_SIZE = 10000
+const WEBHOOK_QUEUE_SIZE = 1000
+const WEBHOOK_MAX_ATTEMPTS = 5
+
+// subsystems logging on their own level, see logLevels
+const LOG_SERVER = "server"
+const LOG_AUTH = "auth"
+const LOG_DATABASE = "database"
+const LOG_QUERY = "query"
+const LOG_STORAGE = "storage"
+const LOG_REPLICATION = "replication"
+const LOG_RAFT = "raft"
+const LOG_WEBHOOK = "webhook"
+
+const LOG_FORMAT_JSON = "json"
+const LOG_FORMAT_TEXT = "text"
+
+const REQUEST_ID_HEADER = "X-Request-Id"
+const REQUEST_ID_METADATA = "x-request-id"
+const REQUEST_ID_MAX_LENGTH = 128 // longer ids sent by clients are replaced
+
+// kinds of file writes timed in the metrics
+const DISK_WRITE_SEGMENT = "segment"
+const DISK_WRITE_BATCH = "batch"
+const DISK_WRITE_COLLECTION = "collection"
+const DISK_WRITE_DATABASE = "database"
+
+const SERVER_SHUTDOWN_TIMEOUT = 10 * time.Second // requests in flight get this long to finish on shutdown
+const WEBHOOK_INITIAL_BACKOFF = 1 * time.Second
+const WEBHOOK_MAX_BACKOFF = 30 * time.Second
+const WEBHOOK_REQUEST_TIMEOUT = 10 * time.Second
+const WEBHOOK_DEAD_LETTER_SIZE = 1000
+const REPLICATION_LOG_SIZE = 100000
+const REPLICATION_BATCH_SIZE = 1000
+const REPLICATION_SNAPSHOT_CHUNK_SIZE = 1024 * 1024
+const REPLICATION_HEARTBEAT_INTERVAL = 1 * time.Second
+const REPLICATION_RETRY_INTERVAL = 2 * time.Second
+const RAFT_TICK_INTERVAL = 50 * time.Millisecond
+const RAFT_HEARTBEAT_INTERVAL = 200 * time.Millisecond
+const RAFT_ELECTION_TIMEOUT_MIN = 1 * time.Second
+const RAFT_ELECTION_TIMEOUT_MAX = 2 * time.Second
+const RAFT_RPC_TIMEOUT = 2 * time.Second
+const RAFT_PROPOSE_TIMEOUT = 10 * time.Second
+const RAFT_MAX_APPEND_ENTRIES = 500
+const RAFT_SNAPSHOT_THRESHOLD = 10000
+const SHARD_RPC_TIMEOUT = 10 * time.Second
 
 // Events
 const EVENT_CREATE = "EVENT_CREATE"
@@ -40,6 +150,29 @@ const EVENT_UPDATE = "EVENT_UPDATE"
 const EVENT_DELETE = "EVENT_DELETE"
 const EVENT_SAVE_TO_DISK = "EVENT_SAVE_TO_DISK"
 const EVENT_STOP_GO_ROUTINE = "EVENT_STOP_GO_ROUTINE"
+const EVENT_FLUSH = "EVENT_FLUSH" // save to disk and report back, sent on shutdown
+const EVENT_CREATE_DATABASE = "EVENT_CREATE_DATABASE"
+const EVENT_DELETE_DATABASE = "EVENT_DELETE_DATABASE"
+const EVENT_CREATE_COLLECTIONS = "EVENT_CREATE_COLLECTIONS"
+const EVENT_DELETE_COLLECTIONS = "EVENT_DELETE_COLLECTIONS"
+const EVENT_CREATE_WEBHOOK = "EVENT_CREATE_WEBHOOK"
+const EVENT_DELETE_WEBHOOK = "EVENT_DELETE_WEBHOOK"
+
+// Replication Roles
+const ROLE_PRIMARY = "primary"
+const ROLE_REPLICA = "replica"
+const ROLE_RAFT = "raft"
+const ROLE_ROUTER = "router"
+
+// TLS client certificates, GNOSQL_TLS_CLIENT_AUTH
+const TLS_CLIENT_AUTH_NONE = "none" // clients aren't asked for a certificate
+const TLS_CLIENT_AUTH_GRPC = "grpc" // the gRPC listener requires one, for service to service traffic
+const TLS_CLIENT_AUTH_ALL = "all"   // both listeners require one
+
+// Load modes, GNOSQL_LOAD_MODE
+const LOAD_MODE_EAGER = "eager"         // every collection is read before the servers start
+const LOAD_MODE_ON_ACCESS = "on-access" // a collection is read on its first access
+const LOAD_MODE_WARM_UP = "warm-up"     // on access, and every collection is read in the background
 
 // Response Messages
 const DATABASE_CREATE_SUCCESS_MSG = "Database created successfully"
@@ -50,15 +183,75 @@ const DATABASE_LOAD_TO_DISK_MSG = "Database to file disk process started"
 
 const COLLECTION_CREATE_SUCCESS_MSG = "Collection created successfully"
 const COLLECTION_DELETE_SUCCESS_MSG = "Collection deleted successfully"
+const QUERY_CACHE_UPDATE_SUCCESS_MSG = "Query cache updated successfully"
+const INVALID_QUERY_CACHE_SIZE_MSG = "Query cache size can't be negative"
 const COLLECTION_NOT_FOUND_MSG = "Collection not found "
 
 const DOCUMENT_DELETE_SUCCESS_MSG = "Document deleted successfully"
 const DOCUMENT_NOT_FOUND_MSG = "Document not found"
 
+const WEBHOOK_CREATE_SUCCESS_MSG = "Webhook created successfully"
+const WEBHOOK_DELETE_SUCCESS_MSG = "Webhook deleted successfully"
+const WEBHOOK_NOT_FOUND_MSG = "Webhook not found"
+const WEBHOOK_URL_REQUIRED_MSG = "Webhook url is required"
+
+const REPLICA_READ_ONLY_MSG = "Replica is read only, send writes to the primary"
+const REPLICATION_LOG_TRUNCATED_MSG = "Replication log truncated, snapshot required"
+const RAFT_NOT_LEADER_MSG = "Node lost leadership before the write was committed"
+const RAFT_NO_LEADER_MSG = "Replica group has no leader, retry later"
+const RAFT_PROPOSE_TIMEOUT_MSG = "Write was not committed in time"
+const RAFT_SEED_NOT_SUPPORTED_MSG = "Seed data is not supported in a replica group"
+const ROUTER_NOT_SUPPORTED_MSG = "Not supported on a router node"
+const NOT_A_ROUTER_MSG = "Node is not running as a router"
+const SHARD_KEY_REQUIRED_MSG = "Document must contain the shard key of the collection"
+const SHARD_KEY_IMMUTABLE_MSG = "Shard key of a document can't be changed"
+const SHARD_MAP_CHANGED_MSG = "Shards differ from the stored shard map, documents would be routed to the wrong shard"
+const BACKUP_CHECKSUM_MISMATCH_MSG = "Backup archive is corrupted, checksum mismatch"
+const BACKUP_MANIFEST_MISSING_MSG = "Backup archive has no manifest"
+const BACKUP_SOURCE_REQUIRED_MSG = "Backup archive has several databases, choose the source database"
+const STORAGE_ENGINE_NOT_FOUND_MSG = "Storage engine not found"
+const COMPRESSION_NOT_FOUND_MSG = "Compression codec not found"
+const ENCRYPTION_NOT_ENABLED_MSG = "Encryption at rest is not enabled"
+const MASTER_KEY_REQUIRED_MSG = "File is encrypted, a master key is required"
+const DATA_KEY_NOT_FOUND_MSG = "Data key not found"
+const FILE_DECRYPTION_FAILED_MSG = "File can't be decrypted"
+const KEY_ROTATION_STARTED_MSG = "Key rotation started"
+const KEY_ROTATION_IN_PROGRESS_MSG = "Key rotation is in progress"
+const AUTHENTICATION_REQUIRED_MSG = "Authentication required"
+const INVALID_CREDENTIALS_MSG = "Invalid username or password"
+const INVALID_TOKEN_MSG = "Invalid token"
+const TOKEN_EXPIRED_MSG = "Token expired"
+const TOKEN_SECRET_REQUIRED_MSG = "Token secret is required when authentication is on"
+const SYSTEM_DATABASE_RESERVED_MSG = "Database name is reserved"
+const PERMISSION_DENIED_MSG = "Permission denied"
+const USER_CREATE_SUCCESS_MSG = "User created successfully"
+const USER_UPDATE_SUCCESS_MSG = "User updated successfully"
+const USER_DELETE_SUCCESS_MSG = "User deleted successfully"
+const USER_ALREADY_EXISTS_MSG = "User already exists"
+const USER_NOT_FOUND_MSG = "User not found"
+const USERNAME_RESERVED_MSG = "Username is reserved"
+const PASSWORD_REQUIRED_MSG = "Password is required"
+const ROLE_CREATE_SUCCESS_MSG = "Role created successfully"
+const ROLE_UPDATE_SUCCESS_MSG = "Role updated successfully"
+const ROLE_DELETE_SUCCESS_MSG = "Role deleted successfully"
+const ROLE_ALREADY_EXISTS_MSG = "Role already exists"
+const ROLE_NOT_FOUND_MSG = "Role not found"
+const ROLE_BUILT_IN_MSG = "Built in role can't be changed"
+const API_KEY_DELETE_SUCCESS_MSG = "API key revoked successfully"
+const API_KEY_NOT_FOUND_MSG = "API key not found"
+const INVALID_API_KEY_MSG = "Invalid API key"
+const API_KEY_EXPIRED_MSG = "API key expired"
+const NODE_ONLY_MSG = "Only nodes of the cluster can call this method"
+const API_KEY_NOT_ALLOWED_ON_CLUSTER_MSG = "API keys can't call replication or raft methods"
+const EXPLAIN_NOT_SUPPORTED_ON_ROUTER_MSG = "Explain is not supported through a shard router, ask a shard directly"
+const API_KEY_NAME_REQUIRED_MSG = "API key name is required"
+const INVALID_GRANT_MSG = "Grant needs a database and a privilege of read, write or admin"
+
 // Error Response Messages
 const ERROR_WHILE_BINDING_JSON = "Request JSON binding failed"
 const ERROR_WHILE_UNMARSHAL_JSON = "Request JSON Unmarhsall failed"
 const ERROR_WHILE_MARSHAL_JSON = "Request JSON Marhsall failed"
+const FILE_CHECKSUM_MISMATCH_MSG = "File is corrupted, checksum mismatch"
 
 // Divider's
 const COLLECTION_CHANNEL_NAME_DIVIDER = "-&-"
@@ -0,0 +1,130 @@
+package grpc_handler
+
+import (
+	"context"
+	"errors"
+	pb "gnosql/proto"
+	"gnosql/src/auth"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/service"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthUnaryInterceptor requires a token in the authorization metadata, or an api key in the x-api-key metadata, of
+// every call except Login while authentication is on, the service checks the privileges of the caller from the
+// claims in the context. Raft calls are only taken from other nodes
+func AuthUnaryInterceptor(gnoSQL *in_memory_database.GnoSQL) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !auth.IsEnabled() || info.FullMethod == pb.GnoSQLService_Login_FullMethodName {
+			return handler(ctx, req)
+		}
+
+		if err := rejectAPIKey(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		claims, err := authenticate(ctx, gnoSQL)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		if err := authorizeNode(info.FullMethod, claims); err != nil {
+			return nil, err
+		}
+
+		response, err := handler(auth.WithClaims(ctx, claims), req)
+		if errors.Is(err, auth.ErrPermissionDenied) {
+			return response, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return response, err
+	}
+}
+
+// AuthStreamInterceptor requires a token on every stream while authentication is on, replication and raft snapshot
+// streams are only taken from other nodes
+func AuthStreamInterceptor(gnoSQL *in_memory_database.GnoSQL) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !auth.IsEnabled() {
+			return handler(srv, stream)
+		}
+
+		if err := rejectAPIKey(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		claims, err := authenticate(stream.Context(), gnoSQL)
+		if err != nil {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		if err := authorizeNode(info.FullMethod, claims); err != nil {
+			return err
+		}
+
+		return handler(srv, &claimsStream{ServerStream: stream, ctx: auth.WithClaims(stream.Context(), claims)})
+	}
+}
+
+// authorizeNode answers PermissionDenied to a call of the replication or raft service not signed by another node.
+// Those calls read every database, system included, and change the state of the cluster
+func authorizeNode(fullMethod string, claims auth.Claims) error {
+	if !isClusterMethod(fullMethod) {
+		return nil
+	}
+
+	if claims.APIKeyId != "" || claims.Username != global_constants.NODE_USERNAME {
+		return status.Error(codes.PermissionDenied, global_constants.NODE_ONLY_MSG)
+	}
+	return nil
+}
+
+// rejectAPIKey answers PermissionDenied to a cluster call made with an api key before the key is looked up. Keys are
+// scoped to some databases and collections while cluster calls cover all of them, system included
+func rejectAPIKey(ctx context.Context, fullMethod string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if isClusterMethod(fullMethod) && len(md.Get(global_constants.API_KEY_METADATA)) > 0 {
+		return status.Error(codes.PermissionDenied, global_constants.API_KEY_NOT_ALLOWED_ON_CLUSTER_MSG)
+	}
+	return nil
+}
+
+// isClusterMethod tells the methods nodes call on each other
+func isClusterMethod(fullMethod string) bool {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	return service == pb.ReplicationService_ServiceDesc.ServiceName || service == pb.RaftService_ServiceDesc.ServiceName
+}
+
+// claimsStream hands the context carrying the claims of the caller to the stream handler
+type claimsStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *claimsStream) Context() context.Context {
+	return stream.ctx
+}
+
+func authenticate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (auth.Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, apiKey := range md.Get(global_constants.API_KEY_METADATA) {
+		return service.AuthenticateAPIKey(ctx, gnoSQL, apiKey)
+	}
+
+	for _, authorization := range md.Get(global_constants.AUTHORIZATION_METADATA) {
+		if token := auth.BearerToken(authorization); token != "" {
+			return auth.VerifyToken(token)
+		}
+	}
+
+	return auth.Claims{}, errors.New(global_constants.AUTHENTICATION_REQUIRED_MSG)
+}
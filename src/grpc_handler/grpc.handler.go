@@ -22,7 +22,13 @@ func (s *GnoSQLServer) CreateNewDatabase(ctx context.Context,
 	response := &pb.DatabaseCreateResponse{}
 	var collectionsInput = ConvertReqToCollectionInput(req.GetCollections())
 
-	result, err := service.CreateDatabase(s.GnoSQL, req.DatabaseName, collectionsInput)
+	var configInput = in_memory_database.DatabaseConfigInput{
+		StorageEngine:  req.GetConfig().GetStorageEngine(),
+		MemoryBudgetMB: int(req.GetConfig().GetMemoryBudgetMB()),
+		Compression:    req.GetConfig().GetCompression(),
+	}
+
+	result, err := service.CreateDatabase(ctx, s.GnoSQL, req.DatabaseName, collectionsInput, configInput)
 
 	response.Data = result.Data
 	return response, err
@@ -35,21 +41,21 @@ func (s *GnoSQLServer) ConnectDatabase(ctx context.Context,
 	response := &pb.DatabaseConnectResponse{}
 	var collectionsInput = ConvertReqToCollectionInput(req.GetCollections())
 
-	result := service.ConnectDatabase(s.GnoSQL, req.DatabaseName, collectionsInput)
+	result, err := service.ConnectDatabase(ctx, s.GnoSQL, req.DatabaseName, collectionsInput)
 
 	response.Data = &pb.DatabaseResponse{
 		DatabaseName: result.Data.DatabaseName,
 		Collections:  result.Data.Collections,
 	}
 
-	return response, nil
+	return response, err
 
 }
 
 func (s *GnoSQLServer) DeleteDatabase(ctx context.Context, req *pb.DatabaseDeleteRequest) (*pb.DatabaseDeleteResponse, error) {
 	var response = &pb.DatabaseDeleteResponse{}
 
-	result, err := service.DeleteDatabase(s.GnoSQL, req.DatabaseName)
+	result, err := service.DeleteDatabase(ctx, s.GnoSQL, req.DatabaseName)
 	response.Data = result.Data
 
 	return response, err
@@ -58,7 +64,7 @@ func (s *GnoSQLServer) DeleteDatabase(ctx context.Context, req *pb.DatabaseDelet
 func (s *GnoSQLServer) GetAllDatabases(ctx context.Context, req *pb.NoRequestBody) (*pb.DatabaseGetAllResponse, error) {
 	var response = &pb.DatabaseGetAllResponse{}
 
-	result, err := service.GetAllDatabase(s.GnoSQL)
+	result, err := service.GetAllDatabase(ctx, s.GnoSQL)
 	response.Data = result.Data
 
 	return response, err
@@ -67,7 +73,7 @@ func (s *GnoSQLServer) GetAllDatabases(ctx context.Context, req *pb.NoRequestBod
 func (s *GnoSQLServer) LoadToDisk(ctx context.Context, req *pb.NoRequestBody) (*pb.LoadToDiskResponse, error) {
 	var response = &pb.LoadToDiskResponse{}
 
-	result, err := service.LoadToDisk(s.GnoSQL)
+	result, err := service.LoadToDisk(ctx, s.GnoSQL)
 	response.Data = result.Data
 
 	return response, err
@@ -77,7 +83,7 @@ func (s *GnoSQLServer) CreateNewCollection(ctx context.Context, req *pb.Collecti
 	response := &pb.CollectionCreateResponse{}
 	var collectionsInput = ConvertReqToCollectionInput(req.GetCollections())
 
-	result, err := service.CreateCollections(s.GnoSQL, req.DatabaseName, collectionsInput)
+	result, err := service.CreateCollections(ctx, s.GnoSQL, req.DatabaseName, collectionsInput)
 	response.Data = result.Data
 
 	return response, err
@@ -86,7 +92,7 @@ func (s *GnoSQLServer) CreateNewCollection(ctx context.Context, req *pb.Collecti
 func (s *GnoSQLServer) DeleteCollections(ctx context.Context, req *pb.CollectionDeleteRequest) (*pb.CollectionDeleteResponse, error) {
 	response := &pb.CollectionDeleteResponse{}
 
-	result, err := service.DeleteCollections(s.GnoSQL, req.DatabaseName, req.GetCollections())
+	result, err := service.DeleteCollections(ctx, s.GnoSQL, req.DatabaseName, req.GetCollections())
 	response.Data = result.Data
 
 	return response, err
@@ -97,7 +103,7 @@ func (s *GnoSQLServer) GetAllCollections(ctx context.Context, req *pb.Collection
 
 	response := &pb.CollectionGetAllResponse{}
 
-	result, err := service.GetAllCollections(s.GnoSQL, req.DatabaseName)
+	result, err := service.GetAllCollections(ctx, s.GnoSQL, req.DatabaseName)
 	response.Data = result.Data
 
 	return response, err
@@ -107,12 +113,29 @@ func (s *GnoSQLServer) GetCollectionStats(ctx context.Context, req *pb.Collectio
 
 	response := &pb.CollectionStatsResponse{}
 
-	result, err := service.GetCollectionStats(s.GnoSQL, req.DatabaseName, req.CollectionName)
+	result, err := service.GetCollectionStats(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName)
 
 	response.Data = &pb.CollectionStats{
-		CollectionName: result.Data.CollectionName,
-		IndexKeys:      result.Data.IndexKeys,
-		Documents:      int32(result.Data.Documents),
+		CollectionName:  result.Data.CollectionName,
+		IndexKeys:       result.Data.IndexKeys,
+		Documents:       int32(result.Data.Documents),
+		ResidentBatches: int32(result.Data.ResidentBatches),
+		EvictedBatches:  int32(result.Data.EvictedBatches),
+		CacheHits:       result.Data.CacheHits,
+		CacheMisses:     result.Data.CacheMisses,
+		CacheHitRate:    result.Data.CacheHitRate,
+		Ready:           result.Data.Ready,
+
+		StoredBytes:       result.Data.StoredBytes,
+		UncompressedBytes: result.Data.UncompressedBytes,
+		CompressionRatio:  result.Data.CompressionRatio,
+
+		QueryCacheHits:          result.Data.QueryCacheHits,
+		QueryCacheMisses:        result.Data.QueryCacheMisses,
+		QueryCacheHitRate:       result.Data.QueryCacheHitRate,
+		QueryCacheInvalidations: result.Data.QueryCacheInvalidations,
+		QueryCacheEntries:       int32(result.Data.QueryCacheEntries),
+		QueryCacheBytes:         result.Data.QueryCacheBytes,
 	}
 
 	return response, err
@@ -130,7 +153,7 @@ func (s *GnoSQLServer) CreateDocument(ctx context.Context, req *pb.DocumentCreat
 		return response, errors.New(global_constants.ERROR_WHILE_UNMARSHAL_JSON)
 	}
 
-	result, err := service.DocumentCreate(s.GnoSQL, req.DatabaseName, req.CollectionName, newDocument)
+	result, err := service.DocumentCreate(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName, newDocument)
 
 	if err != nil {
 		return response, err
@@ -146,7 +169,7 @@ func (s *GnoSQLServer) CreateDocument(ctx context.Context, req *pb.DocumentCreat
 func (s *GnoSQLServer) ReadDocument(ctx context.Context, req *pb.DocumentReadRequest) (*pb.DocumentReadResponse, error) {
 	response := &pb.DocumentReadResponse{}
 
-	result, err := service.DocumentRead(s.GnoSQL, req.DatabaseName, req.CollectionName, req.DocId)
+	result, err := service.DocumentRead(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName, req.DocId)
 
 	resultString, err := ConvertDocumentMapToString(result.Data)
 
@@ -170,7 +193,7 @@ func (s *GnoSQLServer) FilterDocument(ctx context.Context, req *pb.DocumentFilte
 		return response, errors.New(global_constants.ERROR_WHILE_UNMARSHAL_JSON)
 	}
 
-	result, err := service.DocumentFilter(s.GnoSQL, req.DatabaseName, req.CollectionName, filter)
+	result, err := service.DocumentFilter(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName, filter, req.Explain)
 
 	if err != nil {
 		return response, err
@@ -180,6 +203,14 @@ func (s *GnoSQLServer) FilterDocument(ctx context.Context, req *pb.DocumentFilte
 
 	response.Data = resultString
 
+	if err == nil && result.Plan != nil {
+		plan, marshalErr := json.Marshal(result.Plan)
+		if marshalErr != nil {
+			return response, marshalErr
+		}
+		response.Plan = string(plan)
+	}
+
 	return response, err
 }
 
@@ -194,7 +225,7 @@ func (s *GnoSQLServer) UpdateDocument(ctx context.Context, req *pb.DocumentUpdat
 		return response, errors.New(global_constants.ERROR_WHILE_UNMARSHAL_JSON)
 	}
 
-	result, err := service.DocumentUpdate(s.GnoSQL, req.DatabaseName, req.CollectionName, req.DocId, document)
+	result, err := service.DocumentUpdate(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName, req.DocId, document)
 	if err != nil {
 		return response, err
 	}
@@ -209,7 +240,7 @@ func (s *GnoSQLServer) UpdateDocument(ctx context.Context, req *pb.DocumentUpdat
 func (s *GnoSQLServer) DeleteDocument(ctx context.Context, req *pb.DocumentDeleteRequest) (*pb.DocumentDeleteResponse, error) {
 	response := &pb.DocumentDeleteResponse{}
 
-	result, err := service.DocumentDelete(s.GnoSQL, req.DatabaseName, req.CollectionName, req.DocId)
+	result, err := service.DocumentDelete(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName, req.DocId)
 	if err != nil {
 		return response, err
 	}
@@ -221,7 +252,7 @@ func (s *GnoSQLServer) DeleteDocument(ctx context.Context, req *pb.DocumentDelet
 func (s *GnoSQLServer) GetAllDocuments(ctx context.Context, req *pb.DocumentGetAllRequest) (*pb.DocumentGetAllResponse, error) {
 	response := &pb.DocumentGetAllResponse{}
 
-	result, err := service.DocumentGetAll(s.GnoSQL, req.DatabaseName, req.CollectionName)
+	result, err := service.DocumentGetAll(ctx, s.GnoSQL, req.DatabaseName, req.CollectionName)
 
 	if err != nil {
 		return response, err
@@ -233,6 +264,18 @@ func (s *GnoSQLServer) GetAllDocuments(ctx context.Context, req *pb.DocumentGetA
 
 	return response, err
 }
+
+func (s *GnoSQLServer) Login(ctx context.Context,
+	req *pb.LoginRequest) (*pb.LoginResponse, error) {
+
+	response := &pb.LoginResponse{}
+
+	result, err := service.Login(ctx, s.GnoSQL, req.GetUsername(), req.GetPassword())
+
+	response.Data = result.Data
+	return response, err
+}
+
 func ConvertReqToCollectionInput(collections []*pb.CollectionInput) []in_memory_database.CollectionInput {
 
 	var collectionsInput []in_memory_database.CollectionInput
@@ -241,6 +284,8 @@ func ConvertReqToCollectionInput(collections []*pb.CollectionInput) []in_memory_
 		collectionInput := in_memory_database.CollectionInput{
 			CollectionName: EachInput.CollectionName,
 			IndexKeys:      EachInput.IndexKeys,
+			ShardKey:       EachInput.ShardKey,
+			QueryCacheMB:   int(EachInput.QueryCacheMB),
 		}
 		collectionsInput = append(collectionsInput, collectionInput)
 	}
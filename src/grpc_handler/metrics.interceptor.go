@@ -0,0 +1,42 @@
+package grpc_handler
+
+import (
+	"context"
+	"gnosql/src/metrics"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsUnaryInterceptor counts and times every call by method and status code, calls refused by the auth
+// interceptor included
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		startedAt := time.Now()
+
+		response, err := handler(ctx, req)
+
+		observeCall(info.FullMethod, startedAt, err)
+
+		return response, err
+	}
+}
+
+// MetricsStreamInterceptor counts and times streams like MetricsUnaryInterceptor, a stream is timed until it ends
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startedAt := time.Now()
+
+		err := handler(srv, stream)
+
+		observeCall(info.FullMethod, startedAt, err)
+
+		return err
+	}
+}
+
+func observeCall(method string, startedAt time.Time, err error) {
+	metrics.GrpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	metrics.GrpcRequestDuration.WithLabelValues(method).Observe(time.Since(startedAt).Seconds())
+}
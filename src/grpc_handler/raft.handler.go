@@ -0,0 +1,84 @@
+package grpc_handler
+
+import (
+	"bytes"
+	"context"
+	pb "gnosql/proto"
+	"gnosql/src/raft"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RaftServer struct {
+	pb.UnimplementedRaftServiceServer
+
+	Node *raft.Node
+}
+
+func (s *RaftServer) RequestVote(ctx context.Context, req *pb.RaftVoteRequest) (*pb.RaftVoteResponse, error) {
+	response := s.Node.HandleRequestVote(raft.VoteRequest{
+		Term:         req.Term,
+		CandidateId:  req.CandidateId,
+		LastLogIndex: req.LastLogIndex,
+		LastLogTerm:  req.LastLogTerm,
+	})
+
+	return &pb.RaftVoteResponse{Term: response.Term, VoteGranted: response.VoteGranted}, nil
+}
+
+func (s *RaftServer) AppendEntries(ctx context.Context, req *pb.RaftAppendRequest) (*pb.RaftAppendResponse, error) {
+	response := s.Node.HandleAppendEntries(raft.AppendRequest{
+		Term:         req.Term,
+		LeaderId:     req.LeaderId,
+		PrevLogIndex: req.PrevLogIndex,
+		PrevLogTerm:  req.PrevLogTerm,
+		Entries:      raft.EntriesFromProto(req.Entries),
+		LeaderCommit: req.LeaderCommit,
+	})
+
+	return &pb.RaftAppendResponse{Term: response.Term, Success: response.Success, ConflictIndex: response.ConflictIndex}, nil
+}
+
+func (s *RaftServer) InstallSnapshot(stream pb.RaftService_InstallSnapshotServer) error {
+	var request raft.SnapshotRequest
+	var data bytes.Buffer
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		request.Term = chunk.Term
+		request.LeaderId = chunk.LeaderId
+		request.LastIncludedIndex = chunk.LastIncludedIndex
+		request.LastIncludedTerm = chunk.LastIncludedTerm
+		data.Write(chunk.Data)
+	}
+
+	request.Data = data.Bytes()
+	response := s.Node.HandleInstallSnapshot(request)
+
+	return stream.SendAndClose(&pb.RaftSnapshotResponse{Term: response.Term})
+}
+
+// Propose accepts commands forwarded by followers, only the leader accepts them so a command is never forwarded twice
+func (s *RaftServer) Propose(ctx context.Context, req *pb.RaftProposeRequest) (*pb.RaftProposeResponse, error) {
+	if !s.Node.IsLeader() {
+		return nil, status.Error(codes.Unavailable, raft.ErrNotLeader.Error())
+	}
+
+	index, err := s.Node.Propose(req.Data)
+
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &pb.RaftProposeResponse{Index: index}, nil
+}
@@ -0,0 +1,106 @@
+package grpc_handler
+
+import (
+	pb "gnosql/proto"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ReplicationServer struct {
+	pb.UnimplementedReplicationServiceServer
+
+	GnoSQL *in_memory_database.GnoSQL
+}
+
+func (s *ReplicationServer) Snapshot(req *pb.SnapshotRequest, stream pb.ReplicationService_SnapshotServer) error {
+	seq, snapshotFiles, err := s.GnoSQL.CreateSnapshot()
+
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	var logId = s.GnoSQL.ReplicationLog.LogId
+
+	// an empty chunk carries seq even if there is no database yet
+	if err := stream.Send(&pb.SnapshotChunk{Seq: seq, LogId: logId}); err != nil {
+		return err
+	}
+
+	for _, snapshotFile := range snapshotFiles {
+		for start := 0; start == 0 || start < len(snapshotFile.Data); start += global_constants.REPLICATION_SNAPSHOT_CHUNK_SIZE {
+			end := min(start+global_constants.REPLICATION_SNAPSHOT_CHUNK_SIZE, len(snapshotFile.Data))
+
+			chunk := &pb.SnapshotChunk{
+				Path:  snapshotFile.Path,
+				Data:  snapshotFile.Data[start:end],
+				Seq:   seq,
+				LogId: logId,
+			}
+
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ReplicationServer) StreamMutations(req *pb.ReplicationStreamRequest, stream pb.ReplicationService_StreamMutationsServer) error {
+	var replicationLog = s.GnoSQL.ReplicationLog
+	var nextSeq = req.FromSeq
+
+	if req.LogId != replicationLog.LogId {
+		return status.Error(codes.OutOfRange, global_constants.REPLICATION_LOG_TRUNCATED_MSG)
+	}
+
+	heartbeat := time.NewTicker(global_constants.REPLICATION_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	for {
+		entries, notify, err := replicationLog.ReadFrom(nextSeq, global_constants.REPLICATION_BATCH_SIZE)
+
+		if err != nil {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+
+		for _, entry := range entries {
+			entryGobData, err := common.EncodeGob(entry)
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+
+			response := &pb.ReplicationEntry{
+				Seq:       entry.Seq,
+				Data:      entryGobData,
+				LeaderSeq: replicationLog.LastSeq(),
+				LogId:     replicationLog.LogId,
+			}
+
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+			nextSeq = entry.Seq + 1
+		}
+
+		if len(entries) > 0 {
+			continue
+		}
+
+		select {
+		case <-notify:
+		case <-heartbeat.C:
+			// entry without data, keeps the replica's view of the primary sequence fresh
+			if err := stream.Send(&pb.ReplicationEntry{LeaderSeq: replicationLog.LastSeq(), LogId: replicationLog.LogId}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
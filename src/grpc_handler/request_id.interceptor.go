@@ -0,0 +1,69 @@
+package grpc_handler
+
+import (
+	"context"
+	"gnosql/src/global_constants"
+	"gnosql/src/logging"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Get(global_constants.LOG_SERVER)
+
+// RequestIdUnaryInterceptor gives every call an id, the one sent in the x-request-id metadata or a new one, sends it
+// back in the x-request-id header and puts it in the context so the logs of the call show it
+func RequestIdUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestId(ctx)
+		startedAt := time.Now()
+
+		response, err := handler(ctx, req)
+
+		logger.DebugContext(ctx, "call", "method", info.FullMethod, "code", status.Code(err).String(), "took", time.Since(startedAt))
+
+		return response, err
+	}
+}
+
+// RequestIdStreamInterceptor gives every stream an id like RequestIdUnaryInterceptor
+func RequestIdStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestId(stream.Context())
+		startedAt := time.Now()
+
+		err := handler(srv, &requestIdStream{ServerStream: stream, ctx: ctx})
+
+		logger.DebugContext(ctx, "stream", "method", info.FullMethod, "code", status.Code(err).String(), "took", time.Since(startedAt))
+
+		return err
+	}
+}
+
+func withRequestId(ctx context.Context) context.Context {
+	var requestId string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(global_constants.REQUEST_ID_METADATA); len(values) > 0 {
+			requestId = values[0]
+		}
+	}
+	if requestId == "" || len(requestId) > global_constants.REQUEST_ID_MAX_LENGTH {
+		requestId = logging.NewRequestId()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(global_constants.REQUEST_ID_METADATA, requestId))
+
+	return logging.WithRequestId(ctx, requestId)
+}
+
+// requestIdStream hands the context carrying the request id to the stream handler
+type requestIdStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *requestIdStream) Context() context.Context {
+	return stream.ctx
+}
@@ -1,21 +1,26 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"gnosql/src/auth"
 	"gnosql/src/global_constants"
 	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
 	"gnosql/src/service"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+var logger = logging.Get(global_constants.LOG_SERVER)
+
 // @Summary      Create new database
 // @Description  To create a new database
 // @Tags         database
 // @Accept       json
 // @Produce      json
-// @Param        requestBody  body  in_memory_database.DatabaseCreateRequest  true  "Database creation request containing databaseName and collections"
+// @Param        requestBody  body  in_memory_database.DatabaseCreateRequest  true  "Database creation request containing databaseName, collections and config"
 // @Success      200  {object}  in_memory_database.DatabaseCreateResult  "Database created successfully"
 // @Failure      400  {object}  map[string]string  "Database already exists or error while binding JSON"
 // @Router       /database/add [post]
@@ -27,7 +32,7 @@ func CreateDatabase(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.CreateDatabase(gnoSQL, requestBody.DatabaseName, requestBody.Collections)
+	result, err := service.CreateDatabase(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.Collections, requestBody.Config)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -49,9 +54,9 @@ func ConnectDatabase(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result := service.ConnectDatabase(gnoSQL, requestBody.DatabaseName, requestBody.Collections)
+	result, err := service.ConnectDatabase(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.Collections)
 
-	c.JSON(GetResponse(result, nil))
+	c.JSON(GetResponse(result, err))
 }
 
 // @Summary      Delete database
@@ -71,7 +76,7 @@ func DeleteDatabase(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DeleteDatabase(gnoSQL, requestBody.DatabaseName)
+	result, err := service.DeleteDatabase(c.Request.Context(), gnoSQL, requestBody.DatabaseName)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -84,7 +89,7 @@ func DeleteDatabase(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /database/get-all [get]
 func GetAllDatabases(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
-	result, err := service.GetAllDatabase(gnoSQL)
+	result, err := service.GetAllDatabase(c.Request.Context(), gnoSQL)
 	c.JSON(GetResponse(result, err))
 }
 
@@ -96,7 +101,53 @@ func GetAllDatabases(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 // @Failure      500  {object}  map[string]string  "Error loading database to disk"
 // @Router       /database/load-to-disk [get]
 func LoadDatabaseToDisk(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
-	result, err := service.LoadToDisk(gnoSQL)
+	result, err := service.LoadToDisk(c.Request.Context(), gnoSQL)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Backup database
+// @Description  Download a consistent snapshot of a database, or of all databases when databaseName is empty, as a tar.gz archive
+// @Tags         database
+// @Produce      application/gzip
+// @Param        databaseName  query  string  false  "database to backup"
+// @Success      200  {file}  file  "Backup archive"
+// @Failure      400  {object}  map[string]string  "Database not found"
+// @Router       /database/backup [get]
+func BackupDatabase(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	backup, err := service.CreateBackup(c.Request.Context(), gnoSQL, c.Query("databaseName"))
+
+	if err != nil {
+		c.JSON(GetResponse(backup, err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%v", backup.FileName()))
+	c.Header("Content-Type", "application/gzip")
+	c.Status(http.StatusOK)
+
+	if err := backup.WriteArchive(c.Writer); err != nil {
+		logger.ErrorContext(c.Request.Context(), "backup archive write error", "error", err)
+	}
+}
+
+// @Summary      Rotate database key
+// @Description  Make a new data key the active one of a database and re-encrypt its files in the background
+// @Tags         database
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.DatabaseRotateKeyRequest true "databaseName"
+// @Success      200  {object}  in_memory_database.DatabaseRotateKeyResult  "Key rotation started"
+// @Failure      400  {object}  map[string]string  "Database not found or encryption not enabled"
+// @Router       /database/rotate-key [post]
+func RotateDatabaseKey(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.DatabaseRotateKeyRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.RotateDatabaseKey(c.Request.Context(), gnoSQL, requestBody.DatabaseName)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -118,7 +169,7 @@ func CreateCollection(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.CreateCollections(gnoSQL, requestBody.DatabaseName, requestBody.Collections)
+	result, err := service.CreateCollections(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.Collections)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -140,7 +191,7 @@ func DeleteCollection(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DeleteCollections(gnoSQL, requestBody.DatabaseName, requestBody.Collections)
+	result, err := service.DeleteCollections(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.Collections)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -161,7 +212,7 @@ func GetAllCollections(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.GetAllCollections(gnoSQL, requestBody.DatabaseName)
+	result, err := service.GetAllCollections(c.Request.Context(), gnoSQL, requestBody.DatabaseName)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -182,7 +233,49 @@ func CollectionStats(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.GetCollectionStats(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName)
+	result, err := service.GetCollectionStats(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Compact collection
+// @Description  Merge under-filled batches of a collection and delete their files
+// @Tags         collection
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.CollectionCompactRequest true "databaseName, collectionName"
+// @Success      200  {object}  in_memory_database.CollectionCompactResult  "Batches before and after compaction"
+// @Failure      400  {object}  map[string]string  "Database or Collection not found or deleted"
+// @Router       /collection/compact [post]
+func CompactCollection(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.CollectionCompactRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.CompactCollection(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Set query cache
+// @Description  Cap the memory for cached filter results of a collection in MB, 0 turns the cache off
+// @Tags         collection
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.CollectionQueryCacheRequest true "databaseName, collectionName, queryCacheMB"
+// @Success      200  {object}  in_memory_database.CollectionQueryCacheResult  "Query cache updated successfully"
+// @Failure      400  {object}  map[string]string  "Database or Collection not found or deleted"
+// @Router       /collection/query-cache [post]
+func SetQueryCache(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.CollectionQueryCacheRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.SetQueryCache(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.QueryCacheMB)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -204,7 +297,7 @@ func CreateDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DocumentCreate(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.Document)
+	result, err := service.DocumentCreate(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.Document)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -225,7 +318,7 @@ func ReadDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DocumentRead(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.DocId)
+	result, err := service.DocumentRead(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.DocId)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -234,8 +327,8 @@ func ReadDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 // @Description  Filter document
 // @Tags         document
 // @Produce      json
-// @Param        requestBody  body   in_memory_database.DocumentFilterRequest true "databaseName, collectionName, filter"
-// @Success      200 {array}  in_memory_database.Document
+// @Param        requestBody  body   in_memory_database.DocumentFilterRequest true "databaseName, collectionName, filter, explain"
+// @Success      200 {object}  in_memory_database.DocumentFilterResult
 // @Success   	 400 "Database/Collection deleted"
 // @Router       /document/filter [post]
 func FilterDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
@@ -246,8 +339,7 @@ func FilterDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DocumentFilter(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.Filter)
-	fmt.Printf("\n result %v \n err %v", result, err)
+	result, err := service.DocumentFilter(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.Filter, requestBody.Explain)
 	c.JSON(GetResponse(result, err))
 }
 
@@ -267,7 +359,7 @@ func UpdateDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DocumentUpdate(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.DocId, requestBody.Document)
+	result, err := service.DocumentUpdate(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.DocId, requestBody.Document)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -288,7 +380,7 @@ func DeleteDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DocumentDelete(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.DocId)
+	result, err := service.DocumentDelete(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName, requestBody.DocId)
 
 	c.JSON(GetResponse(result, err))
 }
@@ -309,14 +401,442 @@ func ReadAllDocument(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
 		return
 	}
 
-	result, err := service.DocumentGetAll(gnoSQL, requestBody.DatabaseName, requestBody.CollectionName)
+	result, err := service.DocumentGetAll(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.CollectionName)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Replication stats
+// @Description  Role of this node, replication log position and lag of a replica behind its primary
+// @Tags         replication
+// @Produce      json
+// @Success      200  {object}  in_memory_database.ReplicationStatsResult  "Replication stats"
+// @Router       /replication/stats [get]
+func ReplicationStats(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	result, err := service.GetReplicationStats(c.Request.Context(), gnoSQL)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Shard map
+// @Description  Shards behind this router and the shard key of every collection
+// @Tags         sharding
+// @Produce      json
+// @Success      200  {object}  in_memory_database.ShardMapResult  "Shard map"
+// @Router       /shard-map [get]
+func GetShardMap(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	result, err := service.GetShardMap(c.Request.Context(), gnoSQL)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Create webhook
+// @Description  Subscribe a url to document changes of a database or collection
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.WebhookCreateRequest true "databaseName, collectionName, url, secret, events, filter"
+// @Success      200  {object}  in_memory_database.WebhookCreateResult  "Webhook created successfully"
+// @Failure      400  {object}  map[string]string  "Database/Collection not found or error while binding JSON"
+// @Router       /webhook/add [post]
+func CreateWebhook(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.WebhookCreateRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.WebhookCreate(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Delete webhook
+// @Description  Remove a webhook subscription
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.WebhookDeleteRequest true "databaseName, webhookId"
+// @Success      200  {object}  in_memory_database.WebhookDeleteResult  "Webhook deleted successfully"
+// @Failure      400  {object}  map[string]string  "Database/Webhook not found or error while binding JSON"
+// @Router       /webhook/delete [post]
+func DeleteWebhook(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.WebhookDeleteRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.WebhookDelete(c.Request.Context(), gnoSQL, requestBody.DatabaseName, requestBody.WebhookId)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Get all webhooks
+// @Description  Retrieve all webhook subscriptions of a database
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.WebhookGetAllRequest true "databaseName"
+// @Success      200  {object}  in_memory_database.WebhookGetAllResult  "List of webhooks"
+// @Failure      400  {object}  map[string]string  "Database not found or error while binding JSON"
+// @Router       /webhook/get-all [post]
+func GetAllWebhooks(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.WebhookGetAllRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.WebhookGetAll(c.Request.Context(), gnoSQL, requestBody.DatabaseName)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Webhook dead letters
+// @Description  Retrieve webhook deliveries of a database that failed after all retries
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.WebhookGetAllRequest true "databaseName"
+// @Success      200  {object}  in_memory_database.WebhookDeadLettersResult  "List of failed deliveries"
+// @Failure      400  {object}  map[string]string  "Database not found or error while binding JSON"
+// @Router       /webhook/dead-letters [post]
+func GetWebhookDeadLetters(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.WebhookGetAllRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.WebhookDeadLetters(c.Request.Context(), gnoSQL, requestBody.DatabaseName)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Login
+// @Description  Exchange a username and password for a token, send it as "Authorization: Bearer <token>"
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.LoginRequest true "username, password"
+// @Success      200  {object}  in_memory_database.LoginResult  "Token"
+// @Failure      401  {object}  in_memory_database.Result  "Invalid username or password"
+// @Router       /auth/login [post]
+func Login(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.LoginRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.Login(c.Request.Context(), gnoSQL, requestBody.Username, requestBody.Password)
+
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, in_memory_database.Result{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      Create user
+// @Description  Create a user with a password and roles
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.UserCreateRequest true "username, password, roles"
+// @Success      200  {object}  in_memory_database.UserUpdateResult  "User created successfully"
+// @Failure      400  {object}  map[string]string  "User already exists or role not found"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /user/add [post]
+func CreateUser(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.UserCreateRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.UserCreate(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Delete user
+// @Description  Delete a user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.UserDeleteRequest true "username"
+// @Success      200  {object}  in_memory_database.UserUpdateResult  "User deleted successfully"
+// @Failure      400  {object}  map[string]string  "User not found"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /user/delete [post]
+func DeleteUser(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.UserDeleteRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.UserDelete(c.Request.Context(), gnoSQL, requestBody.Username)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Change password
+// @Description  Change the password of a user, users can change their own password
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.UserPasswordRequest true "username, password"
+// @Success      200  {object}  in_memory_database.UserUpdateResult  "User updated successfully"
+// @Failure      400  {object}  map[string]string  "User not found"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /user/password [post]
+func SetUserPassword(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.UserPasswordRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.UserSetPassword(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Set user roles
+// @Description  Replace the roles of a user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.UserRolesRequest true "username, roles"
+// @Success      200  {object}  in_memory_database.UserUpdateResult  "User updated successfully"
+// @Failure      400  {object}  map[string]string  "User or role not found"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /user/roles [post]
+func SetUserRoles(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.UserRolesRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.UserSetRoles(c.Request.Context(), gnoSQL, requestBody)
 
 	c.JSON(GetResponse(result, err))
 }
 
+// @Summary      Get all users
+// @Description  Get every user with its roles
+// @Tags         user
+// @Produce      json
+// @Success      200  {object}  in_memory_database.UserGetAllResult  "Users"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /user/get-all [get]
+func GetAllUsers(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	result, err := service.UserGetAll(c.Request.Context(), gnoSQL)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Create role
+// @Description  Create a role with grants of read, write or admin on a database or a collection
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.RoleCreateRequest true "name, grants"
+// @Success      200  {object}  in_memory_database.RoleUpdateResult  "Role created successfully"
+// @Failure      400  {object}  map[string]string  "Role already exists or invalid grant"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /role/add [post]
+func CreateRole(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.RoleCreateRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.RoleCreate(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Delete role
+// @Description  Delete a role and take it away from its users
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.RoleDeleteRequest true "name"
+// @Success      200  {object}  in_memory_database.RoleUpdateResult  "Role deleted successfully"
+// @Failure      400  {object}  map[string]string  "Role not found"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /role/delete [post]
+func DeleteRole(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.RoleDeleteRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.RoleDelete(c.Request.Context(), gnoSQL, requestBody.Name)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Grant privilege
+// @Description  Add a grant to a role
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.RoleGrantRequest true "name, grant"
+// @Success      200  {object}  in_memory_database.RoleUpdateResult  "Role updated successfully"
+// @Failure      400  {object}  map[string]string  "Role not found or invalid grant"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /role/grant [post]
+func GrantRole(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.RoleGrantRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.RoleGrant(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Revoke privilege
+// @Description  Remove a grant from a role
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.RoleGrantRequest true "name, grant"
+// @Success      200  {object}  in_memory_database.RoleUpdateResult  "Role updated successfully"
+// @Failure      400  {object}  map[string]string  "Role not found or invalid grant"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /role/revoke [post]
+func RevokeRole(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.RoleGrantRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.RoleRevoke(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Get all roles
+// @Description  Get every role with its grants
+// @Tags         role
+// @Produce      json
+// @Success      200  {object}  in_memory_database.RoleGetAllResult  "Roles"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /role/get-all [get]
+func GetAllRoles(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	result, err := service.RoleGetAll(c.Request.Context(), gnoSQL)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Create api key
+// @Description  Create an api key for a service account, with grants like a role and an optional expiry. The key is shown only once, send it as "X-API-Key: <key>"
+// @Tags         api-key
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.APIKeyCreateRequest true "name, grants, expiresAt"
+// @Success      200  {object}  in_memory_database.APIKeyCreateResult  "Key id and key"
+// @Failure      400  {object}  map[string]string  "Name missing, invalid grant or expiresAt"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /api-key/add [post]
+func CreateAPIKey(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.APIKeyCreateRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.APIKeyCreate(c.Request.Context(), gnoSQL, requestBody)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Revoke api key
+// @Description  Delete an api key, requests made with it are refused from then on
+// @Tags         api-key
+// @Accept       json
+// @Produce      json
+// @Param        requestBody  body  in_memory_database.APIKeyDeleteRequest true "keyId"
+// @Success      200  {object}  in_memory_database.APIKeyDeleteResult  "API key revoked successfully"
+// @Failure      400  {object}  map[string]string  "API key not found"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /api-key/delete [post]
+func DeleteAPIKey(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	var requestBody in_memory_database.APIKeyDeleteRequest
+
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, global_constants.ERROR_WHILE_BINDING_JSON)
+		return
+	}
+
+	result, err := service.APIKeyDelete(c.Request.Context(), gnoSQL, requestBody.KeyId)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Get all api keys
+// @Description  Get every api key with its grants, expiry and when it was last used
+// @Tags         api-key
+// @Produce      json
+// @Success      200  {object}  in_memory_database.APIKeyGetAllResult  "API keys"
+// @Failure      403  {object}  in_memory_database.Result  "Permission denied"
+// @Router       /api-key/get-all [get]
+func GetAllAPIKeys(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	result, err := service.APIKeyGetAll(c.Request.Context(), gnoSQL)
+
+	c.JSON(GetResponse(result, err))
+}
+
+// @Summary      Readiness
+// @Description  200 once the databases are loaded and the node can serve requests, 503 with the loading progress before that
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  in_memory_database.LoadProgressResult  "Ready"
+// @Failure      503  {object}  in_memory_database.LoadProgressResult  "Loading"
+// @Router       /health/ready [get]
+func HealthReady(c *gin.Context, gnoSQL *in_memory_database.GnoSQL) {
+	result, _ := service.GetLoadProgress(c.Request.Context(), gnoSQL)
+
+	if !result.Data.Ready {
+		c.JSON(http.StatusServiceUnavailable, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func GetResponse(result interface{}, err error) (int, interface{}) {
 	if err == nil {
 		return http.StatusOK, result
+	} else if errors.Is(err, auth.ErrPermissionDenied) {
+		return http.StatusForbidden, in_memory_database.Result{Error: err.Error()}
 	} else {
 		return http.StatusBadRequest, err
 	}
@@ -0,0 +1,303 @@
+package in_memory_database
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type BackupFile struct {
+	Path   string `json:"path"` // relative to the data folder
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+type BackupManifest struct {
+	Version   int          `json:"version"`
+	CreatedAt string       `json:"createdAt"`
+	Databases []string     `json:"databases"`
+	Files     []BackupFile `json:"files"`
+}
+
+// Backup is a point in time copy of one or more databases, written as a tar.gz archive with the manifest first
+type Backup struct {
+	Manifest BackupManifest
+	Files    []SnapshotFile
+}
+
+// CreateBackup captures databaseName, or every database when it is empty.
+// Writers of every collection are paused at a barrier until all of them are captured, other databases keep accepting writes.
+func (gnoSQL *GnoSQL) CreateBackup(databaseName string) (Backup, error) {
+	var databases = gnoSQL.GetAllDBs()
+
+	if databaseName != "" {
+		db := gnoSQL.GetDB(databaseName)
+		if db == nil {
+			return Backup{}, errors.New(global_constants.DATABASE_NOT_FOUND_MSG)
+		}
+		databases = []*Database{db}
+	}
+
+	var collections = make([]*Collection, 0)
+	for _, db := range databases {
+		collections = append(collections, db.GetAllColls()...)
+	}
+
+	for _, collection := range collections {
+		collection.barrier.Lock()
+	}
+
+	var snapshotFiles = make([]SnapshotFile, 0)
+	var databaseNames = make([]string, 0)
+	var err error
+
+	for _, db := range databases {
+		var databaseFiles []SnapshotFile
+
+		if databaseFiles, err = db.snapshotFiles(); err != nil {
+			break
+		}
+		snapshotFiles = append(snapshotFiles, databaseFiles...)
+		databaseNames = append(databaseNames, db.DatabaseName)
+	}
+
+	for _, collection := range collections {
+		collection.barrier.Unlock()
+	}
+
+	if err != nil {
+		return Backup{}, err
+	}
+
+	backup := Backup{
+		Manifest: BackupManifest{
+			Version:   global_constants.BACKUP_VERSION,
+			CreatedAt: common.TimeToString(time.Now()),
+			Databases: databaseNames,
+			Files:     make([]BackupFile, 0),
+		},
+		Files: snapshotFiles,
+	}
+
+	for _, snapshotFile := range snapshotFiles {
+		backup.Manifest.Files = append(backup.Manifest.Files, BackupFile{
+			Path:   filepath.ToSlash(snapshotFile.Path),
+			Size:   int64(len(snapshotFile.Data)),
+			Sha256: checksum(snapshotFile.Data),
+		})
+	}
+
+	return backup, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (backup Backup) FileName() string {
+	var name = "gnosql"
+	if len(backup.Manifest.Databases) == 1 {
+		name = backup.Manifest.Databases[0]
+	}
+	return fmt.Sprintf("%v-backup-%v.tar.gz", name, time.Now().UTC().Format("20060102-150405"))
+}
+
+func (backup Backup) WriteArchive(writer io.Writer) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestData, err := json.MarshalIndent(backup.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeTarFile(tarWriter, global_constants.BACKUP_MANIFEST_FILE_NAME, manifestData); err != nil {
+		return err
+	}
+
+	for _, snapshotFile := range backup.Files {
+		if err := writeTarFile(tarWriter, filepath.ToSlash(snapshotFile.Path), snapshotFile.Data); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tarWriter.Write(data)
+	return err
+}
+
+// ReadBackupArchive reads an archive written by WriteArchive and verifies every file against the manifest
+func ReadBackupArchive(reader io.Reader) (Backup, error) {
+	var backup Backup
+	var manifestFound = false
+	var files = make(map[string][]byte)
+
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return backup, err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return backup, err
+		}
+
+		var data bytes.Buffer
+		if _, err := io.Copy(&data, tarReader); err != nil {
+			return backup, err
+		}
+
+		if header.Name == global_constants.BACKUP_MANIFEST_FILE_NAME {
+			if err := json.Unmarshal(data.Bytes(), &backup.Manifest); err != nil {
+				return backup, err
+			}
+			manifestFound = true
+			continue
+		}
+
+		files[header.Name] = data.Bytes()
+	}
+
+	if !manifestFound {
+		return backup, errors.New(global_constants.BACKUP_MANIFEST_MISSING_MSG)
+	}
+
+	for _, backupFile := range backup.Manifest.Files {
+		data, exists := files[backupFile.Path]
+
+		if !exists || int64(len(data)) != backupFile.Size || checksum(data) != backupFile.Sha256 {
+			return backup, fmt.Errorf("%v: %v", global_constants.BACKUP_CHECKSUM_MISMATCH_MSG, backupFile.Path)
+		}
+
+		if !filepath.IsLocal(filepath.FromSlash(backupFile.Path)) {
+			return backup, fmt.Errorf("backup file %v is outside of the database folder", backupFile.Path)
+		}
+
+		backup.Files = append(backup.Files, SnapshotFile{Path: filepath.FromSlash(backupFile.Path), Data: data})
+	}
+
+	return backup, nil
+}
+
+// RestoreBackup writes sourceDatabaseName of the backup to disk as databaseName, it is loaded with the other databases on start.
+// sourceDatabaseName may be empty if the backup holds one database, databaseName defaults to the source name.
+func RestoreBackup(dataPath string, backup Backup, sourceDatabaseName string, databaseName string) error {
+	if sourceDatabaseName == "" {
+		if len(backup.Manifest.Databases) != 1 {
+			return errors.New(global_constants.BACKUP_SOURCE_REQUIRED_MSG)
+		}
+		sourceDatabaseName = backup.Manifest.Databases[0]
+	}
+
+	if databaseName == "" {
+		databaseName = sourceDatabaseName
+	}
+
+	var isSourceFound = false
+	for _, name := range backup.Manifest.Databases {
+		isSourceFound = isSourceFound || name == sourceDatabaseName
+	}
+	if !isSourceFound {
+		return errors.New(global_constants.DATABASE_NOT_FOUND_MSG)
+	}
+
+	if _, err := os.Stat(common.GetDatabaseFolderPath(dataPath, databaseName)); err == nil {
+		return errors.New(global_constants.DATABASE_ALREADY_EXISTS_MSG)
+	}
+
+	var restoredFiles = make([]SnapshotFile, 0)
+
+	for _, snapshotFile := range backup.Files {
+		parts := strings.Split(snapshotFile.Path, string(filepath.Separator))
+
+		if parts[0] != sourceDatabaseName {
+			continue
+		}
+
+		data, err := renameBackupFile(snapshotFile, databaseName)
+		if err != nil {
+			return err
+		}
+
+		var fileName = parts[len(parts)-1]
+		if strings.HasSuffix(fileName, global_constants.DB_EXTENSION) {
+			fileName = common.GetDatabaseFileName(databaseName)
+		}
+		parts[0], parts[len(parts)-1] = databaseName, fileName
+
+		restoredFiles = append(restoredFiles, SnapshotFile{Path: filepath.Join(parts...), Data: data})
+	}
+
+	return WriteSnapshotFiles(dataPath, restoredFiles)
+}
+
+// renameBackupFile rewrites the database name stored inside database and collection files
+func renameBackupFile(snapshotFile SnapshotFile, databaseName string) ([]byte, error) {
+	switch {
+	case strings.HasSuffix(snapshotFile.Path, global_constants.DB_EXTENSION):
+		var databaseFile DatabaseFileStruct
+		if err := common.DecodeGob(snapshotFile.Data, &databaseFile); err != nil {
+			return nil, err
+		}
+
+		databaseFile.DatabaseName = databaseName
+
+		if webhooks, ok := databaseFile.Config[global_constants.CONFIG_WEBHOOKS].([]Webhook); ok {
+			for i := range webhooks {
+				webhooks[i].DatabaseName = databaseName
+			}
+		}
+
+		return common.EncodeGob(databaseFile)
+
+	case strings.HasSuffix(snapshotFile.Path, global_constants.COLLECTION_EXTENSION):
+		var collectionFile CollectionFileStruct
+		if err := common.DecodeGob(snapshotFile.Data, &collectionFile); err != nil {
+			return nil, err
+		}
+
+		collectionFile.DatabaseName = databaseName
+
+		return common.EncodeGob(collectionFile)
+	}
+
+	return snapshotFile.Data, nil
+}
@@ -0,0 +1,242 @@
+package in_memory_database
+
+import (
+	"errors"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"sort"
+	"sync"
+)
+
+// BatchCache keeps the resident batches of a database within its memory budget. Once over budget the least recently used
+// clean batches are dropped from memory and read back from their batch files on access. Sizes are the encoded size of the batches.
+type BatchCache struct {
+	mu            sync.Mutex
+	budget        int64 // bytes, 0 keeps every batch resident
+	clock         uint64
+	entries       map[batchCacheKey]*batchCacheEntry
+	residentBytes int64
+}
+
+type batchCacheKey struct {
+	collection *Collection
+	batchId    string
+}
+
+type batchCacheEntry struct {
+	size     int64
+	lastUsed uint64
+}
+
+func NewBatchCache(budget int64) *BatchCache {
+	return &BatchCache{budget: budget, entries: make(map[batchCacheKey]*batchCacheEntry)}
+}
+
+// memoryBudget returns the budget in bytes named in Config, 0 when the database has none
+func (config Config) memoryBudget() int64 {
+	if budgetMB, ok := config[global_constants.CONFIG_MEMORY_BUDGET].(int); ok && budgetMB > 0 {
+		return int64(budgetMB) * 1024 * 1024
+	}
+	return 0
+}
+
+// touch marks a batch as just used, size is left unchanged when negative
+func (cache *BatchCache) touch(collection *Collection, batchId string, size int64) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	var key = batchCacheKey{collection: collection, batchId: batchId}
+
+	entry, exists := cache.entries[key]
+	if !exists {
+		entry = &batchCacheEntry{}
+		cache.entries[key] = entry
+	}
+
+	cache.clock++
+	entry.lastUsed = cache.clock
+
+	if size >= 0 {
+		cache.residentBytes += size - entry.size
+		entry.size = size
+	}
+}
+
+func (cache *BatchCache) remove(collection *Collection, batchId string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	var key = batchCacheKey{collection: collection, batchId: batchId}
+
+	if entry, exists := cache.entries[key]; exists {
+		cache.residentBytes -= entry.size
+		delete(cache.entries, key)
+	}
+}
+
+func (cache *BatchCache) removeCollection(collection *Collection) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, entry := range cache.entries {
+		if key.collection == collection {
+			cache.residentBytes -= entry.size
+			delete(cache.entries, key)
+		}
+	}
+}
+
+// victims returns the resident batches from least to most recently used, nil while within budget
+func (cache *BatchCache) victims() []batchCacheKey {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.budget == 0 || cache.residentBytes <= cache.budget {
+		return nil
+	}
+
+	var keys = make([]batchCacheKey, 0, len(cache.entries))
+	for key := range cache.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return cache.entries[keys[i]].lastUsed < cache.entries[keys[j]].lastUsed })
+
+	return keys
+}
+
+func (cache *BatchCache) isOverBudget() bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return cache.budget > 0 && cache.residentBytes > cache.budget
+}
+
+// evict drops least recently used batches until the database is within budget. The caller holds collection.mu and
+// collection.cacheMu of current, if any, and keepBatchId of it stays resident. Other collections are skipped while busy
+// instead of waited for, so two collections evicting from each other never deadlock.
+func (cache *BatchCache) evict(current *Collection, keepBatchId string) {
+	if cache == nil {
+		return
+	}
+
+	for _, key := range cache.victims() {
+		if !cache.isOverBudget() {
+			return
+		}
+
+		if key.collection == current {
+			if key.batchId != keepBatchId {
+				current.evictBatch(key.batchId)
+			}
+			continue
+		}
+
+		if !key.collection.mu.TryRLock() {
+			continue
+		}
+		if key.collection.cacheMu.TryLock() {
+			key.collection.evictBatch(key.batchId)
+			key.collection.cacheMu.Unlock()
+		}
+		key.collection.mu.RUnlock()
+	}
+}
+
+// evictBatch drops a batch from memory if its batch file holds the same documents,
+// the current batch and batches changed since the last checkpoint stay. Caller must hold collection.mu and collection.cacheMu
+func (collection *Collection) evictBatch(batchId string) bool {
+	if _, isSaved := collection.BatchChecksums[batchId]; !isSaved || batchId == collection.CurrentBatchId || collection.BatchUpdateStatus[batchId] {
+		return false
+	}
+	if _, isResident := collection.DocumentsMap[batchId]; !isResident {
+		return false
+	}
+
+	delete(collection.DocumentsMap, batchId)
+	collection.evictedBatchIds[batchId] = true
+	collection.cache.remove(collection, batchId)
+
+	return true
+}
+
+// residentBatch returns the documents of a batch, reading it back from its batch file if it was evicted.
+// Caller must hold collection.mu, the read side is enough
+func (collection *Collection) residentBatch(batchId string) BatchDocuments {
+	collection.cacheMu.Lock()
+	defer collection.cacheMu.Unlock()
+
+	if documents, exists := collection.DocumentsMap[batchId]; exists {
+		collection.cacheHits.Add(1)
+		collection.cache.touch(collection, batchId, -1)
+		return documents
+	}
+
+	if !collection.evictedBatchIds[batchId] {
+		return nil
+	}
+
+	collection.cacheMisses.Add(1)
+
+	batchGobData, err := collection.loadBatchGobData(batchId)
+	if err != nil {
+		storageLogger.Error("batch read error", "database", collection.DatabaseName, "collection", collection.CollectionName, "batch", batchId, "error", err)
+		return nil
+	}
+
+	var documents BatchDocuments
+	if err := common.DecodeGob(batchGobData, &documents); err != nil {
+		storageLogger.Error("batch decoding error", "database", collection.DatabaseName, "collection", collection.CollectionName, "batch", batchId, "error", err)
+		return nil
+	}
+
+	collection.DocumentsMap[batchId] = documents
+	delete(collection.evictedBatchIds, batchId)
+
+	collection.cache.touch(collection, batchId, int64(len(batchGobData)))
+	collection.cache.evict(collection, batchId)
+
+	return documents
+}
+
+// loadBatchGobData reads an evicted batch and checks it is the one the collection saved
+func (collection *Collection) loadBatchGobData(batchId string) ([]byte, error) {
+	batchGobData, err := collection.storage.LoadBatch(collection.DatabaseName, collection.CollectionName, batchId)
+	if err != nil {
+		return nil, err
+	}
+
+	if common.Checksum(batchGobData) != collection.BatchChecksums[batchId] {
+		return nil, errors.New(global_constants.FILE_CHECKSUM_MISMATCH_MSG)
+	}
+
+	return batchGobData, nil
+}
+
+// batchIds returns the resident and evicted batches, caller must hold collection.mu
+func (collection *Collection) batchIds() []string {
+	collection.cacheMu.Lock()
+	defer collection.cacheMu.Unlock()
+
+	var batchIds = make([]string, 0, len(collection.DocumentsMap)+len(collection.evictedBatchIds))
+
+	for batchId := range collection.DocumentsMap {
+		batchIds = append(batchIds, batchId)
+	}
+	for batchId := range collection.evictedBatchIds {
+		batchIds = append(batchIds, batchId)
+	}
+
+	return batchIds
+}
@@ -1,10 +1,11 @@
 package in_memory_database
 
 import (
-	"fmt"
+	"errors"
 	"gnosql/src/common"
 	"gnosql/src/global_constants"
 	"sync"
+	"sync/atomic"
 )
 
 type Document map[string]interface{}
@@ -23,16 +24,39 @@ type Event struct {
 	Type      string
 	Id        string
 	EventData Document
+	flushed   chan struct{} // EVENT_FLUSH, closed once the collection is saved
+	requestId string        // request the event came from, for the logs of the mutation worker
 }
 
 type CollectionStats struct {
-	CollectionName string   `json:"collectionName"`
-	IndexKeys      []string `json:"IndexKeys"`
-	Documents      int      `json:"Documents"`
+	CollectionName  string   `json:"collectionName"`
+	IndexKeys       []string `json:"IndexKeys"`
+	Documents       int      `json:"Documents"`
+	ResidentBatches int      `json:"ResidentBatches"`
+	EvictedBatches  int      `json:"EvictedBatches"` // only in their batch files, read back on access
+	CacheHits       uint64   `json:"CacheHits"`
+	CacheMisses     uint64   `json:"CacheMisses"`
+	CacheHitRate    float64  `json:"CacheHitRate"`
+	Ready           bool     `json:"Ready"` // false until the files of a lazily loaded collection are read
+
+	// batch and collection files as stored and before compression, the ratio is 1 for uncompressed files
+	StoredBytes       int64   `json:"StoredBytes"`
+	UncompressedBytes int64   `json:"UncompressedBytes"`
+	CompressionRatio  float64 `json:"CompressionRatio"`
+
+	// filters served from the query cache, all zero when the collection has none
+	QueryCacheHits          uint64  `json:"QueryCacheHits"`
+	QueryCacheMisses        uint64  `json:"QueryCacheMisses"`
+	QueryCacheHitRate       float64 `json:"QueryCacheHitRate"`
+	QueryCacheInvalidations uint64  `json:"QueryCacheInvalidations"` // entries dropped by a change to a document they matched
+	QueryCacheEntries       int     `json:"QueryCacheEntries"`
+	QueryCacheBytes         int64   `json:"QueryCacheBytes"`
 }
 
 type BatchUpdateStatus map[string]bool
 
+type BatchChecksums map[string]uint32 // Ex: { batchId: crc32 of the batch gob data }
+
 type Collection struct {
 	CollectionName    string            `json:"CollectionName"`
 	DatabaseName      string            `json:"DatabaseName"`
@@ -43,8 +67,31 @@ type Collection struct {
 	CurrentBatchId    string            `json:"CurrentBatchId"`
 	CurrentBatchCount int               `json:"CurrentBatchCount"`
 	BatchUpdateStatus BatchUpdateStatus `json:"BatchUpdateStatus"`
+	BatchChecksums    BatchChecksums    `json:"BatchChecksums"`
 	IsChanged         bool
 	mu                sync.RWMutex
+	saveMu            sync.Mutex // keeps segment writes in Seq order
+	compactMu         sync.Mutex // one checkpoint at a time
+	storage           StorageEngine
+	pendingChanges    map[string]string // docId: batchId of the documents changed since the last save
+	documentBatchIds  map[string]string // docId: batchId holding the document
+	obsoleteBatchIds  []string          // batch files left on disk, deleted by the next checkpoint
+	cache             *BatchCache       // memory budget shared with the other collections of the database
+	cacheMu           sync.Mutex        // guards DocumentsMap and evictedBatchIds for holders of the read side of mu
+	evictedBatchIds   map[string]bool   // batches dropped from DocumentsMap, read back from their batch files on access
+	cacheHits         atomic.Uint64
+	cacheMisses       atomic.Uint64
+	queryCache        *QueryCache // results of recent filters, nil unless Config of the database caps it
+	segmentSeq        uint64      // Seq of the last segment written
+	segmentIds        []string    // segments on disk not yet folded into a checkpoint
+	segmentBytes      int64
+	channel           chan Event
+	workerDone        chan struct{}
+	barrier           sync.RWMutex // writers hold the read side, a backup holds the write side while capturing
+	loadMu            sync.Mutex   // one load at a time, held by DeleteCollection so a deleted collection isn't loaded
+	isLoaded          atomic.Bool  // false while registered without its files
+	isDeleted         bool
+	gnoSQL            *GnoSQL
 }
 
 type CollectionFileStruct struct {
@@ -57,6 +104,16 @@ type CollectionFileStruct struct {
 	CurrentBatchId    string            `json:"CurrentBatchId"`
 	CurrentBatchCount int               `json:"CurrentBatchCount"`
 	BatchUpdateStatus BatchUpdateStatus `json:"BatchUpdateStatus"`
+	BatchChecksums    BatchChecksums    `json:"BatchChecksums"`
+
+	CompactedSegmentSeq uint64 `json:"CompactedSegmentSeq"` // segments up to this Seq are already in the batch files
+
+	loadedBatchChecksums BatchChecksums // checksums of the batch files read from disk on load
+	loadedSegments       []Segment      // segments newer than the checkpoint, in Seq order
+	loadedSegmentIds     []string
+	obsoleteBatchIds     []string // batch files on disk the collection file doesn't list
+	loadedBatchSizes     map[string]int64
+	loadedBytes          int64 // size of every file read on load
 }
 
 type CollectionInput struct {
@@ -65,6 +122,12 @@ type CollectionInput struct {
 
 	// Example: indexKeys
 	IndexKeys []string
+
+	// Example: shardKey, used by a router node to place documents, docId when empty
+	ShardKey string
+
+	// Example: 16, memory for cached filter results in MB. Filters aren't cached when 0
+	QueryCacheMB int
 }
 
 func CreateCollection(collectionInput CollectionInput, db *Database) *Collection {
@@ -77,59 +140,231 @@ func CreateCollection(collectionInput CollectionInput, db *Database) *Collection
 			IndexKeys:         collectionInput.IndexKeys,
 			DocumentsMap:      make(DocumentsMap),
 			IndexMap:          make(IndexMap),
-			IsChanged:         true,
+			IsChanged:         false,
 			LastIndex:         0,
 			CurrentBatchId:    currentBatchId,
 			BatchUpdateStatus: BatchUpdateStatus{currentBatchId: true},
+			BatchChecksums:    make(BatchChecksums),
 			CurrentBatchCount: 0,
 			mu:                sync.RWMutex{},
+			workerDone:        make(chan struct{}),
+			storage:           db.storage,
+			pendingChanges:    make(map[string]string),
+			documentBatchIds:  make(map[string]string),
+			segmentIds:        make([]string, 0),
+			cache:             db.cache,
+			evictedBatchIds:   make(map[string]bool),
+			gnoSQL:            db.gnoSQL,
 		}
 
-	collection.SaveCollectionToFile()
+	if budget := db.queryCacheBudget(collectionInput.CollectionName); budget > 0 {
+		collection.queryCache = NewQueryCache(budget)
+	}
+
+	collection.isLoaded.Store(true)
+	collection.CompactSegments()
 	collection.StartInternalFunctions()
 
 	return collection
 }
 
-func LoadCollections(collectionsGob []CollectionFileStruct) []*Collection {
-	var collections = make([]*Collection, 0)
-
-	for _, collectionGob := range collectionsGob {
-		collection := &Collection{
-			CollectionName:    collectionGob.CollectionName,
-			DatabaseName:      collectionGob.DatabaseName,
-			IndexKeys:         collectionGob.IndexKeys,
-			DocumentsMap:      collectionGob.DocumentsMap,
-			IndexMap:          collectionGob.IndexMap,
-			LastIndex:         collectionGob.LastIndex,
-			CurrentBatchId:    collectionGob.CurrentBatchId,
-			CurrentBatchCount: collectionGob.CurrentBatchCount,
-			BatchUpdateStatus: collectionGob.BatchUpdateStatus,
-			IsChanged:         false,
-			mu:                sync.RWMutex{},
-		}
+// RegisterCollection returns a collection known only by its name, its files are read by EnsureLoaded on first access
+func RegisterCollection(collectionName string, db *Database) *Collection {
+	collection := &Collection{
+		CollectionName:    collectionName,
+		DatabaseName:      db.DatabaseName,
+		DocumentsMap:      make(DocumentsMap),
+		IndexMap:          make(IndexMap),
+		BatchUpdateStatus: make(BatchUpdateStatus),
+		BatchChecksums:    make(BatchChecksums),
+		IsChanged:         false,
+		mu:                sync.RWMutex{},
+		workerDone:        make(chan struct{}),
+		storage:           db.storage,
+		pendingChanges:    make(map[string]string),
+		documentBatchIds:  make(map[string]string),
+		segmentIds:        make([]string, 0),
+		cache:             db.cache,
+		evictedBatchIds:   make(map[string]bool),
+		gnoSQL:            db.gnoSQL,
+	}
+
+	if budget := db.queryCacheBudget(collectionName); budget > 0 {
+		collection.queryCache = NewQueryCache(budget)
+	}
+
+	return collection
+}
+
+// EnsureLoaded reads the files of a collection registered without them, concurrent callers wait for the first one
+func (collection *Collection) EnsureLoaded() error {
+	if collection.isLoaded.Load() {
+		return nil
+	}
+
+	collection.loadMu.Lock()
+	defer collection.loadMu.Unlock()
+
+	if collection.isLoaded.Load() {
+		return nil
+	}
+	if collection.isDeleted {
+		return errors.New(global_constants.COLLECTION_NOT_FOUND_MSG)
+	}
 
-		go collection.StartInternalFunctions()
-		collections = append(collections, collection)
+	collectionGob, err := collection.gnoSQL.LoadCollectionFile(collection.storage, collection.DatabaseName, collection.CollectionName)
+	if err != nil {
+		logger.Error("collection load error", "database", collection.DatabaseName, "collection", collection.CollectionName, "error", err)
+		return err
 	}
-	return collections
+
+	collection.load(collectionGob)
+	collection.cache.evict(nil, "")
+
+	logger.Info("collection loaded on access", "database", collection.DatabaseName, "collection", collection.CollectionName)
+
+	return nil
+}
+
+// IsLoaded reports if the documents of the collection are in memory, or evicted after being loaded
+func (collection *Collection) IsLoaded() bool {
+	return collection.isLoaded.Load()
+}
+
+// load fills a registered collection from its files and starts its worker
+func (collection *Collection) load(collectionGob CollectionFileStruct) {
+	collection.mu.Lock()
+
+	collection.IndexKeys = collectionGob.IndexKeys
+	collection.DocumentsMap = collectionGob.DocumentsMap
+	collection.IndexMap = collectionGob.IndexMap
+	collection.LastIndex = collectionGob.LastIndex
+	collection.CurrentBatchId = collectionGob.CurrentBatchId
+	collection.CurrentBatchCount = collectionGob.CurrentBatchCount
+	collection.BatchUpdateStatus = collectionGob.BatchUpdateStatus
+	collection.BatchChecksums = collectionGob.BatchChecksums
+	collection.segmentSeq = collectionGob.CompactedSegmentSeq
+	collection.segmentIds = collectionGob.loadedSegmentIds
+	collection.obsoleteBatchIds = collectionGob.obsoleteBatchIds
+
+	var isCheckpointNeeded = false
+
+	if len(collection.BatchChecksums) == 0 {
+		// collection file written before checksums were added
+		collection.BatchChecksums = collectionGob.loadedBatchChecksums
+	} else if !isBatchChecksumsMatching(collection.BatchChecksums, collectionGob.loadedBatchChecksums) {
+		// crash between the batch writes and the collection file write, the documents are newer than the index
+		logger.Warn("batch files don't match the collection file, rebuilding index", "database", collection.DatabaseName, "collection", collection.CollectionName)
+		collection.BatchChecksums = collectionGob.loadedBatchChecksums
+		collection.rebuildIndex()
+		isCheckpointNeeded = true
+	}
+
+	if collection.BatchChecksums == nil {
+		collection.BatchChecksums = make(BatchChecksums)
+	}
+	if collection.BatchUpdateStatus == nil {
+		collection.BatchUpdateStatus = make(BatchUpdateStatus)
+	}
+
+	collection.rebuildPlacement()
+
+	// changes saved after the checkpoint, the batches they touch are rewritten by the next checkpoint
+	for _, segment := range collectionGob.loadedSegments {
+		collection.applySegment(segment)
+		collection.segmentSeq = segment.Seq
+	}
+
+	for batchId, size := range collectionGob.loadedBatchSizes {
+		collection.cache.touch(collection, batchId, size)
+	}
+
+	collection.mu.Unlock()
+
+	collection.StartInternalFunctions()
+	collection.isLoaded.Store(true)
+
+	if isCheckpointNeeded {
+		collection.compactInBackground()
+	}
+}
+
+// isBatchChecksumsMatching compares the batch checksums saved in a collection file with the batch files read from disk
+func isBatchChecksumsMatching(savedChecksums BatchChecksums, loadedChecksums BatchChecksums) bool {
+	for batchId, checksum := range savedChecksums {
+		if loadedChecksums[batchId] != checksum {
+			return false
+		}
+	}
+
+	return len(savedChecksums) == len(loadedChecksums)
 }
 
 func (collection *Collection) DeleteCollection(ToBeDeleted bool) {
+	// a collection still being loaded is deleted once its worker runs
+	collection.loadMu.Lock()
+	defer collection.loadMu.Unlock()
+
+	collection.isDeleted = true
+
 	if ToBeDeleted {
-		common.DeleteFolder(common.GetCollectionFolderPath(collection.DatabaseName, collection.CollectionName))
+		// a running checkpoint would write the files again after they are deleted
+		collection.compactMu.Lock()
+		defer collection.compactMu.Unlock()
+
+		if err := collection.storage.DeleteCollection(collection.DatabaseName, collection.CollectionName); err != nil {
+			storageLogger.Error("collection delete error", "database", collection.DatabaseName, "collection", collection.CollectionName, "error", err)
+		}
 	}
-	AddIncomingRequest(collection.DatabaseName, collection.CollectionName, Event{Type: global_constants.EVENT_STOP_GO_ROUTINE})
+	collection.cache.removeCollection(collection)
+
+	if !collection.isLoaded.Load() {
+		// no worker was started, nothing is left to stop
+		close(collection.workerDone)
+		return
+	}
+	collection.channel <- Event{Type: global_constants.EVENT_STOP_GO_ROUTINE}
+}
+
+// Flush saves every change sent to the worker before the call and waits for the file writes, including a
+// checkpoint the save starts in the background
+func (collection *Collection) Flush() {
+	if !collection.isLoaded.Load() {
+		return
+	}
+
+	var flushed = make(chan struct{})
+
+	// a collection deleted meanwhile has no worker left to answer
+	select {
+	case collection.channel <- Event{Type: global_constants.EVENT_FLUSH, flushed: flushed}:
+	case <-collection.workerDone:
+		return
+	}
+
+	select {
+	case <-flushed:
+	case <-collection.workerDone:
+		return
+	}
+
+	collection.compactMu.Lock()
+	collection.compactMu.Unlock()
+}
+
+// WaitForWorkerStop blocks until the mutation worker has processed EVENT_STOP_GO_ROUTINE
+func (collection *Collection) WaitForWorkerStop() {
+	<-collection.workerDone
 }
 
 func (collection *Collection) GetAllData() []Document {
 	collection.mu.RLock()
 	defer collection.mu.RUnlock()
 
-	resultDocuments := make([]Document, 0, len(collection.DocumentsMap))
+	resultDocuments := make([]Document, 0, len(collection.documentBatchIds))
 
-	for _, documents := range collection.DocumentsMap {
-		for _, document := range documents {
+	for _, batchId := range collection.batchIds() {
+		for _, document := range collection.residentBatch(batchId) {
 			resultDocuments = append(resultDocuments, document)
 		}
 	}
@@ -150,17 +385,57 @@ func (collection *Collection) Clear() {
 	collection.CurrentBatchId = ""
 	collection.CurrentBatchCount = 0
 	collection.BatchUpdateStatus = make(BatchUpdateStatus) // Reset to an empty map
+	collection.BatchChecksums = make(BatchChecksums)
+	collection.pendingChanges = make(map[string]string)
+	collection.documentBatchIds = make(map[string]string)
+	collection.evictedBatchIds = make(map[string]bool)
+	collection.queryCache.clear()
 	collection.IsChanged = false
 }
 
 func (collection *Collection) Stats() CollectionStats {
+	// fields of a collection being loaded are written without the lock held by readers
+	if !collection.isLoaded.Load() {
+		return CollectionStats{CollectionName: collection.CollectionName, Ready: false}
+	}
+
 	collection.mu.RLock()
 	defer collection.mu.RUnlock()
 
+	collection.cacheMu.Lock()
+	defer collection.cacheMu.Unlock()
+
 	var statsMap = CollectionStats{
-		CollectionName: collection.CollectionName,
-		IndexKeys:      collection.IndexKeys,
-		Documents:      len(collection.DocumentsMap),
+		CollectionName:  collection.CollectionName,
+		IndexKeys:       collection.IndexKeys,
+		Documents:       len(collection.documentBatchIds),
+		ResidentBatches: len(collection.DocumentsMap),
+		EvictedBatches:  len(collection.evictedBatchIds),
+		CacheHits:       collection.cacheHits.Load(),
+		CacheMisses:     collection.cacheMisses.Load(),
+		Ready:           true,
+	}
+
+	if accesses := statsMap.CacheHits + statsMap.CacheMisses; accesses > 0 {
+		statsMap.CacheHitRate = float64(statsMap.CacheHits) / float64(accesses)
+	}
+
+	if collection.queryCache != nil {
+		statsMap.QueryCacheEntries, statsMap.QueryCacheBytes = collection.queryCache.stats()
+		statsMap.QueryCacheHits = collection.queryCache.hits.Load()
+		statsMap.QueryCacheMisses = collection.queryCache.misses.Load()
+		statsMap.QueryCacheInvalidations = collection.queryCache.invalidations.Load()
+
+		if filters := statsMap.QueryCacheHits + statsMap.QueryCacheMisses; filters > 0 {
+			statsMap.QueryCacheHitRate = float64(statsMap.QueryCacheHits) / float64(filters)
+		}
+	}
+
+	fileSize := compressedSizeOf(collection.storage, collection.CollectionName)
+	statsMap.StoredBytes = fileSize.stored
+	statsMap.UncompressedBytes = fileSize.uncompressed
+	if fileSize.stored > 0 {
+		statsMap.CompressionRatio = float64(fileSize.uncompressed) / float64(fileSize.stored)
 	}
 	return statsMap
 }
@@ -247,15 +522,9 @@ func ConvertToCollectionInputs(collectionsInterface []interface{}) []CollectionI
 	return collectionsInput
 }
 
-func (collection *Collection) SaveCollectionToFile() {
-	collection.mu.Lock()
-	defer collection.mu.Unlock()
-
-	if !collection.IsChanged {
-		return
-	}
-
-	temp := CollectionFileStruct{
+// toCollectionFileStruct returns metadata written to -collection.gob, caller must hold collection.mu
+func (collection *Collection) toCollectionFileStruct() CollectionFileStruct {
+	return CollectionFileStruct{
 		CollectionName:    collection.CollectionName,
 		DatabaseName:      collection.DatabaseName,
 		IndexKeys:         collection.IndexKeys,
@@ -264,37 +533,75 @@ func (collection *Collection) SaveCollectionToFile() {
 		CurrentBatchId:    collection.CurrentBatchId,
 		CurrentBatchCount: collection.CurrentBatchCount,
 		BatchUpdateStatus: collection.BatchUpdateStatus,
+		BatchChecksums:    collection.BatchChecksums,
 	}
+}
 
-	collection.IsChanged = false
+// SaveCollectionToFile appends the documents changed since the last save as a segment,
+// batch files and the collection file are only rewritten by a checkpoint once enough segments piled up
+func (collection *Collection) SaveCollectionToFile() {
+	// a collection not loaded yet has nothing to save
+	if !collection.isLoaded.Load() {
+		return
+	}
+
+	collection.saveMu.Lock()
+	err := collection.appendSegment()
+	collection.saveMu.Unlock()
 
-	// Write collection file to disk
-	collectionGobData, err := common.EncodeGob(temp)
-	if err == nil {
-		var collectionFileName = common.GetCollectionFileName(collection.CollectionName)
-		go common.WriteGobDataToDisk(common.GetCollectionFilePath(collection.DatabaseName, collection.CollectionName, collectionFileName), collectionGobData)
-	} else {
-		fmt.Printf("\n collection: %v \t GOB encoding error: %v ", collection.CollectionName, err)
-	}
-
-	// Write Batch file to disk
-	for fileName, isUpdated := range collection.BatchUpdateStatus {
-		if documents, exists := collection.DocumentsMap[fileName]; exists && isUpdated {
-			gobData, err := common.EncodeGob(documents)
-			if err == nil {
-				go common.WriteGobDataToDisk(common.GetCollectionFilePath(collection.DatabaseName, collection.CollectionName, fileName), gobData)
-			} else {
-				fmt.Printf("\n collection: %v \t batch filename: %v \t GOB encoding error: %v ", collection.CollectionName, fileName, err)
+	if err == nil && collection.isCompactionDue() {
+		collection.compactInBackground()
+	}
+}
+
+// RebuildIndex rebuilds IndexMap and the current batch count from the documents,
+// LastIndex is raised to the highest docIndex but never lowered so indexes of deleted documents aren't reused
+func (collection *Collection) RebuildIndex() {
+	collection.mu.Lock()
+	defer collection.mu.Unlock()
+
+	collection.rebuildIndex()
+}
+
+// rebuildIndex rebuilds IndexMap from the documents, caller must hold collection.mu
+func (collection *Collection) rebuildIndex() {
+	collection.IndexMap = make(IndexMap)
+
+	for _, documents := range collection.DocumentsMap {
+		for _, document := range documents {
+			collection.createIndex(document)
+
+			if documentIndex := documentIndexOf(document); documentIndex > collection.LastIndex {
+				collection.LastIndex = documentIndex
 			}
+		}
+	}
+
+	collection.CurrentBatchCount = len(collection.DocumentsMap[collection.CurrentBatchId])
+}
 
-		} else {
-			fmt.Printf("\n batchid: %v does not exists in DocumentsMap ", fileName)
+// rebuildPlacement maps every document to the batch holding it, caller must hold collection.mu or own the collection
+func (collection *Collection) rebuildPlacement() {
+	collection.documentBatchIds = make(map[string]string)
+
+	for batchId, documents := range collection.DocumentsMap {
+		for docId := range documents {
+			collection.documentBatchIds[docId] = batchId
 		}
-		collection.BatchUpdateStatus[fileName] = false
 	}
+}
 
+func documentIndexOf(document Document) int {
+	switch documentIndex := document[global_constants.DOC_INDEX].(type) {
+	case int:
+		return documentIndex
+	case float64:
+		return int(documentIndex)
+	}
+	return 0
 }
 
 func (collection *Collection) StartInternalFunctions() {
+	collection.channel = collection.gnoSQL.collectionChannels.CreateCollectionChannel(collection.DatabaseName, collection.CollectionName, collection.gnoSQL.Settings.CollectionChannelSize)
 	go collection.StartMutationWorker()
 }
@@ -0,0 +1,132 @@
+package in_memory_database
+
+import (
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"slices"
+	"time"
+)
+
+type BatchCompactionStats struct {
+	CollectionName string `json:"collectionName"`
+	BatchesBefore  int    `json:"batchesBefore"`
+	BatchesAfter   int    `json:"batchesAfter"`
+	MovedDocuments int    `json:"movedDocuments"`
+}
+
+// CompactBatches merges batches filled below BATCH_COMPACTION_FILL_RATIO into full ones, then writes a checkpoint.
+// The merged batches and the collection file are on disk before the old batch files are deleted,
+// a crash in between loads the old batches listed in the old collection file and ignores the new ones.
+func (collection *Collection) CompactBatches() BatchCompactionStats {
+	collection.compactMu.Lock()
+	defer collection.compactMu.Unlock()
+
+	// no segment write may be in flight while documents change batches
+	collection.saveMu.Lock()
+	collection.mu.Lock()
+	stats := collection.mergeSparseBatches()
+	collection.mu.Unlock()
+	collection.saveMu.Unlock()
+
+	if stats.MovedDocuments > 0 || stats.BatchesAfter < stats.BatchesBefore {
+		collection.compactSegments()
+		logger.Info("batches compacted", "database", collection.DatabaseName, "collection", stats.CollectionName,
+			"batchesBefore", stats.BatchesBefore, "batchesAfter", stats.BatchesAfter, "movedDocuments", stats.MovedDocuments)
+	}
+
+	return stats
+}
+
+// mergeSparseBatches moves the documents of under-filled batches into new batches in docIndex order,
+// the current batch is left alone as new documents go there. Caller must hold collection.saveMu and collection.mu
+func (collection *Collection) mergeSparseBatches() BatchCompactionStats {
+	var batchIds = collection.batchIds()
+	var stats = BatchCompactionStats{
+		CollectionName: collection.CollectionName,
+		BatchesBefore:  len(batchIds),
+		BatchesAfter:   len(batchIds),
+	}
+
+	// counted from the placement so evicted batches aren't read unless they are merged
+	var batchCounts = make(map[string]int)
+	for _, batchId := range collection.documentBatchIds {
+		batchCounts[batchId]++
+	}
+
+	var fillLimit = int(float64(collection.gnoSQL.Settings.BatchSize) * global_constants.BATCH_COMPACTION_FILL_RATIO)
+	var sparseBatchIds = make([]string, 0)
+	var isEmptyBatchFound = false
+
+	for _, batchId := range batchIds {
+		if batchId != collection.CurrentBatchId && batchCounts[batchId] < fillLimit {
+			sparseBatchIds = append(sparseBatchIds, batchId)
+			isEmptyBatchFound = isEmptyBatchFound || batchCounts[batchId] == 0
+		}
+	}
+
+	// a single sparse batch has nothing to merge with
+	if len(sparseBatchIds) < 2 && !isEmptyBatchFound {
+		return stats
+	}
+
+	var documents = make([]Document, 0)
+	for _, batchId := range sparseBatchIds {
+		var batchDocuments = collection.residentBatch(batchId)
+
+		// an evicted batch that can't be read back keeps its documents where they are
+		if batchDocuments == nil && batchCounts[batchId] > 0 {
+			continue
+		}
+
+		for _, document := range batchDocuments {
+			documents = append(documents, document)
+		}
+
+		delete(collection.DocumentsMap, batchId)
+		delete(collection.evictedBatchIds, batchId)
+		delete(collection.BatchUpdateStatus, batchId)
+		delete(collection.BatchChecksums, batchId)
+		collection.cache.remove(collection, batchId)
+		collection.obsoleteBatchIds = append(collection.obsoleteBatchIds, batchId)
+	}
+
+	slices.SortFunc(documents, func(a, b Document) int { return documentIndexOf(a) - documentIndexOf(b) })
+
+	var batchId string
+	for i, document := range documents {
+		if i%collection.gnoSQL.Settings.BatchSize == 0 {
+			batchId = common.GetCollectionBatchIdFileName()
+			collection.DocumentsMap[batchId] = make(BatchDocuments)
+			collection.BatchUpdateStatus[batchId] = true
+		}
+
+		var docId = document[global_constants.DOC_ID].(string)
+
+		collection.DocumentsMap[batchId][docId] = document
+		collection.documentBatchIds[docId] = batchId
+
+		if _, exists := collection.pendingChanges[docId]; exists {
+			collection.pendingChanges[docId] = batchId
+		}
+	}
+
+	stats.BatchesAfter = len(collection.DocumentsMap) + len(collection.evictedBatchIds)
+	stats.MovedDocuments = len(documents)
+
+	return stats
+}
+
+// StartBatchCompaction compacts the batches of every collection once per interval
+func (gnoSQL *GnoSQL) StartBatchCompaction(interval time.Duration) {
+	for range time.Tick(interval) {
+		for _, database := range gnoSQL.GetAllDBs() {
+			for _, collection := range database.GetAllColls() {
+				// left to the warm-up or the first access
+				if !collection.IsLoaded() {
+					continue
+				}
+				collection.CompactBatches()
+			}
+		}
+	}
+}
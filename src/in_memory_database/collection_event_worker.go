@@ -1,7 +1,6 @@
 package in_memory_database
 
 import (
-	"fmt"
 	"gnosql/src/global_constants"
 	"strings"
 	"sync"
@@ -15,24 +14,15 @@ type CollectionChannel struct {
 	mu       sync.RWMutex
 }
 
-var (
-	CollectionChannelInstance *CollectionChannel
-)
-
 func NewCollectionChannel() *CollectionChannel {
 	return &CollectionChannel{channels: make(ChannelMap)}
 }
 
-func init() {
-	CollectionChannelInstance = NewCollectionChannel()
-	go CollectionChannelInstance.StartTimerToSaveFile()
-}
-
 func (cc *CollectionChannel) AddCollectionEvent(databaseName string, collectionName string, event Event) {
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 
-	var channel = GetCollectionChannel(databaseName, collectionName)
+	var channel = cc.getCollectionChannel(databaseName, collectionName)
 	channel <- event
 }
 
@@ -40,7 +30,31 @@ func (cc *CollectionChannel) GetCollectionChannelWithLock(databaseName string, c
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 
-	return GetCollectionChannel(databaseName, collectionName)
+	return cc.getCollectionChannel(databaseName, collectionName)
+}
+
+// CreateCollectionChannel always creates a fresh channel, so a re-created collection never reads events of the deleted one
+func (cc *CollectionChannel) CreateCollectionChannel(databaseName string, collectionName string, channelSize int) chan Event {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	var channelName = ToCollectionChannelName(databaseName, collectionName)
+	var channel = make(chan Event, channelSize)
+	cc.channels[channelName] = channel
+
+	return channel
+}
+
+// RemoveCollectionChannel removes the channel only if it still belongs to the stopped collection
+func (cc *CollectionChannel) RemoveCollectionChannel(databaseName string, collectionName string, channel chan Event) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	var channelName = ToCollectionChannelName(databaseName, collectionName)
+
+	if cc.channels[channelName] == channel {
+		delete(cc.channels, channelName)
+	}
 }
 
 func (cc *CollectionChannel) GetAllCollections() []string {
@@ -55,28 +69,26 @@ func (cc *CollectionChannel) GetAllCollections() []string {
 
 }
 
-func (cc *CollectionChannel) StartTimerToSaveFile() {
-	for range time.Tick(global_constants.TIME_INTERVAL_TO_SYNC_DISK) {
-		fmt.Printf("\n ---------------------------------------------------------------- \n")
-		fmt.Printf("\n Ticker started. getting all channels\n")
-		for _, channelName := range cc.GetAllCollections() {
+func (gnoSQL *GnoSQL) StartTimerToSaveFile(syncInterval time.Duration) {
+	for range time.Tick(syncInterval) {
+		for _, channelName := range gnoSQL.collectionChannels.GetAllCollections() {
 			var databaseName, CollectionName = ExtractDatabaseAndCollectionName(channelName)
-			AddIncomingRequest(databaseName, CollectionName, Event{Type: global_constants.EVENT_SAVE_TO_DISK})
+			gnoSQL.AddIncomingRequest(databaseName, CollectionName, Event{Type: global_constants.EVENT_SAVE_TO_DISK})
 		}
-		fmt.Printf("\n EVENT_SAVE_TO_DISK event sent to all colelction channels \n")
-		fmt.Printf("\n ---------------------------------------------------------------- \n")
+		storageLogger.Debug("save sent to every collection")
 
 	}
 }
 
-func GetCollectionChannel(databaseName string, collectionName string) chan Event {
+// getCollectionChannel expects cc.mu to be held
+func (cc *CollectionChannel) getCollectionChannel(databaseName string, collectionName string) chan Event {
 	var channelName = ToCollectionChannelName(databaseName, collectionName)
 
-	if _, isExists := CollectionChannelInstance.channels[channelName]; !isExists {
-		CollectionChannelInstance.channels[channelName] = make(chan Event, global_constants.COLLECTION_CHANNEL_SIZE)
+	if _, isExists := cc.channels[channelName]; !isExists {
+		cc.channels[channelName] = make(chan Event, global_constants.COLLECTION_CHANNEL_SIZE)
 	}
 
-	var channel = CollectionChannelInstance.channels[channelName]
+	var channel = cc.channels[channelName]
 
 	return channel
 }
@@ -90,8 +102,11 @@ func ExtractDatabaseAndCollectionName(channelName string) (string, string) {
 	return result[0], result[1]
 }
 
-func DeleteCollectionChannel(databaseName string, collectionName string) {
+func (cc *CollectionChannel) DeleteCollectionChannel(databaseName string, collectionName string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
 	var channelName = ToCollectionChannelName(databaseName, collectionName)
 
-	delete(CollectionChannelInstance.channels, channelName)
+	delete(cc.channels, channelName)
 }
@@ -1,42 +1,87 @@
 package in_memory_database
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"gnosql/src/common"
 	"gnosql/src/global_constants"
+	"gnosql/src/logging"
 )
 
 func (collection *Collection) StartMutationWorker() {
-	var collectionChannelName = collection.DatabaseName + collection.CollectionName
-	var collectionChannel = CollectionChannelInstance.GetCollectionChannelWithLock(collection.DatabaseName, collection.CollectionName)
+	var databaseName, collectionName = collection.DatabaseName, collection.CollectionName
+	var collectionChannel = collection.channel
 
 	for {
 		event := <-collectionChannel
 
-		if event.Type == global_constants.EVENT_CREATE {
-			collection.Create(event.EventData)
-		}
-		if event.Type == global_constants.EVENT_UPDATE {
-			collection.Update(event.Id, event.EventData)
-		}
-		if event.Type == global_constants.EVENT_DELETE {
-			collection.Delete(event.Id)
+		if event.Type == global_constants.EVENT_CREATE || event.Type == global_constants.EVENT_UPDATE || event.Type == global_constants.EVENT_DELETE {
+			collection.applyEvent(event)
 		}
 		if event.Type == global_constants.EVENT_SAVE_TO_DISK {
 			collection.SaveCollectionToFile()
-			fmt.Printf("\n EVENT_SAVE_TO_DISK : %v done\n", collectionChannelName)
+			storageLogger.Debug("collection saved", "database", databaseName, "collection", collectionName)
+		}
+		if event.Type == global_constants.EVENT_FLUSH {
+			collection.SaveCollectionToFile()
+			close(event.flushed)
 		}
 		if event.Type == global_constants.EVENT_STOP_GO_ROUTINE {
 			collection.Clear()
-			fmt.Printf("\n %v Event channel closed. Exiting the goroutine. ", collection.CollectionName)
+			collection.gnoSQL.collectionChannels.RemoveCollectionChannel(databaseName, collectionName, collectionChannel)
+			close(collection.workerDone)
+			logger.Debug("mutation worker stopped", "database", databaseName, "collection", collectionName)
 			return
 		}
 
 	}
 }
 
+// applyEvent applies a document event and notifies webhook subscribers of the change
+func (collection *Collection) applyEvent(event Event) {
+	collection.barrier.RLock()
+	defer collection.barrier.RUnlock()
+
+	// logged with the id of the request the event came from
+	ctx := logging.WithRequestId(context.Background(), event.requestId)
+
+	switch event.Type {
+	case global_constants.EVENT_CREATE:
+		document := collection.Create(event.EventData)
+		collection.publishChange(event.Type, document[global_constants.DOC_ID].(string), document)
+		logger.DebugContext(ctx, "document created", "database", collection.DatabaseName, "collection", collection.CollectionName, "docId", document[global_constants.DOC_ID])
+	case global_constants.EVENT_UPDATE:
+		if err := collection.Update(event.Id, event.EventData); err == nil {
+			collection.publishChange(event.Type, event.Id, event.EventData)
+			logger.DebugContext(ctx, "document updated", "database", collection.DatabaseName, "collection", collection.CollectionName, "docId", event.Id)
+		} else {
+			logger.WarnContext(ctx, "update not applied", "database", collection.DatabaseName, "collection", collection.CollectionName, "docId", event.Id, "error", err)
+		}
+	case global_constants.EVENT_DELETE:
+		document := collection.Read(event.Id)
+		if err := collection.Delete(event.Id); err == nil {
+			collection.publishChange(event.Type, event.Id, document)
+			logger.DebugContext(ctx, "document deleted", "database", collection.DatabaseName, "collection", collection.CollectionName, "docId", event.Id)
+		} else {
+			logger.WarnContext(ctx, "delete not applied", "database", collection.DatabaseName, "collection", collection.CollectionName, "docId", event.Id, "error", err)
+		}
+	}
+}
+
+func (collection *Collection) publishChange(eventType string, id string, document Document) {
+	collection.gnoSQL.WebhookDispatcher.Publish(ChangeEvent{
+		DatabaseName:   collection.DatabaseName,
+		CollectionName: collection.CollectionName,
+		Type:           eventType,
+		DocId:          id,
+		Document:       document,
+	})
+}
+
 func (collection *Collection) Create(document Document) Document {
+	collection.gnoSQL.replicationGate.RLock()
+	defer collection.gnoSQL.replicationGate.RUnlock()
+
 	collection.mu.Lock()
 	defer collection.mu.Unlock()
 
@@ -52,7 +97,7 @@ func (collection *Collection) Create(document Document) Document {
 	var batchId = collection.CurrentBatchId
 	var batchCount = collection.CurrentBatchCount + 1
 
-	if batchCount > global_constants.BATCH_SIZE {
+	if batchCount > collection.gnoSQL.Settings.BatchSize {
 		batchId = common.GetCollectionBatchIdFileName()
 		collection.CurrentBatchId = batchId
 		batchCount = 0
@@ -65,15 +110,24 @@ func (collection *Collection) Create(document Document) Document {
 	collection.DocumentsMap[batchId][uniqueUuid] = document
 
 	collection.createIndex(document)
+	collection.queryCache.invalidate(nil, document)
 
 	collection.IsChanged = true
 	collection.BatchUpdateStatus[batchId] = true
+	collection.pendingChanges[uniqueUuid] = batchId
+	collection.documentBatchIds[uniqueUuid] = batchId
 	collection.LastIndex = documentIndex
 	collection.CurrentBatchCount = batchCount
+
+	collection.logMutation(Event{Type: global_constants.EVENT_CREATE, Id: uniqueUuid, EventData: copyDocument(document)})
+
 	return document
 }
 
 func (collection *Collection) Update(id string, updatedDocument Document) error {
+	collection.gnoSQL.replicationGate.RLock()
+	defer collection.gnoSQL.replicationGate.RUnlock()
+
 	collection.mu.Lock()
 	defer collection.mu.Unlock()
 
@@ -86,14 +140,21 @@ func (collection *Collection) Update(id string, updatedDocument Document) error
 	collection.updateIndex(document, updatedDocument)
 
 	collection.DocumentsMap[batchId][id] = updatedDocument
+	collection.queryCache.invalidate(document, updatedDocument)
 
 	collection.IsChanged = true
 	collection.BatchUpdateStatus[batchId] = true
+	collection.pendingChanges[id] = batchId
+
+	collection.logMutation(Event{Type: global_constants.EVENT_UPDATE, Id: id, EventData: copyDocument(updatedDocument)})
 
 	return nil
 }
 
 func (collection *Collection) Delete(id string) error {
+	collection.gnoSQL.replicationGate.RLock()
+	defer collection.gnoSQL.replicationGate.RUnlock()
+
 	collection.mu.Lock()
 	defer collection.mu.Unlock()
 
@@ -104,10 +165,15 @@ func (collection *Collection) Delete(id string) error {
 	}
 
 	delete(collection.DocumentsMap[batchId], id)
+	delete(collection.documentBatchIds, id)
 	collection.deleteIndex(document)
+	collection.queryCache.invalidate(document, nil)
 
 	collection.IsChanged = true
 	collection.BatchUpdateStatus[batchId] = true
+	collection.pendingChanges[id] = batchId
+
+	collection.logMutation(Event{Type: global_constants.EVENT_DELETE, Id: id})
 
 	return nil
 }
@@ -0,0 +1,294 @@
+package in_memory_database
+
+import (
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/metrics"
+	"sort"
+	"time"
+)
+
+// Segment holds the documents changed between two saves, segments are replayed in Seq order on top of the batch files
+type Segment struct {
+	Seq               uint64
+	LastIndex         int
+	CurrentBatchId    string
+	CurrentBatchCount int
+	Entries           []SegmentEntry
+}
+
+type SegmentEntry struct {
+	BatchId  string
+	DocId    string
+	Document Document // nil when the document was deleted
+}
+
+// appendSegment writes the documents changed since the last save as a new segment, caller must hold collection.saveMu
+func (collection *Collection) appendSegment() error {
+	collection.mu.Lock()
+
+	if !collection.IsChanged || collection.CollectionName == "" {
+		collection.mu.Unlock()
+		return nil
+	}
+
+	var segment = Segment{
+		Seq:               collection.segmentSeq + 1,
+		LastIndex:         collection.LastIndex,
+		CurrentBatchId:    collection.CurrentBatchId,
+		CurrentBatchCount: collection.CurrentBatchCount,
+		Entries:           make([]SegmentEntry, 0, len(collection.pendingChanges)),
+	}
+
+	for docId, batchId := range collection.pendingChanges {
+		segment.Entries = append(segment.Entries, SegmentEntry{BatchId: batchId, DocId: docId, Document: collection.DocumentsMap[batchId][docId]})
+	}
+
+	segmentGobData, err := common.EncodeGob(segment)
+	if err != nil {
+		storageLogger.Error("segment GOB encoding error", "database", collection.DatabaseName, "collection", collection.CollectionName, "error", err)
+		collection.mu.Unlock()
+		return err
+	}
+
+	var pendingChanges = collection.pendingChanges
+	var databaseName, collectionName = collection.DatabaseName, collection.CollectionName
+
+	collection.pendingChanges = make(map[string]string)
+	collection.IsChanged = false
+
+	collection.mu.Unlock()
+
+	var segmentId = common.GetCollectionSegmentFileName(segment.Seq)
+
+	startedAt := time.Now()
+	err = collection.storage.PersistSegment(databaseName, collectionName, segmentId, segmentGobData)
+	if metrics.ObserveDiskWrite(global_constants.DISK_WRITE_SEGMENT, startedAt, err) != nil {
+		storageLogger.Error("segment write error", "database", databaseName, "collection", collectionName, "segment", segmentId, "error", err)
+
+		// the next save writes these changes again together with the newer ones
+		collection.mu.Lock()
+		for docId, batchId := range pendingChanges {
+			if _, exists := collection.pendingChanges[docId]; !exists {
+				collection.pendingChanges[docId] = batchId
+			}
+		}
+		collection.IsChanged = true
+		collection.mu.Unlock()
+
+		return err
+	}
+
+	collection.mu.Lock()
+	collection.segmentSeq = segment.Seq
+	collection.segmentIds = append(collection.segmentIds, segmentId)
+	collection.segmentBytes += int64(len(segmentGobData))
+	collection.mu.Unlock()
+
+	return nil
+}
+
+// isCompactionDue reports if enough segments piled up since the last checkpoint
+func (collection *Collection) isCompactionDue() bool {
+	collection.mu.RLock()
+	defer collection.mu.RUnlock()
+
+	return len(collection.segmentIds) >= global_constants.SEGMENT_COMPACTION_COUNT || collection.segmentBytes >= global_constants.SEGMENT_COMPACTION_SIZE
+}
+
+// compactInBackground starts a checkpoint unless one is already running
+func (collection *Collection) compactInBackground() {
+	if !collection.compactMu.TryLock() {
+		return
+	}
+
+	go func() {
+		defer collection.compactMu.Unlock()
+		collection.compactSegments()
+	}()
+}
+
+// CompactSegments writes a checkpoint: the batches changed since the last checkpoint, then the collection file with IndexMap,
+// then the segments folded into them are deleted. A crash at any point leaves either the old or the new checkpoint with its segments.
+func (collection *Collection) CompactSegments() {
+	collection.compactMu.Lock()
+	defer collection.compactMu.Unlock()
+
+	collection.compactSegments()
+}
+
+// compactSegments writes a checkpoint, caller must hold collection.compactMu
+func (collection *Collection) compactSegments() {
+	collection.saveMu.Lock()
+
+	// every change up to the checkpoint must be in a segment, so deleting the segments never loses one
+	if err := collection.appendSegment(); err != nil {
+		collection.saveMu.Unlock()
+		return
+	}
+
+	collection.mu.Lock()
+
+	if collection.CollectionName == "" {
+		collection.mu.Unlock()
+		collection.saveMu.Unlock()
+		return
+	}
+
+	var batchesGobData = make(map[string][]byte)
+
+	for fileName, isUpdated := range collection.BatchUpdateStatus {
+		if documents, exists := collection.DocumentsMap[fileName]; exists && isUpdated {
+			gobData, err := common.EncodeGob(documents)
+			if err == nil {
+				batchesGobData[fileName] = gobData
+				collection.BatchChecksums[fileName] = common.Checksum(gobData)
+				collection.BatchUpdateStatus[fileName] = false
+			} else {
+				storageLogger.Error("batch GOB encoding error", "database", collection.DatabaseName, "collection", collection.CollectionName, "batch", fileName, "error", err)
+			}
+
+		} else if isUpdated {
+			storageLogger.Debug("changed batch not in DocumentsMap", "database", collection.DatabaseName, "collection", collection.CollectionName, "batch", fileName)
+			collection.BatchUpdateStatus[fileName] = false
+		}
+	}
+
+	collectionFileStruct := collection.toCollectionFileStruct()
+	collectionFileStruct.CompactedSegmentSeq = collection.segmentSeq
+
+	collectionGobData, err := common.EncodeGob(collectionFileStruct)
+	if err != nil {
+		storageLogger.Error("collection file GOB encoding error", "database", collection.DatabaseName, "collection", collection.CollectionName, "error", err)
+	}
+
+	var segmentIds, obsoleteBatchIds = collection.segmentIds, collection.obsoleteBatchIds
+	var databaseName, collectionName = collection.DatabaseName, collection.CollectionName
+
+	collection.segmentIds = make([]string, 0)
+	collection.segmentBytes = 0
+	collection.obsoleteBatchIds = make([]string, 0)
+
+	collection.mu.Unlock()
+	collection.saveMu.Unlock()
+
+	// Write Batch files to disk
+	for fileName, gobData := range batchesGobData {
+		startedAt := time.Now()
+		err := collection.storage.PersistBatch(databaseName, collectionName, fileName, gobData)
+		if metrics.ObserveDiskWrite(global_constants.DISK_WRITE_BATCH, startedAt, err) != nil {
+			storageLogger.Error("batch write error", "database", databaseName, "collection", collectionName, "batch", fileName, "error", err)
+			collection.markUncompacted(batchesGobData, segmentIds, obsoleteBatchIds)
+			return
+		}
+	}
+
+	if collectionGobData == nil {
+		collection.markUncompacted(nil, segmentIds, obsoleteBatchIds)
+		return
+	}
+
+	// Write collection file to disk, only after all batches it refers to are on disk
+	startedAt := time.Now()
+	err = collection.storage.PersistMetadata(databaseName, collectionName, collectionGobData)
+	if metrics.ObserveDiskWrite(global_constants.DISK_WRITE_COLLECTION, startedAt, err) != nil {
+		storageLogger.Error("collection file write error", "database", databaseName, "collection", collectionName, "error", err)
+		collection.markUncompacted(batchesGobData, segmentIds, obsoleteBatchIds)
+		return
+	}
+
+	// leftover segments are at or below CompactedSegmentSeq and leftover batches aren't listed in the collection file,
+	// both are skipped on load and deleted by the next checkpoint
+	if err := collection.storage.DeleteSegments(databaseName, collectionName, segmentIds); err != nil {
+		storageLogger.Error("segment delete error", "database", databaseName, "collection", collectionName, "error", err)
+		collection.markUncompacted(nil, segmentIds, nil)
+	}
+	if err := collection.storage.DeleteBatches(databaseName, collectionName, obsoleteBatchIds); err != nil {
+		storageLogger.Error("batch delete error", "database", databaseName, "collection", collectionName, "error", err)
+		collection.markUncompacted(nil, nil, obsoleteBatchIds)
+	}
+
+	// the written batches can be evicted now that their files hold the same documents
+	for batchId, gobData := range batchesGobData {
+		collection.cache.touch(collection, batchId, int64(len(gobData)))
+	}
+	collection.cache.evict(nil, "")
+}
+
+// markUncompacted makes the next checkpoint rewrite the batches and delete the files of a failed checkpoint
+func (collection *Collection) markUncompacted(batchesGobData map[string][]byte, segmentIds []string, obsoleteBatchIds []string) {
+	collection.mu.Lock()
+	defer collection.mu.Unlock()
+
+	for batchId := range batchesGobData {
+		collection.BatchUpdateStatus[batchId] = true
+	}
+	collection.segmentIds = append(segmentIds, collection.segmentIds...)
+	collection.obsoleteBatchIds = append(obsoleteBatchIds, collection.obsoleteBatchIds...)
+}
+
+// applySegment replays the changes of a segment and keeps IndexMap in step, caller must hold collection.mu
+func (collection *Collection) applySegment(segment Segment) {
+	for _, entry := range segment.Entries {
+		// the document may sit in another batch if the merge that moved it was cut short
+		if batchId, exists := collection.documentBatchIds[entry.DocId]; exists {
+			collection.deleteIndex(collection.DocumentsMap[batchId][entry.DocId])
+			delete(collection.DocumentsMap[batchId], entry.DocId)
+			delete(collection.documentBatchIds, entry.DocId)
+			collection.BatchUpdateStatus[batchId] = true
+		}
+
+		collection.BatchUpdateStatus[entry.BatchId] = true
+
+		if entry.Document == nil {
+			continue
+		}
+
+		if _, exists := collection.DocumentsMap[entry.BatchId]; !exists {
+			collection.DocumentsMap[entry.BatchId] = make(BatchDocuments)
+		}
+		collection.DocumentsMap[entry.BatchId][entry.DocId] = entry.Document
+		collection.documentBatchIds[entry.DocId] = entry.BatchId
+		collection.createIndex(entry.Document)
+	}
+
+	if segment.LastIndex > collection.LastIndex {
+		collection.LastIndex = segment.LastIndex
+	}
+	collection.CurrentBatchId = segment.CurrentBatchId
+	collection.CurrentBatchCount = segment.CurrentBatchCount
+}
+
+// decodeSegments decodes the segments newer than the checkpoint in Seq order, the ids of all segments read are returned
+// so the next checkpoint deletes them, including the ones left behind by a checkpoint that was cut short
+func decodeSegments(databaseName string, collectionName string, segmentsGobData map[string][]byte, compactedSegmentSeq uint64) ([]Segment, []string) {
+	var segments = make([]Segment, 0)
+	var segmentIds = make([]string, 0, len(segmentsGobData))
+
+	for segmentId, segmentGobData := range segmentsGobData {
+		segmentIds = append(segmentIds, segmentId)
+
+		var segment Segment
+		if err := common.DecodeGob(segmentGobData, &segment); err != nil {
+			storageLogger.Error("segment decoding error", "database", databaseName, "collection", collectionName, "segment", segmentId, "error", err)
+			continue
+		}
+
+		if segment.Seq > compactedSegmentSeq {
+			segments = append(segments, segment)
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool { return segments[i].Seq < segments[j].Seq })
+	sort.Strings(segmentIds)
+
+	var expectedSeq = compactedSegmentSeq + 1
+	for _, segment := range segments {
+		if segment.Seq != expectedSeq {
+			storageLogger.Error("segments missing, their changes are lost", "database", databaseName, "collection", collectionName, "fromSeq", expectedSeq, "toSeq", segment.Seq-1)
+		}
+		expectedSeq = segment.Seq + 1
+	}
+
+	return segments, segmentIds
+}
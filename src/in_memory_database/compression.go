@@ -0,0 +1,186 @@
+package in_memory_database
+
+import (
+	"errors"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"sync"
+)
+
+// compressedStorage compresses the batch and collection files of a database with the codec named in its Config before they
+// reach the storage engine. Files are decompressed by their header, so files written uncompressed still load.
+// Segments are small and short lived, they are stored as is.
+type compressedStorage struct {
+	StorageEngine
+	codec string
+	sizes *compressedSizes
+}
+
+// compressedSizes keeps the size of every batch and collection file of a database, before and after compression
+type compressedSizes struct {
+	mu    sync.Mutex
+	files map[string]map[string]fileSize // collectionName: fileId: sizes
+}
+
+type fileSize struct {
+	uncompressed int64
+	stored       int64
+}
+
+// newDatabaseStorage returns the storage engine named in Config, wrapped to compress with the codec named in Config
+func newDatabaseStorage(storageEngines map[string]StorageEngine, config Config) (StorageEngine, error) {
+	storageEngine, exists := storageEngines[config.storageEngineName()]
+	if !exists {
+		return nil, errors.New(global_constants.STORAGE_ENGINE_NOT_FOUND_MSG)
+	}
+
+	if !common.IsCompressionCodec(config.compressionCodec()) {
+		return nil, errors.New(global_constants.COMPRESSION_NOT_FOUND_MSG)
+	}
+
+	return &compressedStorage{
+		StorageEngine: storageEngine,
+		codec:         config.compressionCodec(),
+		sizes:         &compressedSizes{files: make(map[string]map[string]fileSize)},
+	}, nil
+}
+
+// compressionCodec returns the codec named in Config, empty when the database stores files uncompressed
+func (config Config) compressionCodec() string {
+	if codec, ok := config[global_constants.CONFIG_COMPRESSION].(string); ok {
+		return codec
+	}
+	return ""
+}
+
+func (storage *compressedStorage) LoadCollection(databaseName string, collectionName string) (StoredCollection, error) {
+	storedCollection, err := storage.StorageEngine.LoadCollection(databaseName, collectionName)
+	if err != nil {
+		return storedCollection, err
+	}
+
+	storage.sizes.removeCollection(collectionName)
+
+	if storedCollection.Metadata != nil {
+		storedCollection.Metadata = storage.decompress(collectionName, common.GetCollectionFileName(collectionName), storedCollection.Metadata)
+	}
+
+	for batchId, data := range storedCollection.Batches {
+		if gobData := storage.decompress(collectionName, batchId, data); gobData != nil {
+			storedCollection.Batches[batchId] = gobData
+		} else {
+			delete(storedCollection.Batches, batchId)
+		}
+	}
+
+	return storedCollection, nil
+}
+
+func (storage *compressedStorage) LoadBatch(databaseName string, collectionName string, batchId string) ([]byte, error) {
+	data, err := storage.StorageEngine.LoadBatch(databaseName, collectionName, batchId)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecompressGob(data)
+}
+
+func (storage *compressedStorage) PersistBatch(databaseName string, collectionName string, batchId string, gobData []byte) error {
+	data, err := storage.compress(collectionName, batchId, gobData)
+	if err != nil {
+		return err
+	}
+	return storage.StorageEngine.PersistBatch(databaseName, collectionName, batchId, data)
+}
+
+func (storage *compressedStorage) PersistMetadata(databaseName string, collectionName string, gobData []byte) error {
+	data, err := storage.compress(collectionName, common.GetCollectionFileName(collectionName), gobData)
+	if err != nil {
+		return err
+	}
+	return storage.StorageEngine.PersistMetadata(databaseName, collectionName, data)
+}
+
+func (storage *compressedStorage) DeleteBatches(databaseName string, collectionName string, batchIds []string) error {
+	storage.sizes.remove(collectionName, batchIds)
+	return storage.StorageEngine.DeleteBatches(databaseName, collectionName, batchIds)
+}
+
+func (storage *compressedStorage) DeleteCollection(databaseName string, collectionName string) error {
+	storage.sizes.removeCollection(collectionName)
+	return storage.StorageEngine.DeleteCollection(databaseName, collectionName)
+}
+
+// Reencrypt passes a key rotation to the engine, files are sealed after they are compressed
+func (storage *compressedStorage) Reencrypt(databaseName string) (int, error) {
+	if reencrypter, ok := storage.StorageEngine.(Reencrypter); ok {
+		return reencrypter.Reencrypt(databaseName)
+	}
+	return 0, nil
+}
+
+func (storage *compressedStorage) compress(collectionName string, fileId string, gobData []byte) ([]byte, error) {
+	data, err := common.CompressGob(storage.codec, gobData)
+	if err == nil {
+		storage.sizes.set(collectionName, fileId, fileSize{uncompressed: int64(len(gobData)), stored: int64(len(data))})
+	}
+	return data, err
+}
+
+// decompress returns nil for data that can't be decompressed, it is treated like an unreadable file
+func (storage *compressedStorage) decompress(collectionName string, fileId string, data []byte) []byte {
+	gobData, err := common.DecompressGob(data)
+	if err != nil {
+		return nil
+	}
+
+	storage.sizes.set(collectionName, fileId, fileSize{uncompressed: int64(len(gobData)), stored: int64(len(data))})
+	return gobData
+}
+
+func (sizes *compressedSizes) set(collectionName string, fileId string, size fileSize) {
+	sizes.mu.Lock()
+	defer sizes.mu.Unlock()
+
+	if _, exists := sizes.files[collectionName]; !exists {
+		sizes.files[collectionName] = make(map[string]fileSize)
+	}
+	sizes.files[collectionName][fileId] = size
+}
+
+func (sizes *compressedSizes) remove(collectionName string, fileIds []string) {
+	sizes.mu.Lock()
+	defer sizes.mu.Unlock()
+
+	for _, fileId := range fileIds {
+		delete(sizes.files[collectionName], fileId)
+	}
+}
+
+func (sizes *compressedSizes) removeCollection(collectionName string) {
+	sizes.mu.Lock()
+	defer sizes.mu.Unlock()
+
+	delete(sizes.files, collectionName)
+}
+
+// total returns the size of the batch and collection files of a collection, before and after compression
+func (sizes *compressedSizes) total(collectionName string) fileSize {
+	sizes.mu.Lock()
+	defer sizes.mu.Unlock()
+
+	var total fileSize
+	for _, size := range sizes.files[collectionName] {
+		total.uncompressed += size.uncompressed
+		total.stored += size.stored
+	}
+	return total
+}
+
+// compressedSizeOf returns the file sizes of a collection stored through a database storage
+func compressedSizeOf(storageEngine StorageEngine, collectionName string) fileSize {
+	if storage, ok := storageEngine.(*compressedStorage); ok {
+		return storage.sizes.total(collectionName)
+	}
+	return fileSize{}
+}
@@ -1,17 +1,29 @@
 package in_memory_database
 
 import (
-	"fmt"
 	"gnosql/src/common"
-	"os"
+	"gnosql/src/global_constants"
+	"gnosql/src/metrics"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 )
 
+// Config is gob encoded as a MapInterface, so every concrete type stored in it other than
+// the basic ones (webhooks, query cache caps) must be registered with gob.Register
 type Config MapInterface
 
 type Database struct {
-	DatabaseName string        `json:"DatabaseName"`
-	Collections  []*Collection `json:"Collections"`
-	Config       Config        `json:"Config"`
+	DatabaseName  string        `json:"DatabaseName"`
+	Collections   []*Collection `json:"Collections"` // guarded by collectionsMu, read it through GetAllColls
+	Config        Config        `json:"Config"`
+	storage       StorageEngine // engine named in Config, shared with the collections
+	cache         *BatchCache   // memory budget named in Config, shared with the collections
+	configMu      sync.RWMutex  // guards Config, changed by webhook and query cache requests while saves and snapshots read it
+	collectionsMu sync.RWMutex
+	gnoSQL        *GnoSQL
 }
 
 type DatabaseFileStruct struct {
@@ -19,54 +31,135 @@ type DatabaseFileStruct struct {
 	Config       Config `json:"Config"`
 }
 
-func CreateDatabase(databaseName string, collectionsInput []CollectionInput) *Database {
+// DatabaseConfigInput holds the settings chosen when a database is created, they are kept in its Config
+type DatabaseConfigInput struct {
+	// Example: kv, gob when empty
+	StorageEngine string `json:"storageEngine"`
+
+	// Example: 512, memory for documents in MB, least recently used batches are kept on disk beyond it. Unbounded when 0
+	MemoryBudgetMB int `json:"memoryBudgetMB"`
+
+	// Example: zstd or snappy, for batch and collection files. Uncompressed when empty
+	Compression string `json:"compression"`
+}
+
+// CreateDatabase writes the file of a new database, its collections are created by CreateDB once it is logged
+func CreateDatabase(databaseName string, configInput DatabaseConfigInput, gnoSQL *GnoSQL) (*Database, error) {
 	Config := make(Config)
 	Config["version"] = 1
+	Config[global_constants.CONFIG_STORAGE_ENGINE] = configInput.StorageEngine
+
+	if configInput.StorageEngine == "" {
+		Config[global_constants.CONFIG_STORAGE_ENGINE] = global_constants.STORAGE_ENGINE_GOB
+	}
+	if configInput.MemoryBudgetMB > 0 {
+		Config[global_constants.CONFIG_MEMORY_BUDGET] = configInput.MemoryBudgetMB
+	}
+	if configInput.Compression != "" {
+		Config[global_constants.CONFIG_COMPRESSION] = configInput.Compression
+	}
 
 	db := &Database{
 		DatabaseName: databaseName,
 		Collections:  make([]*Collection, 0),
 		Config:       Config,
+		gnoSQL:       gnoSQL,
+	}
+	storage, err := newDatabaseStorage(gnoSQL.storageEngines, Config)
+	if err != nil {
+		return nil, err
 	}
+	db.storage = storage
+	db.cache = NewBatchCache(Config.memoryBudget())
 
-	common.CreateFolder(common.GetDatabaseFolderPath(databaseName))
+	common.CreateFolder(db.folderPath())
 
 	db.SaveDatabaseToFile()
-	db.CreateColls(collectionsInput)
 
-	return db
+	return db, nil
 }
 
-func LoadDatabase(database DatabaseFileStruct) *Database {
-	return &Database{
+func LoadDatabase(database DatabaseFileStruct, gnoSQL *GnoSQL) (*Database, error) {
+	db := &Database{
 		DatabaseName: database.DatabaseName,
 		Collections:  make([]*Collection, 0),
 		Config:       database.Config,
+		gnoSQL:       gnoSQL,
 	}
+
+	if db.Config == nil {
+		db.Config = make(Config)
+	}
+	storage, err := newDatabaseStorage(gnoSQL.storageEngines, db.Config)
+	if err != nil {
+		return nil, err
+	}
+	db.storage = storage
+	db.cache = NewBatchCache(db.Config.memoryBudget())
+
+	for _, webhook := range db.GetWebhooks() {
+		gnoSQL.WebhookDispatcher.Register(webhook)
+	}
+
+	return db, nil
+}
+
+// folderPath returns the folder of the database in the data folder, its files are sealed with its data key
+func (db *Database) folderPath() string {
+	return common.GetDatabaseFolderPath(db.gnoSQL.Settings.DataPath, db.DatabaseName)
 }
 
 func (db *Database) DeleteDatabase() {
-	common.DeleteFolder(common.GetDatabaseFolderPath(db.DatabaseName))
-	for _, collection := range db.Collections {
+	db.gnoSQL.WebhookDispatcher.UnregisterDatabase(db.DatabaseName)
+	db.storage.Close(db.DatabaseName)
+	common.DeleteFolder(db.folderPath())
+	for _, collection := range db.GetAllColls() {
 		collection.DeleteCollection(false)
 	}
 }
 
 func (db *Database) CreateColls(collectionsInput []CollectionInput) []*Collection {
 	var collections []*Collection = make([]*Collection, 0)
+	var collectionsCreated []CollectionInput = make([]CollectionInput, 0)
+	var isConfigChanged = false
+
+	// held while creating, so two requests for the same name create it once
+	db.collectionsMu.Lock()
 	for _, collectionInput := range collectionsInput {
-		if IsCollectionExists := db.GetColl(collectionInput.CollectionName); IsCollectionExists == nil {
+		if IsCollectionExists := db.getColl(collectionInput.CollectionName); IsCollectionExists == nil {
+			if collectionInput.QueryCacheMB > 0 {
+				db.setQueryCacheMB(collectionInput.CollectionName, collectionInput.QueryCacheMB)
+				isConfigChanged = true
+			}
+
 			collection := CreateCollection(collectionInput, db)
 			db.Collections = append(db.Collections, collection)
 			collections = append(collections, collection)
+			collectionsCreated = append(collectionsCreated, collectionInput)
 		}
 	}
+	db.collectionsMu.Unlock()
+
+	if isConfigChanged {
+		db.SaveDatabaseToFile()
+	}
+
+	if len(collectionsCreated) > 0 {
+		db.gnoSQL.ReplicationLog.Append(ReplicationEntry{
+			DatabaseName:     db.DatabaseName,
+			Event:            Event{Type: global_constants.EVENT_CREATE_COLLECTIONS},
+			CollectionsInput: collectionsCreated,
+		})
+	}
+
 	return collections
 }
 
 func (db *Database) DeleteColls(collectionNamesToDelete []string) *Database {
 	var Collections []*Collection = make([]*Collection, 0)
+	var deletedCollections []*Collection = make([]*Collection, 0)
 
+	db.collectionsMu.Lock()
 	for _, collection := range db.Collections {
 		ToBeDeleted := false
 		for _, collectionNameToDelete := range collectionNamesToDelete {
@@ -78,21 +171,67 @@ func (db *Database) DeleteColls(collectionNamesToDelete []string) *Database {
 		if !ToBeDeleted {
 			Collections = append(Collections, collection)
 		} else {
-			collection.DeleteCollection(true)
+			deletedCollections = append(deletedCollections, collection)
 		}
 
 	}
-
 	db.Collections = Collections
+	db.collectionsMu.Unlock()
+
+	for _, collection := range deletedCollections {
+		collection.DeleteCollection(true)
+	}
+
+	// a collection created again with the same name starts without a query cache
+	if db.hasQueryCaches() {
+		for _, collectionName := range collectionNamesToDelete {
+			db.setQueryCacheMB(collectionName, 0)
+		}
+		db.SaveDatabaseToFile()
+	}
+
+	db.gnoSQL.ReplicationLog.Append(ReplicationEntry{
+		DatabaseName:    db.DatabaseName,
+		Event:           Event{Type: global_constants.EVENT_DELETE_COLLECTIONS},
+		CollectionNames: collectionNamesToDelete,
+	})
 
 	return db
 }
 
-func (db *Database) LoadColls(collectionsGob []CollectionFileStruct) []*Collection {
-	return LoadCollections(collectionsGob)
+func (db *Database) RegisterColls(collectionNames []string) []*Collection {
+	var collections = make([]*Collection, 0)
+	for _, collectionName := range collectionNames {
+		collections = append(collections, RegisterCollection(collectionName, db))
+	}
+	return collections
+}
+
+// addColls appends collections read from disk
+func (db *Database) addColls(collections []*Collection) {
+	db.collectionsMu.Lock()
+	defer db.collectionsMu.Unlock()
+
+	db.Collections = append(db.Collections, collections...)
+}
+
+// GetAllColls returns the collections at the time of the call, collections created or deleted meanwhile are not in it
+func (db *Database) GetAllColls() []*Collection {
+	db.collectionsMu.RLock()
+	defer db.collectionsMu.RUnlock()
+
+	return slices.Clone(db.Collections)
 }
 
 func (db *Database) GetColl(collectionName string) *Collection {
+	db.collectionsMu.RLock()
+	defer db.collectionsMu.RUnlock()
+
+	return db.getColl(collectionName)
+}
+
+// getColl expects db.collectionsMu to be held
+func (db *Database) getColl(collectionName string) *Collection {
 	for _, collection := range db.Collections {
 		if collection.CollectionName == collectionName {
 			return collection
@@ -102,50 +241,157 @@ func (db *Database) GetColl(collectionName string) *Collection {
 }
 func (db *Database) GetCollectionNames() []string {
 	var colelctionNames []string
-	for _, collection := range db.Collections {
+	for _, collection := range db.GetAllColls() {
 		colelctionNames = append(colelctionNames, collection.CollectionName)
 	}
 	return colelctionNames
 }
 
+func (db *Database) GetWebhooks() []Webhook {
+	db.configMu.RLock()
+	defer db.configMu.RUnlock()
+
+	return db.webhooks()
+}
+
+// webhooks returns the webhooks in Config, caller must hold db.configMu
+func (db *Database) webhooks() []Webhook {
+	if webhooks, ok := db.Config[global_constants.CONFIG_WEBHOOKS].([]Webhook); ok {
+		return webhooks
+	}
+	return make([]Webhook, 0)
+}
+
+func (db *Database) GetWebhook(webhookId string) (Webhook, bool) {
+	for _, webhook := range db.GetWebhooks() {
+		if webhook.Id == webhookId {
+			return webhook, true
+		}
+	}
+	return Webhook{}, false
+}
+
+func (db *Database) AddWebhook(webhook Webhook) Webhook {
+	// id is already set when the webhook is replayed from a replication entry
+	if webhook.Id == "" {
+		webhook.Id = common.Generate16DigitUUID()
+	}
+	webhook.DatabaseName = db.DatabaseName
+	webhook.CreatedAt = common.UuidStringToTimeString(webhook.Id)
+
+	db.configMu.Lock()
+	// a new slice, files being saved or snapshots being taken keep the webhooks they read
+	db.Config[global_constants.CONFIG_WEBHOOKS] = append(append(make([]Webhook, 0), db.webhooks()...), webhook)
+	db.configMu.Unlock()
+
+	db.SaveDatabaseToFile()
+
+	db.gnoSQL.WebhookDispatcher.Register(webhook)
+
+	db.gnoSQL.ReplicationLog.Append(ReplicationEntry{
+		DatabaseName: db.DatabaseName,
+		Event:        Event{Type: global_constants.EVENT_CREATE_WEBHOOK, Id: webhook.Id},
+		Webhook:      webhook,
+	})
+
+	return webhook
+}
+
+func (db *Database) DeleteWebhook(webhookId string) bool {
+	var webhooks = make([]Webhook, 0)
+	var isDeleted = false
+
+	db.configMu.Lock()
+	for _, webhook := range db.webhooks() {
+		if webhook.Id == webhookId {
+			isDeleted = true
+			continue
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if isDeleted {
+		db.Config[global_constants.CONFIG_WEBHOOKS] = webhooks
+	}
+	db.configMu.Unlock()
+
+	if !isDeleted {
+		return false
+	}
+
+	db.SaveDatabaseToFile()
+
+	db.gnoSQL.WebhookDispatcher.Unregister(db.DatabaseName, webhookId)
+
+	db.gnoSQL.ReplicationLog.Append(ReplicationEntry{
+		DatabaseName: db.DatabaseName,
+		Event:        Event{Type: global_constants.EVENT_DELETE_WEBHOOK, Id: webhookId},
+	})
+
+	return true
+}
+
+// databaseFile returns what is written to the database file, with a copy of Config taken under db.configMu
+func (db *Database) databaseFile() DatabaseFileStruct {
+	db.configMu.RLock()
+	defer db.configMu.RUnlock()
+
+	return DatabaseFileStruct{DatabaseName: db.DatabaseName, Config: maps.Clone(db.Config)}
+}
+
 func (db *Database) SaveDatabaseToFile() {
-	fmt.Printf("\n Writing to database : %s to disk \n", db.DatabaseName)
 
 	// Convert struct to gob
-	temp := DatabaseFileStruct{
-		DatabaseName: db.DatabaseName,
-		Config:       db.Config,
+	gobData, err := common.EncodeGob(db.databaseFile())
+
+	if err != nil {
+		storageLogger.Error("database file GOB encoding error", "database", db.DatabaseName, "error", err)
 	}
 
-	gobData, err := common.EncodeGob(temp)
+	startedAt := time.Now()
+	err = common.SaveGobFile(db.folderPath(), common.GetDatabaseFilePath(db.gnoSQL.Settings.DataPath, db.DatabaseName, common.GetDatabaseFileName(db.DatabaseName)), gobData)
+	metrics.ObserveDiskWrite(global_constants.DISK_WRITE_DATABASE, startedAt, err)
 
 	if err != nil {
-		fmt.Println("GOB encoding error:", err)
+		storageLogger.Error("database file write error", "database", db.DatabaseName, "error", err)
 	}
 
-	err = common.SaveToFile(common.GetDatabaseFilePath(db.DatabaseName, common.GetDatabaseFileName(db.DatabaseName)), gobData)
+	storageLogger.Debug("database file written", "database", db.DatabaseName)
+}
 
+// ReencryptFiles rewrites the files of the database sealed with a previous data key, writes go on meanwhile
+func (db *Database) ReencryptFiles() {
+	var startedAt = time.Now()
+
+	rewrittenFiles, err := common.ReencryptFolder(db.folderPath(), func() (int, error) {
+		if reencrypter, ok := db.storage.(Reencrypter); ok {
+			return reencrypter.Reencrypt(db.DatabaseName)
+		}
+		return 0, nil
+	})
 	if err != nil {
-		fmt.Println("Error saving database GOB to file:", err)
+		logger.Error("re-encryption error", "database", db.DatabaseName, "error", err)
+		return
 	}
 
-	fmt.Println("GOB data saved to ", db.DatabaseName)
+	logger.Info("key rotated", "database", db.DatabaseName, "files", rewrittenFiles, "took", time.Since(startedAt))
 }
 
 func ReadDatabaseGobFile(filePath string) (DatabaseFileStruct, error) {
 	var gobData DatabaseFileStruct
 
-	fileData, err := os.ReadFile(filePath)
+	// the database file is in the folder of its database
+	fileData, err := common.ReadGobFile(filepath.Dir(filePath), filePath)
 
 	if err != nil {
-		fmt.Printf("\n Datebase file %s reading, Error %v", filePath, err)
+		storageLogger.Error("database file read error", "file", filePath, "error", err)
 		return gobData, err
 	}
 
 	err = common.DecodeGob(fileData, &gobData)
 
 	if err != nil {
-		fmt.Printf("\n Datebase file %s decoding , Error %v", filePath, err)
+		storageLogger.Error("database file decoding error", "file", filePath, "error", err)
 
 		return gobData, err
 	}
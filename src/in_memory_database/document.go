@@ -2,11 +2,14 @@ package in_memory_database
 
 import (
 	"cmp"
+	"context"
 	"fmt"
 	"gnosql/src/global_constants"
 	"slices"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 func (collection *Collection) Read(id string) Document {
@@ -17,12 +20,22 @@ func (collection *Collection) Read(id string) Document {
 	return document
 }
 
-func (collection *Collection) Filter(reqFilter MapInterface) []Document {
+func (collection *Collection) Filter(ctx context.Context, reqFilter MapInterface) []Document {
+	documents, _ := collection.FilterWithPlan(ctx, reqFilter)
+	return documents
+}
+
+// FilterWithPlan filters like Filter and returns how the filter ran, Ex: the index lookups and documents scanned
+func (collection *Collection) FilterWithPlan(ctx context.Context, reqFilter MapInterface) ([]Document, QueryPlan) {
 	collection.mu.RLock()
 	defer collection.mu.RUnlock()
 
+	var startedAt = time.Now()
+	var stageStartedAt = startedAt
+	var plan = QueryPlan{Shape: queryShape(reqFilter), IndexLookups: make([]IndexLookup, 0), ScanFilters: make([]string, 0)}
+
 	filters := make([]MapInterface, 0)
-	var limit int = global_constants.FILTER_DEFAULT_LIMIT
+	var limit int = collection.gnoSQL.Settings.FilterLimit
 
 	for key, value := range reqFilter {
 		temp := make(MapInterface)
@@ -31,10 +44,35 @@ func (collection *Collection) Filter(reqFilter MapInterface) []Document {
 			temp[global_constants.FILTER_VALUE] = value
 			filters = append(filters, temp)
 		} else {
-			limit = value.(int)
+			// JSON requests decode numbers as float64
+			switch limitValue := value.(type) {
+			case int:
+				limit = limitValue
+			case float64:
+				limit = int(limitValue)
+			}
+		}
+	}
+
+	// served from the query cache until a change to a document matching the filter
+	var cacheKey string
+	if collection.queryCache != nil {
+		cacheKey = queryCacheKey(filters, limit)
+
+		if documents, isCached := collection.queryCache.get(cacheKey); isCached {
+			plan.Strategy = global_constants.QUERY_STRATEGY_CACHE
+			plan.Limit = limit
+			plan.Returned = len(documents)
+			plan.endStage(global_constants.QUERY_STAGE_CACHE, stageStartedAt)
+
+			var took = time.Since(startedAt)
+			plan.Took = took.String()
+
+			collection.logQuery(ctx, plan, took)
+
+			return documents, plan
 		}
 	}
-	fmt.Printf("\n filters %+v \n", filters)
 
 	filtersWithoutIndex := make([]MapInterface, 0)
 	filtersWithIndex := make([]MapInterface, 0)
@@ -49,22 +87,23 @@ outerLoop:
 			}
 		}
 		filtersWithoutIndex = append(filtersWithoutIndex, filter)
+		plan.ScanFilters = append(plan.ScanFilters, filter[global_constants.FILTER_KEY].(string))
 	}
+	plan.Limit = limit
+	stageStartedAt = plan.endStage(global_constants.QUERY_STAGE_PLAN, stageStartedAt)
 
 	var filteredDocIds = make(DocumentIds, 0)
 
 	// if filter have index keys, first filter ids based on
 	if len(filtersWithIndex) > 0 {
-		filteredDocIds = collection.GetfilteredIdsWithIndexkeys(filtersWithIndex)
+		filteredDocIds, plan.IndexLookups = collection.filteredIdsWithIndexKeys(filtersWithIndex)
+		plan.Candidates = len(filteredDocIds)
+		stageStartedAt = plan.endStage(global_constants.QUERY_STAGE_INDEX, stageStartedAt)
 	}
 
-	// fmt.Printf("\n Indexing filters count: %d ", len(filtersWithIndex))
-	// fmt.Printf("\n Non-indexing filters count: %d ", len(filtersWithoutIndex))
-	// fmt.Printf("\n Scanning %d documents \n", len(filteredDocIds))
-
 	filteredDocIdsLength := len(filteredDocIds)
 
-	workerCount := global_constants.FILTER_DEFAULT_WORKER_COUNT
+	workerCount := collection.gnoSQL.Settings.FilterWorkers
 
 	// Use a WaitGroup to wait for the goroutine to finish
 	var wg sync.WaitGroup
@@ -73,26 +112,29 @@ outerLoop:
 	// Create a channel to communicate results
 	resultChannel := make(chan Document)
 	var isIndexQuery = len(filtersWithIndex) > 0
+	// documents compared against the filters by every worker
+	var scanned atomic.Int64
 
 	// filter document with index query
 	if isIndexQuery {
+		plan.Strategy = global_constants.QUERY_STRATEGY_INDEX
+
 		for i := 0; i < workerCount; i++ {
 			start := i * filteredDocIdsLength / workerCount
 			end := (i + 1) * filteredDocIdsLength / workerCount
-			go collection.filterWithIndex(&wg, resultChannel, filtersWithoutIndex, start, end, filteredDocIds)
+			go collection.filterWithIndex(&wg, resultChannel, filtersWithoutIndex, start, end, filteredDocIds, &scanned)
 		}
 	} else {
-		var allBatchIds = make([]string, 0)
-
-		for batchId := range collection.DocumentsMap {
-			allBatchIds = append(allBatchIds, batchId)
-		}
+		var allBatchIds = collection.batchIds()
 		var allBatchIdsLength = len(allBatchIds)
 
+		plan.Strategy = global_constants.QUERY_STRATEGY_SCAN
+		plan.BatchesScanned = allBatchIdsLength
+
 		for i := 0; i < workerCount; i++ {
 			start := i * allBatchIdsLength / workerCount
 			end := (i + 1) * allBatchIdsLength / workerCount
-			go collection.filterWithoutIndex(&wg, resultChannel, filtersWithoutIndex, start, end, allBatchIds)
+			go collection.filterWithoutIndex(&wg, resultChannel, filtersWithoutIndex, start, end, allBatchIds, &scanned)
 		}
 
 	}
@@ -114,6 +156,9 @@ outerLoop:
 	for result := range resultChannel {
 		results = append(results, result)
 	}
+	plan.DocumentsScanned = scanned.Load()
+	plan.Matched = len(results)
+	stageStartedAt = plan.endStage(global_constants.QUERY_STAGE_SCAN, stageStartedAt)
 
 	sortDocuments(results)
 
@@ -128,11 +173,22 @@ outerLoop:
 	for i := 0; i < limit; i++ {
 		limitedResult = append(limitedResult, results[i])
 	}
+	plan.endStage(global_constants.QUERY_STAGE_SORT, stageStartedAt)
+
+	if collection.queryCache != nil {
+		collection.queryCache.put(cacheKey, filters, limitedResult)
+	}
 
-	return limitedResult
+	var took = time.Since(startedAt)
+	plan.Returned = len(limitedResult)
+	plan.Took = took.String()
+
+	collection.logQuery(ctx, plan, took)
+
+	return limitedResult, plan
 }
 
-func (collection *Collection) filterWithIndex(wg *sync.WaitGroup, resultChannel chan Document, filters []MapInterface, start int, end int, filteredDocIds DocumentIds) {
+func (collection *Collection) filterWithIndex(wg *sync.WaitGroup, resultChannel chan Document, filters []MapInterface, start int, end int, filteredDocIds DocumentIds, scanned *atomic.Int64) {
 	defer wg.Done()
 
 	for i := start; i < end; i++ {
@@ -144,6 +200,7 @@ func (collection *Collection) filterWithIndex(wg *sync.WaitGroup, resultChannel
 		if !exists {
 			continue
 		}
+		scanned.Add(1)
 
 		var isMatch bool = IsMatchWithDocument(filters, document)
 
@@ -153,11 +210,12 @@ func (collection *Collection) filterWithIndex(wg *sync.WaitGroup, resultChannel
 	}
 }
 
-func (collection *Collection) filterWithoutIndex(wg *sync.WaitGroup, resultChannel chan Document, filters []MapInterface, start int, end int, allBatchIds []string) {
+func (collection *Collection) filterWithoutIndex(wg *sync.WaitGroup, resultChannel chan Document, filters []MapInterface, start int, end int, allBatchIds []string, scanned *atomic.Int64) {
 	defer wg.Done()
 
 	for i := start; i < end; i++ {
-		var batchDocuments = collection.DocumentsMap[allBatchIds[i]]
+		var batchDocuments = collection.residentBatch(allBatchIds[i])
+		scanned.Add(int64(len(batchDocuments)))
 
 		for _, document := range batchDocuments {
 			var isMatch bool = IsMatchWithDocument(filters, document)
@@ -188,6 +246,12 @@ func IsMatchWithDocument(filters []MapInterface, document Document) bool {
 }
 
 func (collection *Collection) GetfilteredIdsWithIndexkeys(filters []MapInterface) DocumentIds {
+	filteredIds, _ := collection.filteredIdsWithIndexKeys(filters)
+	return filteredIds
+}
+
+// filteredIdsWithIndexKeys intersects the ids of every index filter and returns the lookups in the order applied
+func (collection *Collection) filteredIdsWithIndexKeys(filters []MapInterface) (DocumentIds, []IndexLookup) {
 	filteredIndexMap := make(IndexMap)
 
 	for _, filter := range filters {
@@ -217,6 +281,7 @@ func (collection *Collection) GetfilteredIdsWithIndexkeys(filters []MapInterface
 	isNotStarted := false
 	resultIdsMap := make(map[string]bool)
 	filteredIds := make([]string, 0)
+	lookups := make([]IndexLookup, 0, len(filters))
 
 outerLoop:
 	for _, eachIndexMap := range filters {
@@ -233,6 +298,7 @@ outerLoop:
 						filteredIds = append(filteredIds, eachId)
 					}
 					isNotStarted = true
+					lookups = append(lookups, IndexLookup{IndexKey: keyToSearch, Ids: len(idsMap), Candidates: len(filteredIds)})
 				} else {
 					tempIdsMap := make(map[string]bool)
 					tempIds := make([]string, 0)
@@ -244,6 +310,8 @@ outerLoop:
 						}
 					}
 
+					lookups = append(lookups, IndexLookup{IndexKey: keyToSearch, Ids: len(idsMap), Candidates: len(tempIds)})
+
 					if len(tempIdsMap) == 0 {
 						break outerLoop
 					}
@@ -251,22 +319,20 @@ outerLoop:
 					resultIdsMap = tempIdsMap
 					filteredIds = tempIds
 				}
+			} else {
+				lookups = append(lookups, IndexLookup{IndexKey: keyToSearch})
 			}
 		}
 	}
-	return filteredIds
+	return filteredIds, lookups
 }
 
 func (collection *Collection) isDocumentExists(id string) (bool, string, Document) {
 	var document Document
-	var batchId string
-
-	for eachBatchId, documents := range collection.DocumentsMap {
+	var batchId, isPlaced = collection.documentBatchIds[id]
 
-		if value, exists := documents[id]; exists {
-			document = value
-			batchId = eachBatchId
-		}
+	if isPlaced {
+		document = collection.residentBatch(batchId)[id]
 	}
 
 	if _, exists := document[global_constants.DOC_ID]; !exists {
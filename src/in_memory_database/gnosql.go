@@ -1,127 +1,262 @@
 package in_memory_database
 
 import (
-	"fmt"
 	"gnosql/src/common"
+	"gnosql/src/config"
 	"gnosql/src/global_constants"
-	"path/filepath"
+	"gnosql/src/logging"
+	"gnosql/src/metrics"
+	"gnosql/src/raft"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
+// loggers of the subsystems this package logs for, their levels are set apart with logLevels
+var (
+	logger            = logging.Get(global_constants.LOG_DATABASE)
+	queryLogger       = logging.Get(global_constants.LOG_QUERY)
+	storageLogger     = logging.Get(global_constants.LOG_STORAGE)
+	replicationLogger = logging.Get(global_constants.LOG_REPLICATION)
+	webhookLogger     = logging.Get(global_constants.LOG_WEBHOOK)
+)
+
+// GnoSQL holds the databases of one data folder with the workers applying their changes, several can run in one process
 type GnoSQL struct {
-	Databases []*Database
+	Databases         []*Database // guarded by databasesMu, read it through GetAllDBs
+	Role              string
+	ReplicaStatus     *ReplicaStatus
+	Consensus         Consensus   // set when running in a raft replica group
+	ShardRouter       ShardRouter // set when running as a router in front of shards
+	Settings          *config.Settings
+	ReplicationLog    *ReplicationLog // changes applied on this node in order, replicas tail it
+	WebhookDispatcher *WebhookDispatcher
+	loader            loadTracker
+	databasesMu       sync.RWMutex
+
+	// Mutations hold the read side while applying and logging a change, CreateSnapshot holds the write side
+	// so that a snapshot and its sequence number always describe the same state
+	replicationGate sync.RWMutex
+
+	incomeRequests     *incomeRequestQueue
+	collectionChannels *CollectionChannel
+	storageEngines     map[string]StorageEngine // engine name: engine over the data folder
+	decodeSlots        chan struct{}            // bounds the batches decoded at once across every collection being loaded
+}
+
+// Consensus commits an entry to the replica group, it returns once the entry is applied on this node
+type Consensus interface {
+	Propose(entry ReplicationEntry) error
+	Stats() raft.NodeStats
 }
 
-func CreateGnoSQL() *GnoSQL {
+// CreateGnoSQL starts the workers of a GnoSQL over settings.DataPath, its databases are read by LoadAllDBs
+func CreateGnoSQL(settings *config.Settings) *GnoSQL {
 	gnoSQL := &GnoSQL{
-		Databases: make([]*Database, 0),
+		Databases:          make([]*Database, 0),
+		Role:               global_constants.ROLE_PRIMARY,
+		Settings:           settings,
+		ReplicationLog:     NewReplicationLog(global_constants.REPLICATION_LOG_SIZE),
+		WebhookDispatcher:  NewWebhookDispatcher(),
+		incomeRequests:     newIncomeRequestQueue(),
+		collectionChannels: NewCollectionChannel(),
+		storageEngines:     newStorageEngines(settings.DataPath),
+		decodeSlots:        make(chan struct{}, max(settings.LoadConcurrency, 1)),
 	}
+
+	go startWorkerWithRecovery(gnoSQL.StartIncomeRequestWorker)
+	go startWorkerWithRecovery(gnoSQL.WebhookDispatcher.StartChangeEventWorker)
+	go gnoSQL.StartTimerToSaveFile(settings.SyncInterval)
+
 	return gnoSQL
 }
 
-func (gnoSQL *GnoSQL) CreateDB(databaseName string, collectionsInput []CollectionInput) *Database {
-	var db *Database = CreateDatabase(databaseName, collectionsInput)
-	gnoSQL.Databases = append(gnoSQL.Databases, db)
-	return db
+func (gnoSQL *GnoSQL) IsReadOnly() bool {
+	return gnoSQL.Role == global_constants.ROLE_REPLICA
 }
 
-func (gnoSQL *GnoSQL) LoadDB(database DatabaseFileStruct) *Database {
-	var db *Database = LoadDatabase(database)
+// CreateDB creates a database with its collections, a database whose storage engine or compression can't be used
+// is logged and refused
+func (gnoSQL *GnoSQL) CreateDB(databaseName string, collectionsInput []CollectionInput, configInput DatabaseConfigInput) (*Database, error) {
+	db, err := CreateDatabase(databaseName, configInput, gnoSQL)
+	if err != nil {
+		logger.Error("database not created", "database", databaseName, "storageEngine", configInput.StorageEngine, "compression", configInput.Compression, "error", err)
+		return nil, err
+	}
+
+	gnoSQL.ReplicationLog.Append(ReplicationEntry{
+		DatabaseName:   databaseName,
+		Event:          Event{Type: global_constants.EVENT_CREATE_DATABASE},
+		DatabaseConfig: configInput,
+	})
+
+	gnoSQL.addDB(db)
+	db.CreateColls(collectionsInput)
+	return db, nil
+}
+
+func (gnoSQL *GnoSQL) LoadDB(database DatabaseFileStruct) (*Database, error) {
+	db, err := LoadDatabase(database, gnoSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	gnoSQL.addDB(db)
+	return db, nil
+}
+
+func (gnoSQL *GnoSQL) addDB(db *Database) {
+	gnoSQL.databasesMu.Lock()
+	defer gnoSQL.databasesMu.Unlock()
+
 	gnoSQL.Databases = append(gnoSQL.Databases, db)
-	return db
 }
 
 func (gnoSQL *GnoSQL) DeleteDB(db *Database) bool {
 	var databases []*Database = make([]*Database, 0)
+	var deletedDatabases []*Database = make([]*Database, 0)
 
+	gnoSQL.databasesMu.Lock()
 	for _, database := range gnoSQL.Databases {
 		if database.DatabaseName != db.DatabaseName {
 			databases = append(databases, database)
 		} else {
-			database.DeleteDatabase()
+			deletedDatabases = append(deletedDatabases, database)
 		}
 	}
-
 	gnoSQL.Databases = databases
+	gnoSQL.databasesMu.Unlock()
+
+	for _, database := range deletedDatabases {
+		database.DeleteDatabase()
+	}
+
+	gnoSQL.ReplicationLog.Append(ReplicationEntry{
+		DatabaseName: db.DatabaseName,
+		Event:        Event{Type: global_constants.EVENT_DELETE_DATABASE},
+	})
 
 	return true
 }
 
+// LoadAllDBs reads every database together with the documents of all its collections
 func (gnoSQL *GnoSQL) LoadAllDBs() {
+	gnoSQL.loadAllDBs(false)
+}
+
+// RegisterAllDBs reads the database files and lists their collections, the files of a collection
+// are read on its first access or by WarmUp so startup time doesn't grow with the data size
+func (gnoSQL *GnoSQL) RegisterAllDBs() {
+	gnoSQL.loadAllDBs(true)
+}
+
+func (gnoSQL *GnoSQL) loadAllDBs(isLazy bool) {
+	var startedAt = time.Now()
+
+	gnoSQL.SetReady(false)
+
 	// Read all database folder from gnosqlpath
-	databaseFolders, err := common.ReadFoldersInDirectory(global_constants.GNOSQL_FULL_PATH)
+	databaseFolders, err := common.ReadFoldersInDirectory(gnoSQL.Settings.DataPath)
 	if err != nil {
-		fmt.Println("Error while reading database folders", fmt.Sprintf("%v", err))
+		logger.Error("database folders read error", "error", err)
 	}
 
-	fmt.Printf("\n Loading databases ")
-	// Read database and all colelctions one by one
+	logger.Info("loading databases", "path", gnoSQL.Settings.DataPath)
+
+	var databases = make([]*Database, 0)
+	var collectionLoads = make([]*collectionLoad, 0)
+
+	// Database files are small and read one by one, the collections of all databases are read in parallel after
 	for _, eachDatabaseFolder := range databaseFolders {
 		fileNames, err := common.ReadFileNamesInDirectory(eachDatabaseFolder)
 		if err != nil {
-			fmt.Println("Error while reading collection files", fmt.Sprintf("%v", err))
+			logger.Error("database folder read error", "folder", eachDatabaseFolder, "error", err)
 		}
 
 		var db *Database
-		var collectionFileStructs []CollectionFileStruct
 
-		// filter fileName "-db.gob", "-collection.gob"
+		// filter fileName "-db.gob"
 		for _, fileName := range fileNames {
-			if strings.Contains(fileName, global_constants.DB_EXTENSION) {
+			if strings.HasSuffix(fileName, global_constants.DB_EXTENSION) {
 				if databaseGob, err := ReadDatabaseGobFile(fileName); err == nil {
-					db = gnoSQL.LoadDB(databaseGob)
+					if db, err = gnoSQL.LoadDB(databaseGob); err != nil {
+						logger.Error("database not loaded", "database", databaseGob.DatabaseName, "storageEngine", databaseGob.Config.storageEngineName(),
+							"compression", databaseGob.Config.compressionCodec(), "error", err)
+					}
 				}
 			}
 
 		}
 
-		collectionFolders, err := common.ReadFoldersInDirectory(eachDatabaseFolder)
+		if db == nil {
+			continue
+		}
+
+		collectionNames, err := db.storage.ListCollections(db.DatabaseName)
 
 		if err != nil {
-			fmt.Println("Error while reading collection folder files", fmt.Sprintf("%v", err))
+			logger.Error("collections read error", "database", db.DatabaseName, "error", err)
 		}
 
-		for _, eachCollectionFolder := range collectionFolders {
-			fileNames, err := common.ReadFileNamesInDirectory(eachCollectionFolder)
-			if err != nil {
-				fmt.Println("Error while reading collection files", fmt.Sprintf("%v", err))
-			}
+		if isLazy {
+			db.addColls(db.RegisterColls(collectionNames))
+			logger.Info("database registered", "database", db.DatabaseName, "collections", db.GetCollectionNames())
+			continue
+		}
 
-			var collectionFile CollectionFileStruct
-			var documentMaps DocumentsMap = make(DocumentsMap)
+		databases = append(databases, db)
+		for _, collectionName := range collectionNames {
+			collectionLoads = append(collectionLoads, &collectionLoad{db: db, collectionName: collectionName})
+		}
+	}
 
-			for _, fileName := range fileNames {
-				if strings.Contains(fileName, global_constants.COLLECTION_EXTENSION) {
-					if collectionGob, err := common.ReadFileAndDecodeGOB[CollectionFileStruct](fileName); err == nil {
-						collectionFile = collectionGob
-					}
-				}
-				if strings.Contains(fileName, global_constants.COLLECTION_BATCH_EXTENSION) {
-					if collectionDataGob, err := common.ReadFileAndDecodeGOB[BatchDocuments](fileName); err == nil {
-						var dataFileName = filepath.Base(fileName)
-						if strings.Contains(dataFileName, global_constants.COLLECTION_BATCH_EXTENSION) {
-							documentMaps[dataFileName] = collectionDataGob
-						}
-					}
-				}
+	gnoSQL.loader.start(len(collectionLoads))
+	gnoSQL.loadCollections(collectionLoads)
+
+	for _, db := range databases {
+		for _, load := range collectionLoads {
+			if load.db == db && load.collection != nil {
+				db.addColls([]*Collection{load.collection})
 			}
+		}
 
-			collectionFile.DocumentsMap = documentMaps
-			collectionFileStructs = append(collectionFileStructs, collectionFile)
+		db.cache.evict(nil, "")
+		logger.Info("database loaded", "database", db.DatabaseName, "collections", db.GetCollectionNames())
+	}
 
-		}
+	var progress = gnoSQL.GetLoadProgress()
+	logger.Info("all databases loaded", "collections", progress.LoadedCollections, "documents", progress.LoadedDocuments,
+		"bytes", progress.LoadedBytes, "took", time.Since(startedAt))
+
+	metrics.StartupLoadDuration.Set(time.Since(startedAt).Seconds())
+
+	gnoSQL.SetReady(true)
+}
 
-		db.Collections = db.LoadColls(collectionFileStructs)
-		fmt.Printf("\n\t Database Name : %v ", db.DatabaseName)
-		fmt.Printf("\n\t Collections Names : %v \n", db.GetCollectionNames())
+// WarmUp loads the collections registered by RegisterAllDBs one at a time, collections accessed meanwhile load on demand
+func (gnoSQL *GnoSQL) WarmUp() {
+	var startedAt = time.Now()
 
+	for _, database := range gnoSQL.GetAllDBs() {
+		for _, collection := range database.GetAllColls() {
+			collection.EnsureLoaded()
+		}
 	}
-	fmt.Printf("\n ----- All databases loaded ----- \n")
 
+	logger.Info("all collections warmed up", "took", time.Since(startedAt))
+}
+
+// GetAllDBs returns the databases at the time of the call, databases created or deleted meanwhile are not in it
+func (gnoSQL *GnoSQL) GetAllDBs() []*Database {
+	gnoSQL.databasesMu.RLock()
+	defer gnoSQL.databasesMu.RUnlock()
+
+	return slices.Clone(gnoSQL.Databases)
 }
 
 func (gnoSQL *GnoSQL) GetDB(databaseName string) *Database {
-	for _, database := range gnoSQL.Databases {
+	for _, database := range gnoSQL.GetAllDBs() {
 		if database.DatabaseName == databaseName {
 			return database
 		}
@@ -130,10 +265,14 @@ func (gnoSQL *GnoSQL) GetDB(databaseName string) *Database {
 }
 
 func (gnoSQL *GnoSQL) GetDatabaseAndCollection(databaseName string, collectionName string) (*Database, *Collection) {
-	for _, database := range gnoSQL.Databases {
+	for _, database := range gnoSQL.GetAllDBs() {
 		if database.DatabaseName == databaseName {
-			for _, collection := range database.Collections {
+			for _, collection := range database.GetAllColls() {
 				if collection.CollectionName == collectionName {
+					// a collection that can't be loaded is treated as missing
+					if err := collection.EnsureLoaded(); err != nil {
+						return database, nil
+					}
 					return database, collection
 				}
 			}
@@ -143,11 +282,43 @@ func (gnoSQL *GnoSQL) GetDatabaseAndCollection(databaseName string, collectionNa
 	return nil, nil
 }
 
+// Shutdown hands every queued request to the mutation workers, then saves every database and collection through
+// them and waits for the file writes. Call it once the servers stopped taking requests
+func (gnoSQL *GnoSQL) Shutdown() {
+	var startedAt = time.Now()
+
+	gnoSQL.DrainIncomingRequests()
+
+	var wg sync.WaitGroup
+
+	for _, database := range gnoSQL.GetAllDBs() {
+		database.SaveDatabaseToFile()
+
+		for _, collection := range database.GetAllColls() {
+			wg.Add(1)
+			go func(collection *Collection) {
+				defer wg.Done()
+				collection.Flush()
+			}(collection)
+		}
+	}
+
+	wg.Wait()
+
+	for _, database := range gnoSQL.GetAllDBs() {
+		if err := database.storage.Close(database.DatabaseName); err != nil {
+			storageLogger.Error("database close error", "database", database.DatabaseName, "error", err)
+		}
+	}
+
+	logger.Info("all collections written to disk", "took", time.Since(startedAt))
+}
+
 func (gnoSQL *GnoSQL) WriteAllDBs() {
-	for _, database := range gnoSQL.Databases {
+	for _, database := range gnoSQL.GetAllDBs() {
 		database.SaveDatabaseToFile()
 
-		for _, collection := range database.Collections {
+		for _, collection := range database.GetAllColls() {
 			collection.SaveCollectionToFile()
 		}
 	}
@@ -1,8 +1,10 @@
 package in_memory_database
 
 import (
-	"fmt"
+	"context"
 	"gnosql/src/global_constants"
+	"gnosql/src/logging"
+	"sync"
 	"time"
 )
 
@@ -10,25 +12,70 @@ type IncomeRequest struct {
 	DatabaseName   string
 	CollectionName string
 	Event          Event
+	drained        chan struct{} // closed by the worker, every request before it is in its collection channel
 }
 
-var IncomeRequestChannel chan IncomeRequest = make(chan IncomeRequest, global_constants.INCOME_REQUEST_CHANNEL_SIZE)
+// incomeRequestQueue hands the requests of a GnoSQL in order to the mutation workers of their collections
+type incomeRequestQueue struct {
+	channel  chan IncomeRequest
+	queued   sync.WaitGroup // requests handed to QueueIncomingRequests and not in channel yet
+	mu       sync.Mutex     // guards isClosed, so queued.Add never runs once DrainIncomingRequests is waiting
+	isClosed bool
+}
 
-func init() {
-	go InitializeWorker()
+func newIncomeRequestQueue() *incomeRequestQueue {
+	return &incomeRequestQueue{channel: make(chan IncomeRequest, global_constants.INCOME_REQUEST_CHANNEL_SIZE)}
 }
 
-func AddIncomingRequest(databaseName string, collectionName string, event Event) {
+func (gnoSQL *GnoSQL) AddIncomingRequest(databaseName string, collectionName string, event Event) {
 	incomingRequest := IncomeRequest{
 		DatabaseName:   databaseName,
 		CollectionName: collectionName,
 		Event:          event,
 	}
-	IncomeRequestChannel <- incomingRequest
+	gnoSQL.incomeRequests.channel <- incomingRequest
+}
+
+// QueueIncomingRequests adds events of a collection in order without making the caller wait for room in the
+// channel, DrainIncomingRequests waits for them. The mutation worker logs them with the request id of ctx.
+// Events queued after DrainIncomingRequests are refused, the collections are being written to disk for shutdown
+func (gnoSQL *GnoSQL) QueueIncomingRequests(ctx context.Context, databaseName string, collectionName string, events ...Event) {
+	var requestId = logging.RequestId(ctx)
+	var queue = gnoSQL.incomeRequests
+
+	queue.mu.Lock()
+	if queue.isClosed {
+		queue.mu.Unlock()
+		logger.WarnContext(ctx, "request queued after shutdown started, dropped", "database", databaseName, "collection", collectionName, "events", len(events))
+		return
+	}
+	queue.queued.Add(1)
+	queue.mu.Unlock()
+
+	go func() {
+		defer queue.queued.Done()
+
+		for _, event := range events {
+			event.requestId = requestId
+			gnoSQL.AddIncomingRequest(databaseName, collectionName, event)
+		}
+	}()
 }
 
-func InitializeWorker() {
-	go startWorkerWithRecovery(StartIncomeRequestWorker)
+// DrainIncomingRequests stops QueueIncomingRequests from taking requests and returns once every request queued before
+// the call was handed to its collection channel
+func (gnoSQL *GnoSQL) DrainIncomingRequests() {
+	var queue = gnoSQL.incomeRequests
+
+	queue.mu.Lock()
+	queue.isClosed = true
+	queue.mu.Unlock()
+
+	queue.queued.Wait()
+
+	var drained = make(chan struct{})
+	queue.channel <- IncomeRequest{drained: drained}
+	<-drained
 }
 
 func startWorkerWithRecovery(workerFunc func()) {
@@ -36,7 +83,7 @@ func startWorkerWithRecovery(workerFunc func()) {
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					fmt.Printf("Worker panic recovered: %v. Restarting worker...\n", r)
+					logger.Error("worker panic recovered, restarting the worker", "panic", r)
 				}
 			}()
 
@@ -48,9 +95,13 @@ func startWorkerWithRecovery(workerFunc func()) {
 	}
 }
 
-func StartIncomeRequestWorker() {
+func (gnoSQL *GnoSQL) StartIncomeRequestWorker() {
 	for {
-		incomeRequest := <-IncomeRequestChannel
-		CollectionChannelInstance.AddCollectionEvent(incomeRequest.DatabaseName, incomeRequest.CollectionName, incomeRequest.Event)
+		incomeRequest := <-gnoSQL.incomeRequests.channel
+		if incomeRequest.drained != nil {
+			close(incomeRequest.drained)
+			continue
+		}
+		gnoSQL.collectionChannels.AddCollectionEvent(incomeRequest.DatabaseName, incomeRequest.CollectionName, incomeRequest.Event)
 	}
 }
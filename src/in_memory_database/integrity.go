@@ -0,0 +1,425 @@
+package in_memory_database
+
+import (
+	"fmt"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+type IntegrityOptions struct {
+	DataPath   string
+	Repair     bool // rewrite collection files with the rebuilt index, remove leftover temp files, add missing checksums
+	Quarantine bool // move unreadable and orphaned files to the quarantine folder
+}
+
+type IntegrityIssue struct {
+	Path    string `json:"path"`
+	Problem string `json:"problem"`
+	Action  string `json:"action"` // empty when the issue is only reported
+}
+
+type IntegrityReport struct {
+	Databases   int              `json:"databases"`
+	Collections int              `json:"collections"`
+	Documents   int              `json:"documents"`
+	Issues      []IntegrityIssue `json:"issues"`
+}
+
+// Unresolved returns the number of issues that were reported but not fixed
+func (report IntegrityReport) Unresolved() int {
+	var count = 0
+	for _, issue := range report.Issues {
+		if issue.Action == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Log logs the totals of the check, then every issue found with what was done about it
+func (report IntegrityReport) Log() {
+	logger.Info("integrity check", "databases", report.Databases, "collections", report.Collections,
+		"documents", report.Documents, "issues", len(report.Issues))
+
+	for _, issue := range report.Issues {
+		var action = issue.Action
+		if action == "" {
+			action = "not fixed"
+		}
+		storageLogger.Warn(issue.Problem, "file", issue.Path, "action", action)
+	}
+}
+
+func QuarantineFolderPath(dataPath string) string {
+	return dataPath + "-quarantine"
+}
+
+type integrityCheck struct {
+	options IntegrityOptions
+	report  IntegrityReport
+}
+
+// CheckIntegrity verifies the database files under options.DataPath, it must run before the databases are loaded.
+// Every collection's IndexMap and LastIndex are rebuilt from its batch files and compared with the collection file.
+func CheckIntegrity(options IntegrityOptions) IntegrityReport {
+	check := &integrityCheck{options: options, report: IntegrityReport{Issues: make([]IntegrityIssue, 0)}}
+
+	databaseFolders, err := common.ReadFoldersInDirectory(options.DataPath)
+	if err != nil {
+		check.addIssue(options.DataPath, fmt.Sprintf("unreadable folder: %v", err), "")
+		return check.report
+	}
+
+	for _, databaseFolder := range databaseFolders {
+		check.checkDatabase(databaseFolder)
+	}
+
+	return check.report
+}
+
+func (check *integrityCheck) addIssue(path string, problem string, action string) {
+	check.report.Issues = append(check.report.Issues, IntegrityIssue{Path: path, Problem: problem, Action: action})
+}
+
+// readGobFile reads a gob file and reports it when it has no checksum, repair rewrites it with one
+func (check *integrityCheck) readGobFile(keyFolder string, filePath string) ([]byte, error) {
+	gobData, isLegacy, err := common.ReadLegacyGobFile(keyFolder, filePath)
+
+	if err == nil && isLegacy {
+		var action = ""
+		if check.options.Repair && common.SaveGobFile(keyFolder, filePath, gobData) == nil {
+			action = "checksum added"
+		}
+		check.addIssue(filePath, "file without a checksum, written before checksums were added", action)
+	}
+
+	return gobData, err
+}
+
+func readAndDecodeGobFile[T any](check *integrityCheck, keyFolder string, filePath string) (T, error) {
+	var data T
+
+	gobData, err := check.readGobFile(keyFolder, filePath)
+	if err == nil {
+		gobData, err = common.DecompressGob(gobData)
+	}
+	if err == nil {
+		err = common.DecodeGob(gobData, &data)
+	}
+
+	return data, err
+}
+
+func (check *integrityCheck) checkDatabase(databaseFolder string) {
+	var databaseName = filepath.Base(databaseFolder)
+	var databaseFilePath = filepath.Join(databaseFolder, common.GetDatabaseFileName(databaseName))
+
+	check.report.Databases++
+
+	fileNames, _ := common.ReadFileNamesInDirectory(databaseFolder)
+	check.checkTempFiles(fileNames)
+
+	databaseFile, err := readAndDecodeGobFile[DatabaseFileStruct](check, databaseFolder, databaseFilePath)
+	if err != nil {
+		var actions = make([]string, 0)
+
+		if check.options.Quarantine {
+			actions = append(actions, check.quarantine(databaseFilePath))
+		}
+		if check.options.Repair {
+			if gobData, err := common.EncodeGob(DatabaseFileStruct{DatabaseName: databaseName, Config: make(Config)}); err == nil {
+				if common.SaveGobFile(databaseFolder, databaseFilePath, gobData) == nil {
+					actions = append(actions, "recreated without config")
+				}
+			}
+		}
+
+		check.addIssue(databaseFilePath, fmt.Sprintf("unreadable database file: %v", err), strings.Join(actions, ", "))
+	}
+
+	// other engines keep collections in their own files and check them when they are opened
+	if err == nil && databaseFile.Config.storageEngineName() != global_constants.STORAGE_ENGINE_GOB {
+		return
+	}
+
+	collectionFolders, _ := common.ReadFoldersInDirectory(databaseFolder)
+
+	for _, collectionFolder := range collectionFolders {
+		check.checkCollection(databaseName, collectionFolder)
+	}
+}
+
+func (check *integrityCheck) checkTempFiles(fileNames []string) {
+	for _, fileName := range fileNames {
+		if !strings.HasSuffix(fileName, global_constants.TEMP_FILE_EXTENSION) {
+			continue
+		}
+
+		var action = ""
+		if check.options.Repair && os.Remove(fileName) == nil {
+			action = "removed"
+		}
+		check.addIssue(fileName, "leftover temp file of an interrupted write", action)
+	}
+}
+
+func (check *integrityCheck) checkCollection(databaseName string, collectionFolder string) {
+	var collectionName = filepath.Base(collectionFolder)
+	var collectionFilePath = filepath.Join(collectionFolder, common.GetCollectionFileName(collectionName))
+	var keyFolder = common.GetDatabaseFolderPath(check.options.DataPath, databaseName)
+	var isChanged = false
+	var isCollectionFileReadable = true
+
+	check.report.Collections++
+
+	fileNames, _ := common.ReadFileNamesInDirectory(collectionFolder)
+	check.checkTempFiles(fileNames)
+
+	collectionFile, err := readAndDecodeGobFile[CollectionFileStruct](check, keyFolder, collectionFilePath)
+	if err != nil {
+		var actions = make([]string, 0)
+
+		if check.options.Quarantine {
+			actions = append(actions, check.quarantine(collectionFilePath))
+		}
+		if check.options.Repair {
+			actions = append(actions, "recreated without index keys")
+		}
+		check.addIssue(collectionFilePath, fmt.Sprintf("unreadable collection file: %v", err), strings.Join(actions, ", "))
+
+		collectionFile = CollectionFileStruct{}
+		isChanged = true
+		isCollectionFileReadable = false
+	}
+
+	collectionFile.CollectionName = collectionName
+	collectionFile.DatabaseName = databaseName
+
+	// collection files written before checksums were added only list batches in BatchUpdateStatus
+	var referencedBatchIds = make(map[string]bool)
+	for batchId := range collectionFile.BatchChecksums {
+		referencedBatchIds[batchId] = true
+	}
+	if len(referencedBatchIds) == 0 {
+		for batchId := range collectionFile.BatchUpdateStatus {
+			referencedBatchIds[batchId] = true
+		}
+	}
+
+	var documentsMap = make(DocumentsMap)
+	var batchChecksums = make(BatchChecksums)
+	var unreadableBatchIds = make(map[string]bool)
+
+	for _, fileName := range fileNames {
+		if !strings.HasSuffix(fileName, global_constants.COLLECTION_BATCH_EXTENSION) {
+			continue
+		}
+
+		var batchId = filepath.Base(fileName)
+
+		batchGobData, err := check.readGobFile(keyFolder, fileName)
+		if err == nil {
+			batchGobData, err = common.DecompressGob(batchGobData)
+		}
+
+		var batchDocuments BatchDocuments
+		if err == nil {
+			err = common.DecodeGob(batchGobData, &batchDocuments)
+		}
+
+		if err != nil {
+			var action = ""
+			if check.options.Quarantine {
+				action = check.quarantine(fileName)
+			}
+			check.addIssue(fileName, fmt.Sprintf("unreadable batch file: %v", err), action)
+			unreadableBatchIds[batchId] = true
+			continue
+		}
+
+		// without a collection file every batch would look orphaned, they are all kept.
+		// An orphan is left by a checkpoint or merge that was cut short, it is ignored on load as its changes are in the segments
+		if isCollectionFileReadable && !referencedBatchIds[batchId] {
+			var action = ""
+			if check.options.Quarantine {
+				action = check.quarantine(fileName)
+			} else if check.options.Repair && os.Remove(fileName) == nil {
+				action = "removed"
+			}
+			check.addIssue(fileName, "orphaned batch file, not referenced by the collection file", action)
+			continue
+		}
+
+		documentsMap[batchId] = batchDocuments
+		batchChecksums[batchId] = common.Checksum(batchGobData)
+	}
+
+	for batchId := range referencedBatchIds {
+		if _, exists := documentsMap[batchId]; !exists && !unreadableBatchIds[batchId] && collectionFile.BatchChecksums[batchId] != 0 {
+			check.addIssue(filepath.Join(collectionFolder, batchId), "batch file referenced by the collection file is missing", "")
+		}
+	}
+
+	// rebuild the index the same way the collection maintains it
+	collection := &Collection{
+		IndexKeys:      collectionFile.IndexKeys,
+		DocumentsMap:   documentsMap,
+		LastIndex:      collectionFile.LastIndex,
+		CurrentBatchId: collectionFile.CurrentBatchId,
+	}
+	collection.RebuildIndex()
+	collection.rebuildPlacement()
+
+	if !isIndexEqual(collectionFile.IndexMap, collection.IndexMap) {
+		var action = ""
+		if check.options.Repair {
+			action = "rebuilt"
+		}
+		check.addIssue(collectionFilePath, "IndexMap doesn't match the documents", action)
+		isChanged = true
+	}
+
+	if collection.LastIndex != collectionFile.LastIndex {
+		var action = ""
+		if check.options.Repair {
+			action = "rebuilt"
+		}
+		check.addIssue(collectionFilePath, fmt.Sprintf("LastIndex %v is lower than the highest docIndex %v", collectionFile.LastIndex, collection.LastIndex), action)
+		isChanged = true
+	}
+
+	if !isBatchChecksumsMatching(collectionFile.BatchChecksums, batchChecksums) {
+		isChanged = true
+	}
+
+	if isChanged && check.options.Repair {
+		check.rewriteCollectionFile(collectionFilePath, collectionFile, collection, batchChecksums)
+	}
+
+	// documents changed after the checkpoint are counted too
+	collection.BatchUpdateStatus = make(BatchUpdateStatus)
+	for _, segment := range check.readSegments(databaseName, collectionName, fileNames, collectionFile.CompactedSegmentSeq) {
+		collection.applySegment(segment)
+	}
+
+	for _, documents := range collection.DocumentsMap {
+		check.report.Documents += len(documents)
+	}
+}
+
+// readSegments reads the segment files newer than the checkpoint, segments already folded into the batch files are removed on repair
+func (check *integrityCheck) readSegments(databaseName string, collectionName string, fileNames []string, compactedSegmentSeq uint64) []Segment {
+	var segmentsGobData = make(map[string][]byte)
+
+	for _, fileName := range fileNames {
+		if !strings.HasSuffix(fileName, global_constants.COLLECTION_SEGMENT_EXTENSION) {
+			continue
+		}
+
+		segmentGobData, err := check.readGobFile(common.GetDatabaseFolderPath(check.options.DataPath, databaseName), fileName)
+
+		var segment Segment
+		if err == nil {
+			err = common.DecodeGob(segmentGobData, &segment)
+		}
+
+		if err != nil {
+			var action = ""
+			if check.options.Quarantine {
+				action = check.quarantine(fileName)
+			}
+			check.addIssue(fileName, fmt.Sprintf("unreadable segment file: %v", err), action)
+			continue
+		}
+
+		if segment.Seq <= compactedSegmentSeq {
+			var action = ""
+			if check.options.Repair && os.Remove(fileName) == nil {
+				action = "removed"
+			}
+			check.addIssue(fileName, "segment file already folded into the batch files", action)
+			continue
+		}
+
+		segmentsGobData[filepath.Base(fileName)] = segmentGobData
+	}
+
+	segments, _ := decodeSegments(databaseName, collectionName, segmentsGobData, compactedSegmentSeq)
+	return segments
+}
+
+// rewriteCollectionFile writes the collection file with the index rebuilt from the batch files
+func (check *integrityCheck) rewriteCollectionFile(collectionFilePath string, collectionFile CollectionFileStruct, collection *Collection, batchChecksums BatchChecksums) {
+	var documentsMap = collection.DocumentsMap
+
+	if collectionFile.CurrentBatchId == "" || documentsMap[collectionFile.CurrentBatchId] == nil {
+		// the current batch is gone, new documents start a new batch
+		collectionFile.CurrentBatchId = common.GetCollectionBatchIdFileName()
+	}
+
+	var batchUpdateStatus = make(BatchUpdateStatus)
+	for batchId := range documentsMap {
+		batchUpdateStatus[batchId] = false
+	}
+
+	collectionFile.IndexMap = collection.IndexMap
+	collectionFile.LastIndex = collection.LastIndex
+	collectionFile.CurrentBatchCount = len(documentsMap[collectionFile.CurrentBatchId])
+	collectionFile.BatchUpdateStatus = batchUpdateStatus
+	collectionFile.BatchChecksums = batchChecksums
+	collectionFile.DocumentsMap = nil
+
+	gobData, err := common.EncodeGob(collectionFile)
+	if err == nil {
+		err = common.SaveGobFile(common.GetDatabaseFolderPath(check.options.DataPath, collectionFile.DatabaseName), collectionFilePath, gobData)
+	}
+	if err != nil {
+		check.addIssue(collectionFilePath, fmt.Sprintf("collection file can't be rewritten: %v", err), "")
+	}
+}
+
+// quarantine moves filePath to the same relative path under the quarantine folder and describes the outcome
+func (check *integrityCheck) quarantine(filePath string) string {
+	relativePath, err := filepath.Rel(check.options.DataPath, filePath)
+	if err != nil {
+		return fmt.Sprintf("quarantine failed: %v", err)
+	}
+
+	var quarantinePath = filepath.Join(QuarantineFolderPath(check.options.DataPath), relativePath)
+
+	if err := os.MkdirAll(filepath.Dir(quarantinePath), 0755); err != nil {
+		return fmt.Sprintf("quarantine failed: %v", err)
+	}
+	if err := os.Rename(filePath, quarantinePath); err != nil {
+		return fmt.Sprintf("quarantine failed: %v", err)
+	}
+
+	return "moved to " + quarantinePath
+}
+
+// isIndexEqual compares two IndexMaps ignoring index values left without ids
+func isIndexEqual(indexMap IndexMap, otherIndexMap IndexMap) bool {
+	return reflect.DeepEqual(compactIndex(indexMap), compactIndex(otherIndexMap))
+}
+
+func compactIndex(indexMap IndexMap) IndexMap {
+	var compacted = make(IndexMap)
+
+	for indexKey, indexIdsMap := range indexMap {
+		for indexValue, ids := range indexIdsMap {
+			if len(ids) == 0 {
+				continue
+			}
+			if _, exists := compacted[indexKey]; !exists {
+				compacted[indexKey] = make(IndexIdsmap)
+			}
+			compacted[indexKey][indexValue] = ids
+		}
+	}
+
+	return compacted
+}
@@ -0,0 +1,117 @@
+package in_memory_database
+
+import (
+	"gnosql/src/common"
+	"gnosql/src/metrics"
+	"sync"
+	"time"
+)
+
+// LoadProgress counts what LoadAllDBs has read so far, Ready turns true once every collection is loaded
+type LoadProgress struct {
+	Ready             bool   `json:"ready"`
+	TotalCollections  int    `json:"totalCollections"`
+	LoadedCollections int    `json:"loadedCollections"`
+	LoadedDocuments   int    `json:"loadedDocuments"`
+	LoadedBytes       int64  `json:"loadedBytes"`
+	StartedAt         string `json:"startedAt,omitempty"`
+	FinishedAt        string `json:"finishedAt,omitempty"`
+}
+
+type loadTracker struct {
+	mu       sync.Mutex
+	progress LoadProgress
+}
+
+// collectionLoad is a collection to read during startup, collections are kept in the order they were listed
+type collectionLoad struct {
+	db             *Database
+	collectionName string
+	collection     *Collection // nil when its files couldn't be read
+}
+
+// GetLoadProgress returns the progress of the databases loaded on start
+func (gnoSQL *GnoSQL) GetLoadProgress() LoadProgress {
+	gnoSQL.loader.mu.Lock()
+	defer gnoSQL.loader.mu.Unlock()
+
+	return gnoSQL.loader.progress
+}
+
+// SetReady marks the node as ready to serve, or not while its databases are being replaced
+func (gnoSQL *GnoSQL) SetReady(isReady bool) {
+	gnoSQL.loader.mu.Lock()
+	defer gnoSQL.loader.mu.Unlock()
+
+	gnoSQL.loader.progress.Ready = isReady
+	if isReady {
+		gnoSQL.loader.progress.FinishedAt = common.TimeToString(time.Now())
+	}
+}
+
+func (tracker *loadTracker) start(totalCollections int) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.progress = LoadProgress{TotalCollections: totalCollections, StartedAt: common.TimeToString(time.Now())}
+}
+
+// loaded adds a collection to the progress and returns how many are loaded so far
+func (tracker *loadTracker) loaded(documents int, bytes int64) int {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.progress.LoadedCollections++
+	tracker.progress.LoadedDocuments += documents
+	tracker.progress.LoadedBytes += bytes
+
+	return tracker.progress.LoadedCollections
+}
+
+// loadCollections reads the collections with up to LoadConcurrency of them at once, each one logs its progress
+func (gnoSQL *GnoSQL) loadCollections(collectionLoads []*collectionLoad) {
+	var pending = make(chan *collectionLoad)
+	var wg sync.WaitGroup
+
+	for i := 0; i < cap(gnoSQL.decodeSlots); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for load := range pending {
+				gnoSQL.loadCollection(load, len(collectionLoads))
+			}
+		}()
+	}
+
+	for _, load := range collectionLoads {
+		pending <- load
+	}
+	close(pending)
+
+	wg.Wait()
+}
+
+func (gnoSQL *GnoSQL) loadCollection(load *collectionLoad, totalCollections int) {
+	var startedAt = time.Now()
+
+	collectionFile, err := gnoSQL.LoadCollectionFile(load.db.storage, load.db.DatabaseName, load.collectionName)
+	if err != nil {
+		logger.Error("collection read error", "database", load.db.DatabaseName, "collection", load.collectionName, "error", err)
+		return
+	}
+
+	load.collection = RegisterCollection(load.collectionName, load.db)
+	load.collection.load(collectionFile)
+	load.db.cache.evict(nil, "")
+
+	// counted after the segments are replayed
+	stats := load.collection.Stats()
+
+	loadedCollections := gnoSQL.loader.loaded(stats.Documents, collectionFile.loadedBytes)
+
+	metrics.CollectionLoadDuration.WithLabelValues(load.db.DatabaseName, load.collectionName).Set(time.Since(startedAt).Seconds())
+
+	logger.Info("collection loaded", "loaded", loadedCollections, "total", totalCollections, "database", load.db.DatabaseName,
+		"collection", load.collectionName, "batches", stats.ResidentBatches, "documents", stats.Documents,
+		"bytes", collectionFile.loadedBytes, "took", time.Since(startedAt))
+}
@@ -0,0 +1,111 @@
+package in_memory_database
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	incomeRequestQueueDesc = prometheus.NewDesc("gnosql_income_request_queue_length",
+		"Requests waiting in the income request queue.", nil, nil)
+	incomeRequestCapacityDesc = prometheus.NewDesc("gnosql_income_request_queue_capacity",
+		"Size of the income request queue.", nil, nil)
+	collectionQueueDesc = prometheus.NewDesc("gnosql_collection_queue_length",
+		"Events waiting in the channel of a collection for its mutation worker.", []string{"database", "collection"}, nil)
+	collectionCapacityDesc = prometheus.NewDesc("gnosql_collection_queue_capacity",
+		"Size of the channel of a collection.", []string{"database", "collection"}, nil)
+	documentsDesc = prometheus.NewDesc("gnosql_collection_documents",
+		"Documents in a loaded collection.", []string{"database", "collection"}, nil)
+	batchesDesc = prometheus.NewDesc("gnosql_collection_batches",
+		"Batches of a loaded collection by state, resident in memory or evicted to their batch files.", []string{"database", "collection", "state"}, nil)
+	indexEntriesDesc = prometheus.NewDesc("gnosql_index_entries",
+		"Document ids held by an index of a loaded collection.", []string{"database", "collection", "index_key"}, nil)
+	indexValuesDesc = prometheus.NewDesc("gnosql_index_values",
+		"Distinct values held by an index of a loaded collection.", []string{"database", "collection", "index_key"}, nil)
+)
+
+// collectionMetrics is what a scrape reads of a loaded collection
+type collectionMetrics struct {
+	documents       int
+	residentBatches int
+	evictedBatches  int
+	indexEntries    map[string]int // indexKey: document ids
+	indexValues     map[string]int // indexKey: distinct values
+}
+
+type metricsCollector struct {
+	gnoSQL *GnoSQL
+}
+
+// MetricsCollector reports queue depths and the sizes of the collections, read on every scrape
+func (gnoSQL *GnoSQL) MetricsCollector() prometheus.Collector {
+	return metricsCollector{gnoSQL: gnoSQL}
+}
+
+func (collector metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- incomeRequestQueueDesc
+	ch <- incomeRequestCapacityDesc
+	ch <- collectionQueueDesc
+	ch <- collectionCapacityDesc
+	ch <- documentsDesc
+	ch <- batchesDesc
+	ch <- indexEntriesDesc
+	ch <- indexValuesDesc
+}
+
+func (collector metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(incomeRequestQueueDesc, prometheus.GaugeValue, float64(len(collector.gnoSQL.incomeRequests.channel)))
+	ch <- prometheus.MustNewConstMetric(incomeRequestCapacityDesc, prometheus.GaugeValue, float64(cap(collector.gnoSQL.incomeRequests.channel)))
+
+	for _, database := range collector.gnoSQL.GetAllDBs() {
+		for _, collection := range database.GetAllColls() {
+			// lazily loaded collections have no worker nor documents until their first access
+			if !collection.isLoaded.Load() {
+				continue
+			}
+
+			var labels = []string{database.DatabaseName, collection.CollectionName}
+
+			ch <- prometheus.MustNewConstMetric(collectionQueueDesc, prometheus.GaugeValue, float64(len(collection.channel)), labels...)
+			ch <- prometheus.MustNewConstMetric(collectionCapacityDesc, prometheus.GaugeValue, float64(cap(collection.channel)), labels...)
+
+			snapshot := collection.metricsSnapshot()
+
+			ch <- prometheus.MustNewConstMetric(documentsDesc, prometheus.GaugeValue, float64(snapshot.documents), labels...)
+			ch <- prometheus.MustNewConstMetric(batchesDesc, prometheus.GaugeValue, float64(snapshot.residentBatches), append(labels, "resident")...)
+			ch <- prometheus.MustNewConstMetric(batchesDesc, prometheus.GaugeValue, float64(snapshot.evictedBatches), append(labels, "evicted")...)
+
+			for indexKey, entries := range snapshot.indexEntries {
+				ch <- prometheus.MustNewConstMetric(indexEntriesDesc, prometheus.GaugeValue, float64(entries), append(labels, indexKey)...)
+				ch <- prometheus.MustNewConstMetric(indexValuesDesc, prometheus.GaugeValue, float64(snapshot.indexValues[indexKey]), append(labels, indexKey)...)
+			}
+		}
+	}
+}
+
+// metricsSnapshot counts documents, batches and index entries without touching the files, unlike Stats
+func (collection *Collection) metricsSnapshot() collectionMetrics {
+	collection.mu.RLock()
+	defer collection.mu.RUnlock()
+
+	var snapshot = collectionMetrics{
+		documents:    len(collection.documentBatchIds),
+		indexEntries: make(map[string]int),
+		indexValues:  make(map[string]int),
+	}
+
+	collection.cacheMu.Lock()
+	snapshot.residentBatches = len(collection.DocumentsMap)
+	snapshot.evictedBatches = len(collection.evictedBatchIds)
+	collection.cacheMu.Unlock()
+
+	for _, indexKey := range collection.IndexKeys {
+		var entries = 0
+		for _, ids := range collection.IndexMap[indexKey] {
+			entries += len(ids)
+		}
+		snapshot.indexEntries[indexKey] = entries
+		snapshot.indexValues[indexKey] = len(collection.IndexMap[indexKey])
+	}
+
+	return snapshot
+}
@@ -0,0 +1,318 @@
+package in_memory_database
+
+import (
+	"encoding/gob"
+	"fmt"
+	"gnosql/src/global_constants"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// QueryCache keeps the results of recent filters of a collection within its memory cap, the least recently used are
+// dropped once over it. An entry is dropped when the mutation worker changes a document it matched before or after
+// the change. Sizes are estimated from the documents.
+type QueryCache struct {
+	mu            sync.Mutex
+	budget        int64 // bytes
+	clock         uint64
+	entries       map[string]*queryCacheEntry           // normalized filter: entry
+	byValue       map[string]map[string]map[string]bool // filter key: value: normalized filters, one key per entry
+	matchAll      map[string]bool                       // normalized filters without a key, every change drops them
+	bytes         int64
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+type queryCacheEntry struct {
+	documents []Document
+	filters   []MapInterface
+	size      int64
+	lastUsed  uint64
+}
+
+func init() {
+	gob.Register(map[string]int{})
+}
+
+func NewQueryCache(budget int64) *QueryCache {
+	return &QueryCache{
+		budget:   budget,
+		entries:  make(map[string]*queryCacheEntry),
+		byValue:  make(map[string]map[string]map[string]bool),
+		matchAll: make(map[string]bool),
+	}
+}
+
+// queryCacheBudget returns the cap in bytes of the query cache of a collection named in Config, 0 when it has none
+func (config Config) queryCacheBudget(collectionName string) int64 {
+	if queryCaches, ok := config[global_constants.CONFIG_QUERY_CACHES].(map[string]int); ok && queryCaches[collectionName] > 0 {
+		return int64(queryCaches[collectionName]) * 1024 * 1024
+	}
+	return 0
+}
+
+// queryCacheBudget returns the cap in bytes of the query cache of a collection, 0 when it has none
+func (db *Database) queryCacheBudget(collectionName string) int64 {
+	db.configMu.RLock()
+	defer db.configMu.RUnlock()
+
+	return db.Config.queryCacheBudget(collectionName)
+}
+
+func (db *Database) hasQueryCaches() bool {
+	db.configMu.RLock()
+	defer db.configMu.RUnlock()
+
+	return db.Config[global_constants.CONFIG_QUERY_CACHES] != nil
+}
+
+// setQueryCacheMB keeps the cap of the query cache of a collection in Config, 0 removes it
+func (db *Database) setQueryCacheMB(collectionName string, queryCacheMB int) {
+	db.configMu.Lock()
+	defer db.configMu.Unlock()
+
+	// a new map, files being saved or snapshots being taken keep the caps they read
+	queryCaches, _ := db.Config[global_constants.CONFIG_QUERY_CACHES].(map[string]int)
+	queryCaches = maps.Clone(queryCaches)
+	if queryCaches == nil {
+		queryCaches = make(map[string]int)
+	}
+
+	if queryCacheMB > 0 {
+		queryCaches[collectionName] = queryCacheMB
+	} else {
+		delete(queryCaches, collectionName)
+	}
+
+	if len(queryCaches) == 0 {
+		delete(db.Config, global_constants.CONFIG_QUERY_CACHES)
+		return
+	}
+	db.Config[global_constants.CONFIG_QUERY_CACHES] = queryCaches
+}
+
+// SetQueryCache caps the query cache of a collection and keeps the cap in Config, 0 turns the cache off. A new cap
+// starts an empty cache with its counters at zero
+func (db *Database) SetQueryCache(collection *Collection, queryCacheMB int) {
+	db.setQueryCacheMB(collection.CollectionName, queryCacheMB)
+	db.SaveDatabaseToFile()
+
+	var queryCache *QueryCache
+	if budget := db.queryCacheBudget(collection.CollectionName); budget > 0 {
+		queryCache = NewQueryCache(budget)
+	}
+
+	collection.mu.Lock()
+	collection.queryCache = queryCache
+	collection.mu.Unlock()
+}
+
+// queryCacheKey normalizes a filter, the same keys and values in any order with the same limit give the same key.
+// Values are compared as text by IsMatchWithDocument, so 30 and "30" give the same key too
+func queryCacheKey(filters []MapInterface, limit int) string {
+	var pairs = make([]string, 0, len(filters)+1)
+
+	for _, filter := range filters {
+		pairs = append(pairs, strconv.Quote(filter[global_constants.FILTER_KEY].(string))+"="+strconv.Quote(fmt.Sprintf("%v", filter[global_constants.FILTER_VALUE])))
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(append(pairs, global_constants.FILTER_LIMIT+"="+strconv.Itoa(limit)), ",")
+}
+
+// get returns the cached documents of a filter, counting a hit or a miss
+func (cache *QueryCache) get(key string) ([]Document, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, exists := cache.entries[key]
+	if !exists {
+		cache.misses.Add(1)
+		return nil, false
+	}
+
+	cache.hits.Add(1)
+	cache.clock++
+	entry.lastUsed = cache.clock
+
+	return slices.Clone(entry.documents), true
+}
+
+// put caches the documents of a filter, results larger than the whole cap are not cached. The caller holds the read
+// side of collection.mu since the filter ran, so no change was applied in between
+func (cache *QueryCache) put(key string, filters []MapInterface, documents []Document) {
+	if cache == nil {
+		return
+	}
+
+	var size = int64(len(key))
+	for _, document := range documents {
+		size += estimateSize(map[string]interface{}(document))
+	}
+	if size > cache.budget {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if _, exists := cache.entries[key]; exists {
+		cache.remove(key)
+	}
+
+	cache.clock++
+	cache.entries[key] = &queryCacheEntry{documents: slices.Clone(documents), filters: filters, size: size, lastUsed: cache.clock}
+	cache.bytes += size
+
+	if len(filters) == 0 {
+		cache.matchAll[key] = true
+	} else {
+		// a document matching the filter has the value of any of its keys, the first one is enough to find the entry
+		filterKey, value := filters[0][global_constants.FILTER_KEY].(string), fmt.Sprintf("%v", filters[0][global_constants.FILTER_VALUE])
+
+		if _, exists := cache.byValue[filterKey]; !exists {
+			cache.byValue[filterKey] = make(map[string]map[string]bool)
+		}
+		if _, exists := cache.byValue[filterKey][value]; !exists {
+			cache.byValue[filterKey][value] = make(map[string]bool)
+		}
+		cache.byValue[filterKey][value][key] = true
+	}
+
+	cache.evict()
+}
+
+// invalidate drops the entries matching a document before or after a change, before is nil for a create and after
+// is nil for a delete. Caller must hold collection.mu
+func (cache *QueryCache) invalidate(before Document, after Document) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	var candidates = make(map[string]bool)
+	for key := range cache.matchAll {
+		candidates[key] = true
+	}
+
+	for _, document := range []Document{before, after} {
+		if document == nil {
+			continue
+		}
+		for filterKey, values := range cache.byValue {
+			if value, exists := document[filterKey]; exists {
+				for key := range values[fmt.Sprintf("%v", value)] {
+					candidates[key] = true
+				}
+			}
+		}
+	}
+
+	for key := range candidates {
+		var entry = cache.entries[key]
+
+		if (before != nil && IsMatchWithDocument(entry.filters, before)) || (after != nil && IsMatchWithDocument(entry.filters, after)) {
+			cache.remove(key)
+			cache.invalidations.Add(1)
+		}
+	}
+}
+
+// clear drops every entry, the counters are kept
+func (cache *QueryCache) clear() {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries = make(map[string]*queryCacheEntry)
+	cache.byValue = make(map[string]map[string]map[string]bool)
+	cache.matchAll = make(map[string]bool)
+	cache.bytes = 0
+}
+
+// stats returns the entries and their estimated size in bytes
+func (cache *QueryCache) stats() (int, int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return len(cache.entries), cache.bytes
+}
+
+// evict drops least recently used entries until the cache is within its cap, caller must hold cache.mu
+func (cache *QueryCache) evict() {
+	if cache.bytes <= cache.budget {
+		return
+	}
+
+	var keys = make([]string, 0, len(cache.entries))
+	for key := range cache.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return cache.entries[keys[i]].lastUsed < cache.entries[keys[j]].lastUsed })
+
+	for _, key := range keys {
+		if cache.bytes <= cache.budget {
+			return
+		}
+		cache.remove(key)
+	}
+}
+
+// remove drops an entry, caller must hold cache.mu
+func (cache *QueryCache) remove(key string) {
+	var entry = cache.entries[key]
+
+	cache.bytes -= entry.size
+	delete(cache.entries, key)
+
+	if len(entry.filters) == 0 {
+		delete(cache.matchAll, key)
+		return
+	}
+
+	filterKey, value := entry.filters[0][global_constants.FILTER_KEY].(string), fmt.Sprintf("%v", entry.filters[0][global_constants.FILTER_VALUE])
+
+	delete(cache.byValue[filterKey][value], key)
+	if len(cache.byValue[filterKey][value]) == 0 {
+		delete(cache.byValue[filterKey], value)
+	}
+	if len(cache.byValue[filterKey]) == 0 {
+		delete(cache.byValue, filterKey)
+	}
+}
+
+// estimateSize approximates the memory held by a decoded JSON value
+func estimateSize(value interface{}) int64 {
+	switch typedValue := value.(type) {
+	case string:
+		return int64(16 + len(typedValue))
+	case map[string]interface{}:
+		var size int64 = 48
+		for key, each := range typedValue {
+			size += int64(16+len(key)) + estimateSize(each)
+		}
+		return size
+	case []interface{}:
+		var size int64 = 24
+		for _, each := range typedValue {
+			size += estimateSize(each)
+		}
+		return size
+	}
+	return 16
+}
@@ -0,0 +1,74 @@
+package in_memory_database
+
+import (
+	"context"
+	"fmt"
+	"gnosql/src/global_constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+// QueryPlan tells how a filter ran, returned with explain and logged for slow queries. It holds no filter values
+type QueryPlan struct {
+	Shape            string        `json:"shape"`        // filter keys without their values, Ex: {city:?,limit:10}
+	Strategy         string        `json:"strategy"`     // index when an index narrowed the candidates, scan when every batch was read
+	IndexLookups     []IndexLookup `json:"indexLookups"` // in the order applied, the smallest first
+	ScanFilters      []string      `json:"scanFilters"`  // keys compared on every candidate document
+	Candidates       int           `json:"candidates"`   // ids left by the index lookups
+	BatchesScanned   int           `json:"batchesScanned"`
+	DocumentsScanned int64         `json:"documentsScanned"`
+	Matched          int           `json:"matched"`
+	Returned         int           `json:"returned"`
+	Limit            int           `json:"limit"`
+	Stages           []QueryStage  `json:"stages"`
+	Took             string        `json:"took"`
+}
+
+// IndexLookup is one index filter, Ids found under its value and Candidates left after intersecting them with the
+// lookups before it
+type IndexLookup struct {
+	IndexKey   string `json:"indexKey"`
+	Ids        int    `json:"ids"`
+	Candidates int    `json:"candidates"`
+}
+
+type QueryStage struct {
+	Name string `json:"name"`
+	Took string `json:"took"`
+}
+
+// endStage records the stage started at startedAt and returns the start of the next one
+func (plan *QueryPlan) endStage(name string, startedAt time.Time) time.Time {
+	now := time.Now()
+	plan.Stages = append(plan.Stages, QueryStage{Name: name, Took: now.Sub(startedAt).String()})
+	return now
+}
+
+// logQuery logs the plan of every filter at debug, and at warn once it took longer than slowQueryThreshold
+func (collection *Collection) logQuery(ctx context.Context, plan QueryPlan, took time.Duration) {
+	var attributes = []any{"database", collection.DatabaseName, "collection", collection.CollectionName, "took", took, "plan", plan}
+
+	if threshold := collection.gnoSQL.Settings.SlowQueryThreshold; threshold > 0 && took >= threshold {
+		queryLogger.WarnContext(ctx, "slow query", attributes...)
+		return
+	}
+
+	queryLogger.DebugContext(ctx, "filter", attributes...)
+}
+
+// queryShape lists the keys of a filter with their values left out, the limit is kept. Ex: {city:?,limit:10}
+func queryShape(reqFilter MapInterface) string {
+	var keys = make([]string, 0, len(reqFilter))
+
+	for key, value := range reqFilter {
+		if key == global_constants.FILTER_LIMIT {
+			keys = append(keys, fmt.Sprintf("%v:%v", key, value))
+		} else {
+			keys = append(keys, key+":?")
+		}
+	}
+	sort.Strings(keys)
+
+	return "{" + strings.Join(keys, ",") + "}"
+}
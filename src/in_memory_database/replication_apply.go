@@ -0,0 +1,342 @@
+package in_memory_database
+
+import (
+	"fmt"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/raft"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ReplicaStatus struct {
+	LeaderAddress string
+	Connected     bool
+	LogId         string
+	LeaderSeq     uint64
+	ReplicatedSeq uint64
+	LagMillis     int64
+	LastContactAt time.Time
+	mu            sync.RWMutex
+}
+
+type ReplicationStats struct {
+	Role          string `json:"role"`
+	LogId         string `json:"logId"`
+	LastSeq       uint64 `json:"lastSeq"`
+	LeaderAddress string `json:"leaderAddress,omitempty"`
+	Connected     bool   `json:"connected"`
+	LeaderSeq     uint64 `json:"leaderSeq"`
+	ReplicatedSeq uint64 `json:"replicatedSeq"`
+	LagEntries    uint64 `json:"lagEntries"`
+	LagMillis     int64  `json:"lagMillis"`
+	LastContactAt string `json:"lastContactAt,omitempty"`
+
+	Raft *raft.NodeStats `json:"raft,omitempty"`
+}
+
+func (gnoSQL *GnoSQL) StartAsReplica(leaderAddress string) {
+	gnoSQL.Role = global_constants.ROLE_REPLICA
+	gnoSQL.ReplicaStatus = &ReplicaStatus{LeaderAddress: leaderAddress}
+
+	// the primary delivers webhooks, replicas would only send duplicates
+	gnoSQL.WebhookDispatcher.SetEnabled(false)
+}
+
+func (status *ReplicaStatus) SetConnected(isConnected bool) {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	status.Connected = isConnected
+}
+
+func (status *ReplicaStatus) Bootstrapped(logId string, seq uint64) {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	status.LogId = logId
+	status.LeaderSeq = seq
+	status.ReplicatedSeq = seq
+	status.LagMillis = 0
+	status.LastContactAt = time.Now()
+}
+
+// Contact records the primary's latest sequence, received with every entry and heartbeat
+func (status *ReplicaStatus) Contact(leaderSeq uint64) {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	status.LeaderSeq = leaderSeq
+	status.LastContactAt = time.Now()
+
+	if status.ReplicatedSeq >= leaderSeq {
+		status.LagMillis = 0
+	}
+}
+
+func (status *ReplicaStatus) Replicated(entry ReplicationEntry) {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	status.ReplicatedSeq = entry.Seq
+	status.LagMillis = time.Since(entry.CreatedAt).Milliseconds()
+}
+
+func (status *ReplicaStatus) GetReplicatedSeq() (string, uint64) {
+	status.mu.RLock()
+	defer status.mu.RUnlock()
+
+	return status.LogId, status.ReplicatedSeq
+}
+
+func (gnoSQL *GnoSQL) GetReplicationStats() ReplicationStats {
+	stats := ReplicationStats{
+		Role:    gnoSQL.Role,
+		LogId:   gnoSQL.ReplicationLog.LogId,
+		LastSeq: gnoSQL.ReplicationLog.LastSeq(),
+	}
+
+	if status := gnoSQL.ReplicaStatus; status != nil {
+		status.mu.RLock()
+		defer status.mu.RUnlock()
+
+		stats.LeaderAddress = status.LeaderAddress
+		stats.Connected = status.Connected
+		stats.LogId = status.LogId
+		stats.LeaderSeq = status.LeaderSeq
+		stats.ReplicatedSeq = status.ReplicatedSeq
+		stats.LagMillis = status.LagMillis
+
+		if status.LeaderSeq > status.ReplicatedSeq {
+			stats.LagEntries = status.LeaderSeq - status.ReplicatedSeq
+		}
+		if !status.LastContactAt.IsZero() {
+			stats.LastContactAt = common.TimeToString(status.LastContactAt)
+		}
+	}
+
+	if gnoSQL.Consensus != nil {
+		raftStats := gnoSQL.Consensus.Stats()
+		stats.Raft = &raftStats
+	}
+
+	return stats
+}
+
+// CreateSnapshot encodes every database in the same layout as the gob files on disk.
+// All mutations are paused while encoding, the returned sequence is the last change included in the snapshot.
+func (gnoSQL *GnoSQL) CreateSnapshot() (uint64, []SnapshotFile, error) {
+	gnoSQL.replicationGate.Lock()
+	defer gnoSQL.replicationGate.Unlock()
+
+	var seq = gnoSQL.ReplicationLog.LastSeq()
+	var snapshotFiles = make([]SnapshotFile, 0)
+
+	for _, db := range gnoSQL.GetAllDBs() {
+		databaseFiles, err := db.snapshotFiles()
+		if err != nil {
+			return seq, nil, err
+		}
+		snapshotFiles = append(snapshotFiles, databaseFiles...)
+	}
+
+	return seq, snapshotFiles, nil
+}
+
+// snapshotFiles encodes the database and all its collections in the same layout as the gob files on disk
+func (db *Database) snapshotFiles() ([]SnapshotFile, error) {
+	var snapshotFiles = make([]SnapshotFile, 0)
+
+	databaseGobData, err := common.EncodeGob(db.databaseFile())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotFiles = append(snapshotFiles, SnapshotFile{
+		Path: filepath.Join(db.DatabaseName, common.GetDatabaseFileName(db.DatabaseName)),
+		Data: databaseGobData,
+	})
+
+	for _, collection := range db.GetAllColls() {
+		collectionFiles, err := collection.snapshotFiles()
+		if err != nil {
+			return nil, err
+		}
+		snapshotFiles = append(snapshotFiles, collectionFiles...)
+	}
+
+	return snapshotFiles, nil
+}
+
+func (collection *Collection) snapshotFiles() ([]SnapshotFile, error) {
+	if err := collection.EnsureLoaded(); err != nil {
+		return nil, err
+	}
+
+	collection.mu.RLock()
+	defer collection.mu.RUnlock()
+
+	var collectionFolder = filepath.Join(collection.DatabaseName, collection.CollectionName)
+	var snapshotFiles = make([]SnapshotFile, 0)
+	var batchChecksums = make(BatchChecksums)
+
+	for _, batchId := range collection.batchIds() {
+		collection.cacheMu.Lock()
+		documents, isResident := collection.DocumentsMap[batchId]
+		collection.cacheMu.Unlock()
+
+		var batchGobData []byte
+		var err error
+
+		// an evicted batch is unchanged since its batch file was written, the file is copied without decoding
+		if isResident {
+			batchGobData, err = common.EncodeGob(documents)
+		} else {
+			batchGobData, err = collection.loadBatchGobData(batchId)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		batchChecksums[batchId] = common.Checksum(batchGobData)
+		snapshotFiles = append(snapshotFiles, SnapshotFile{
+			Path: filepath.Join(collectionFolder, batchId),
+			Data: batchGobData,
+		})
+	}
+
+	// checksums of the batches in this snapshot, not of the ones last saved to disk
+	collectionFileStruct := collection.toCollectionFileStruct()
+	collectionFileStruct.BatchChecksums = batchChecksums
+
+	collectionGobData, err := common.EncodeGob(collectionFileStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotFiles = append(snapshotFiles, SnapshotFile{
+		Path: filepath.Join(collectionFolder, common.GetCollectionFileName(collection.CollectionName)),
+		Data: collectionGobData,
+	})
+
+	return snapshotFiles, nil
+}
+
+// WriteSnapshotFiles stores snapshot files under dataPath through the storage engine named in each database file,
+// the database file comes before the files of its collections and batches come before their collection file
+func WriteSnapshotFiles(dataPath string, snapshotFiles []SnapshotFile) error {
+	var registeredEngines = newStorageEngines(dataPath)
+	var storageEngines = make(map[string]StorageEngine)
+
+	// the databases are loaded later with the engines of their GnoSQL, the files opened here are released
+	defer func() {
+		for databaseName, storageEngine := range storageEngines {
+			storageEngine.Close(databaseName)
+		}
+	}()
+
+	for _, snapshotFile := range snapshotFiles {
+		parts := strings.Split(snapshotFile.Path, string(filepath.Separator))
+
+		if len(parts) == 2 && strings.HasSuffix(parts[1], global_constants.DB_EXTENSION) {
+			var databaseFile DatabaseFileStruct
+			if err := common.DecodeGob(snapshotFile.Data, &databaseFile); err != nil {
+				return err
+			}
+
+			storageEngine, err := newDatabaseStorage(registeredEngines, databaseFile.Config)
+			if err != nil {
+				return err
+			}
+			storageEngines[parts[0]] = storageEngine
+
+			var databaseFolder = common.GetDatabaseFolderPath(dataPath, parts[0])
+			if err := common.SaveGobFile(databaseFolder, filepath.Join(dataPath, snapshotFile.Path), snapshotFile.Data); err != nil {
+				return err
+			}
+			continue
+		}
+
+		storageEngine, exists := storageEngines[parts[0]]
+		if len(parts) != 3 || !exists {
+			return fmt.Errorf("unexpected snapshot file %v", snapshotFile.Path)
+		}
+
+		var err error
+		if parts[2] == common.GetCollectionFileName(parts[1]) {
+			err = storageEngine.PersistMetadata(parts[0], parts[1], snapshotFile.Data)
+		} else {
+			err = storageEngine.PersistBatch(parts[0], parts[1], parts[2], snapshotFile.Data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResetAllDBs deletes every database and waits for their collection workers to stop,
+// it is used by a replica before installing a new snapshot
+func (gnoSQL *GnoSQL) ResetAllDBs() {
+	var collections = make([]*Collection, 0)
+
+	for _, db := range gnoSQL.GetAllDBs() {
+		collections = append(collections, db.GetAllColls()...)
+		gnoSQL.DeleteDB(db)
+	}
+
+	for _, collection := range collections {
+		collection.WaitForWorkerStop()
+	}
+
+	databaseFolders, _ := common.ReadFoldersInDirectory(gnoSQL.Settings.DataPath)
+	for _, databaseFolder := range databaseFolders {
+		common.DeleteFolder(databaseFolder)
+	}
+}
+
+// ApplyReplicationEntry replays an entry of the primary's log or a committed entry of the raft log.
+// Entries are applied synchronously and in order, schema entries are idempotent.
+func (gnoSQL *GnoSQL) ApplyReplicationEntry(entry ReplicationEntry) {
+	var db = gnoSQL.GetDB(entry.DatabaseName)
+
+	switch entry.Event.Type {
+	case global_constants.EVENT_CREATE_DATABASE:
+		if db == nil {
+			// CreateDB logs a database it refuses
+			gnoSQL.CreateDB(entry.DatabaseName, entry.CollectionsInput, entry.DatabaseConfig)
+		} else {
+			db.CreateColls(entry.CollectionsInput)
+		}
+	case global_constants.EVENT_DELETE_DATABASE:
+		if db != nil {
+			gnoSQL.DeleteDB(db)
+		}
+	case global_constants.EVENT_CREATE_COLLECTIONS:
+		if db != nil {
+			db.CreateColls(entry.CollectionsInput)
+		}
+	case global_constants.EVENT_DELETE_COLLECTIONS:
+		if db != nil {
+			db.DeleteColls(entry.CollectionNames)
+		}
+	case global_constants.EVENT_CREATE_WEBHOOK:
+		if db != nil {
+			db.DeleteWebhook(entry.Webhook.Id)
+			db.AddWebhook(entry.Webhook)
+		}
+	case global_constants.EVENT_DELETE_WEBHOOK:
+		if db != nil {
+			db.DeleteWebhook(entry.Event.Id)
+		}
+	default:
+		if _, collection := gnoSQL.GetDatabaseAndCollection(entry.DatabaseName, entry.CollectionName); collection != nil {
+			collection.applyEvent(entry.Event)
+		} else {
+			replicationLogger.Warn("entry skipped, collection not found", "seq", entry.Seq, "database", entry.DatabaseName, "collection", entry.CollectionName)
+		}
+	}
+}
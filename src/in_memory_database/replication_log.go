@@ -0,0 +1,111 @@
+package in_memory_database
+
+import (
+	"errors"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"sync"
+	"time"
+)
+
+type ReplicationEntry struct {
+	Seq              uint64
+	DatabaseName     string
+	CollectionName   string
+	Event            Event
+	CollectionsInput []CollectionInput   // EVENT_CREATE_DATABASE, EVENT_CREATE_COLLECTIONS
+	DatabaseConfig   DatabaseConfigInput // EVENT_CREATE_DATABASE
+	CollectionNames  []string            // EVENT_DELETE_COLLECTIONS
+	Webhook          Webhook             // EVENT_CREATE_WEBHOOK, EVENT_DELETE_WEBHOOK uses Event.Id
+	CreatedAt        time.Time
+}
+
+// ReplicationLog keeps the latest applied mutations in order, replicas tail it after bootstrapping from a snapshot
+type ReplicationLog struct {
+	LogId   string // changes on every start, so replicas can detect a restarted primary
+	entries []ReplicationEntry
+	lastSeq uint64
+	notify  chan struct{}
+	mu      sync.RWMutex
+}
+
+type SnapshotFile struct {
+	Path string // relative to the data folder
+	Data []byte
+}
+
+var (
+	ErrReplicationLogTruncated = errors.New(global_constants.REPLICATION_LOG_TRUNCATED_MSG)
+)
+
+func NewReplicationLog(size int) *ReplicationLog {
+	return &ReplicationLog{
+		LogId:   common.Generate16DigitUUID(),
+		entries: make([]ReplicationEntry, size),
+		notify:  make(chan struct{}),
+	}
+}
+
+func (rl *ReplicationLog) Append(entry ReplicationEntry) uint64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.lastSeq++
+	entry.Seq = rl.lastSeq
+	entry.CreatedAt = time.Now()
+	rl.entries[(rl.lastSeq-1)%uint64(len(rl.entries))] = entry
+
+	// wake up every replica stream waiting for new entries
+	close(rl.notify)
+	rl.notify = make(chan struct{})
+
+	return entry.Seq
+}
+
+func (rl *ReplicationLog) LastSeq() uint64 {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	return rl.lastSeq
+}
+
+// ReadFrom returns up to limit entries starting at fromSeq, and a channel closed on the next append
+func (rl *ReplicationLog) ReadFrom(fromSeq uint64, limit int) ([]ReplicationEntry, <-chan struct{}, error) {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	var size = uint64(len(rl.entries))
+	var firstSeq uint64 = 1
+
+	if rl.lastSeq > size {
+		firstSeq = rl.lastSeq - size + 1
+	}
+
+	if fromSeq < firstSeq || fromSeq > rl.lastSeq+1 {
+		return nil, rl.notify, ErrReplicationLogTruncated
+	}
+
+	entries := make([]ReplicationEntry, 0)
+
+	for seq := fromSeq; seq <= rl.lastSeq && len(entries) < limit; seq++ {
+		entries = append(entries, rl.entries[(seq-1)%size])
+	}
+
+	return entries, rl.notify, nil
+}
+
+func (collection *Collection) logMutation(event Event) {
+	collection.gnoSQL.ReplicationLog.Append(ReplicationEntry{
+		DatabaseName:   collection.DatabaseName,
+		CollectionName: collection.CollectionName,
+		Event:          event,
+	})
+}
+
+func copyDocument(document Document) Document {
+	var copiedDocument = make(Document)
+	for key, value := range document {
+		copiedDocument[key] = value
+	}
+	return copiedDocument
+}
@@ -0,0 +1,36 @@
+package in_memory_database
+
+type Shard struct {
+	Id      string `json:"id"`
+	Address string `json:"address"` // gRPC address of the shard
+}
+
+// ShardMap lists the shards behind a router and the shard key of every collection created through it.
+// A document is stored on shard hash(value of the shard key) % len(Shards).
+type ShardMap struct {
+	Shards    []Shard           `json:"shards"`
+	ShardKeys map[string]string `json:"shardKeys"` // Ex: { "databaseName/collectionName": "city" }
+}
+
+// ShardRouter serves the database API of a router node by forwarding requests to its shards
+type ShardRouter interface {
+	ConnectDatabase(databaseName string, collectionsInput []CollectionInput) (DatabaseResult, error)
+	CreateDatabase(databaseName string, collectionsInput []CollectionInput, configInput DatabaseConfigInput) error
+	DeleteDatabase(databaseName string) error
+	GetAllDatabases() ([]string, error)
+	LoadToDisk() error
+
+	CreateCollections(databaseName string, collectionsInput []CollectionInput) error
+	DeleteCollections(databaseName string, collectionNames []string) error
+	GetAllCollections(databaseName string) ([]string, error)
+	GetCollectionStats(databaseName string, collectionName string) (CollectionStats, error)
+
+	DocumentCreate(databaseName string, collectionName string, document Document) (Document, error)
+	DocumentRead(databaseName string, collectionName string, id string) (Document, error)
+	DocumentFilter(databaseName string, collectionName string, filter MapInterface) ([]Document, error)
+	DocumentUpdate(databaseName string, collectionName string, id string, document Document) (Document, error)
+	DocumentDelete(databaseName string, collectionName string, id string) error
+	DocumentGetAll(databaseName string, collectionName string) ([]Document, error)
+
+	GetShardMap() ShardMap
+}
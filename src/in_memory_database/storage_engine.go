@@ -0,0 +1,245 @@
+package in_memory_database
+
+import (
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// StorageEngine persists the collections of a database, a collection is stored as its collection file (metadata), its batches
+// and the segments holding the document changes made since the batches were last written.
+// Data is passed gob encoded, so every engine stores the same bytes and batch checksums mean the same thing.
+// The database file holding Config is always a gob file, it names the engine of the database.
+type StorageEngine interface {
+	ListCollections(databaseName string) ([]string, error)
+	LoadCollection(databaseName string, collectionName string) (StoredCollection, error)
+	LoadBatch(databaseName string, collectionName string, batchId string) ([]byte, error)
+	PersistBatch(databaseName string, collectionName string, batchId string, data []byte) error
+	PersistMetadata(databaseName string, collectionName string, data []byte) error
+	PersistSegment(databaseName string, collectionName string, segmentId string, data []byte) error
+	DeleteSegments(databaseName string, collectionName string, segmentIds []string) error
+	DeleteBatches(databaseName string, collectionName string, batchIds []string) error
+	DeleteCollection(databaseName string, collectionName string) error
+	Close(databaseName string) error // releases open files before the database folder is deleted
+}
+
+// Reencrypter is implemented by engines keeping sealed values inside their own files, a key rotation has them rewrite
+// the values not sealed with the active data key before the previous keys are dropped
+type Reencrypter interface {
+	Reencrypt(databaseName string) (int, error)
+}
+
+type StoredCollection struct {
+	Metadata []byte            // nil if the collection file is unreadable
+	Batches  map[string][]byte // batchId: batch gob data
+	Segments map[string][]byte // segmentId: segment gob data
+}
+
+// StorageEngines create the engines a database can be stored with, every GnoSQL has its own over its data folder
+var StorageEngines = map[string]func(dataPath string) StorageEngine{
+	global_constants.STORAGE_ENGINE_GOB: func(dataPath string) StorageEngine { return &GobStorageEngine{dataPath: dataPath} },
+	global_constants.STORAGE_ENGINE_KV:  func(dataPath string) StorageEngine { return NewKVStorageEngine(dataPath) },
+}
+
+// GetStorageEngine returns the engine registered as name, the gob engine when name is empty
+func GetStorageEngine(name string) (func(dataPath string) StorageEngine, bool) {
+	if name == "" {
+		name = global_constants.STORAGE_ENGINE_GOB
+	}
+	newStorageEngine, exists := StorageEngines[name]
+	return newStorageEngine, exists
+}
+
+// newStorageEngines creates every registered engine over dataPath
+func newStorageEngines(dataPath string) map[string]StorageEngine {
+	var storageEngines = make(map[string]StorageEngine)
+	for name, newStorageEngine := range StorageEngines {
+		storageEngines[name] = newStorageEngine(dataPath)
+	}
+	return storageEngines
+}
+
+// storageEngineName returns the engine named in Config, databases created before engines were added use gob files
+func (config Config) storageEngineName() string {
+	if name, ok := config[global_constants.CONFIG_STORAGE_ENGINE].(string); ok && name != "" {
+		return name
+	}
+	return global_constants.STORAGE_ENGINE_GOB
+}
+
+// LoadCollectionFile reads a collection through its storage engine and decodes it,
+// checksums of the batches read are kept to detect a save that was cut short
+func (gnoSQL *GnoSQL) LoadCollectionFile(storageEngine StorageEngine, databaseName string, collectionName string) (CollectionFileStruct, error) {
+	var collectionFile CollectionFileStruct
+
+	storedCollection, err := storageEngine.LoadCollection(databaseName, collectionName)
+	if err != nil {
+		return collectionFile, err
+	}
+
+	if storedCollection.Metadata != nil {
+		if err := common.DecodeGob(storedCollection.Metadata, &collectionFile); err != nil {
+			storageLogger.Error("collection file decoding error", "database", databaseName, "collection", collectionName, "error", err)
+		}
+	}
+
+	// an unreadable collection file still loads the documents, the index is rebuilt from them
+	collectionFile.CollectionName = collectionName
+	collectionFile.DatabaseName = databaseName
+	collectionFile.DocumentsMap = make(DocumentsMap)
+	collectionFile.loadedBatchChecksums = make(BatchChecksums)
+	collectionFile.loadedBatchSizes = make(map[string]int64)
+
+	collectionFile.loadedBytes = int64(len(storedCollection.Metadata))
+
+	var wg sync.WaitGroup
+	var decodedMu sync.Mutex
+
+	for batchId, batchGobData := range storedCollection.Batches {
+		// a batch the collection file doesn't list was written by a checkpoint or merge that was cut short,
+		// its changes are still in the segments. Collection files written before checksums were added list none.
+		if _, exists := collectionFile.BatchChecksums[batchId]; len(collectionFile.BatchChecksums) > 0 && !exists {
+			collectionFile.obsoleteBatchIds = append(collectionFile.obsoleteBatchIds, batchId)
+			continue
+		}
+
+		gnoSQL.decodeSlots <- struct{}{}
+		wg.Add(1)
+
+		go func(batchId string, batchGobData []byte) {
+			defer wg.Done()
+			defer func() { <-gnoSQL.decodeSlots }()
+
+			var batchDocuments BatchDocuments
+
+			if err := common.DecodeGob(batchGobData, &batchDocuments); err != nil {
+				storageLogger.Error("batch decoding error", "database", databaseName, "collection", collectionName, "batch", batchId, "error", err)
+				return
+			}
+
+			var checksum = common.Checksum(batchGobData)
+
+			decodedMu.Lock()
+			defer decodedMu.Unlock()
+
+			collectionFile.DocumentsMap[batchId] = batchDocuments
+			collectionFile.loadedBatchChecksums[batchId] = checksum
+			collectionFile.loadedBatchSizes[batchId] = int64(len(batchGobData))
+			collectionFile.loadedBytes += int64(len(batchGobData))
+		}(batchId, batchGobData)
+	}
+
+	wg.Wait()
+
+	for _, segmentGobData := range storedCollection.Segments {
+		collectionFile.loadedBytes += int64(len(segmentGobData))
+	}
+
+	collectionFile.loadedSegments, collectionFile.loadedSegmentIds = decodeSegments(databaseName, collectionName, storedCollection.Segments, collectionFile.CompactedSegmentSeq)
+
+	return collectionFile, nil
+}
+
+// GobStorageEngine keeps every collection in its own folder, the collection file and each batch are separate gob files
+type GobStorageEngine struct {
+	dataPath string
+}
+
+// keyFolder returns the folder whose data key seals the files of a database
+func (engine *GobStorageEngine) keyFolder(databaseName string) string {
+	return common.GetDatabaseFolderPath(engine.dataPath, databaseName)
+}
+
+func (engine *GobStorageEngine) ListCollections(databaseName string) ([]string, error) {
+	collectionFolders, err := common.ReadFoldersInDirectory(common.GetDatabaseFolderPath(engine.dataPath, databaseName))
+	if err != nil {
+		return nil, err
+	}
+
+	var collectionNames = make([]string, 0)
+	for _, collectionFolder := range collectionFolders {
+		collectionNames = append(collectionNames, filepath.Base(collectionFolder))
+	}
+	return collectionNames, nil
+}
+
+func (engine *GobStorageEngine) LoadCollection(databaseName string, collectionName string) (StoredCollection, error) {
+	var storedCollection = StoredCollection{Batches: make(map[string][]byte), Segments: make(map[string][]byte)}
+
+	fileNames, err := common.ReadFileNamesInDirectory(common.GetCollectionFolderPath(engine.dataPath, databaseName, collectionName))
+	if err != nil {
+		return storedCollection, err
+	}
+
+	for _, fileName := range fileNames {
+		if strings.HasSuffix(fileName, global_constants.COLLECTION_EXTENSION) {
+			if collectionGobData, err := common.ReadGobFile(engine.keyFolder(databaseName), fileName); err == nil {
+				storedCollection.Metadata = collectionGobData
+			} else {
+				storageLogger.Error("read error", "file", fileName, "error", err)
+			}
+		}
+		if strings.HasSuffix(fileName, global_constants.COLLECTION_BATCH_EXTENSION) {
+			if batchGobData, err := common.ReadGobFile(engine.keyFolder(databaseName), fileName); err == nil {
+				storedCollection.Batches[filepath.Base(fileName)] = batchGobData
+			} else {
+				storageLogger.Error("read error", "file", fileName, "error", err)
+			}
+		}
+		if strings.HasSuffix(fileName, global_constants.COLLECTION_SEGMENT_EXTENSION) {
+			if segmentGobData, err := common.ReadGobFile(engine.keyFolder(databaseName), fileName); err == nil {
+				storedCollection.Segments[filepath.Base(fileName)] = segmentGobData
+			} else {
+				storageLogger.Error("read error", "file", fileName, "error", err)
+			}
+		}
+	}
+
+	return storedCollection, nil
+}
+
+func (engine *GobStorageEngine) LoadBatch(databaseName string, collectionName string, batchId string) ([]byte, error) {
+	return common.ReadGobFile(engine.keyFolder(databaseName), common.GetCollectionFilePath(engine.dataPath, databaseName, collectionName, batchId))
+}
+
+func (engine *GobStorageEngine) PersistBatch(databaseName string, collectionName string, batchId string, data []byte) error {
+	return common.SaveGobFile(engine.keyFolder(databaseName), common.GetCollectionFilePath(engine.dataPath, databaseName, collectionName, batchId), data)
+}
+
+func (engine *GobStorageEngine) PersistMetadata(databaseName string, collectionName string, data []byte) error {
+	var collectionFileName = common.GetCollectionFileName(collectionName)
+	return common.SaveGobFile(engine.keyFolder(databaseName), common.GetCollectionFilePath(engine.dataPath, databaseName, collectionName, collectionFileName), data)
+}
+
+func (engine *GobStorageEngine) PersistSegment(databaseName string, collectionName string, segmentId string, data []byte) error {
+	return common.SaveGobFile(engine.keyFolder(databaseName), common.GetCollectionFilePath(engine.dataPath, databaseName, collectionName, segmentId), data)
+}
+
+func (engine *GobStorageEngine) DeleteSegments(databaseName string, collectionName string, segmentIds []string) error {
+	return removeCollectionFiles(common.GetCollectionFolderPath(engine.dataPath, databaseName, collectionName), segmentIds)
+}
+
+func (engine *GobStorageEngine) DeleteBatches(databaseName string, collectionName string, batchIds []string) error {
+	return removeCollectionFiles(common.GetCollectionFolderPath(engine.dataPath, databaseName, collectionName), batchIds)
+}
+
+func removeCollectionFiles(collectionFolder string, fileNames []string) error {
+	for _, fileName := range fileNames {
+		if err := os.Remove(filepath.Join(collectionFolder, fileName)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (engine *GobStorageEngine) DeleteCollection(databaseName string, collectionName string) error {
+	common.DeleteFolder(common.GetCollectionFolderPath(engine.dataPath, databaseName, collectionName))
+	return nil
+}
+
+func (engine *GobStorageEngine) Close(databaseName string) error {
+	return nil
+}
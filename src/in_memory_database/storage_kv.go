@@ -0,0 +1,547 @@
+package in_memory_database
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	kvOpPut    byte = 1
+	kvOpDelete byte = 2
+
+	kvRecordHeaderSize = 13 // crc32, op, key length, value length
+)
+
+// KVStorageEngine keeps all collections of a database in one append only key-value file.
+// Every put and delete is appended as a record with a crc32, a torn record at the end of the file is dropped on open.
+// The file is rewritten with only the live records once more than half of it is garbage.
+// Values are sealed with the data key of the database while encryption is enabled, keys are collection names and ids.
+type KVStorageEngine struct {
+	dataPath string
+	mu       sync.Mutex
+	stores   map[string]*kvStore // databaseName: open store
+}
+
+type kvStore struct {
+	mu        sync.Mutex
+	filePath  string
+	file      *os.File
+	size      int64
+	values    map[string]kvValuePosition // key: position of its latest value
+	liveBytes int64                      // size of the records holding latest values
+}
+
+type kvValuePosition struct {
+	offset     int64
+	length     int64
+	recordSize int64
+}
+
+func NewKVStorageEngine(dataPath string) *KVStorageEngine {
+	return &KVStorageEngine{dataPath: dataPath, stores: make(map[string]*kvStore)}
+}
+
+// keyFolder is the database folder holding the store file and its data keys
+func (store *kvStore) keyFolder() string {
+	return filepath.Dir(store.filePath)
+}
+
+func metadataKey(collectionName string) string {
+	return collectionName + "/meta"
+}
+
+func batchKeyPrefix(collectionName string) string {
+	return collectionName + "/batch/"
+}
+
+func segmentKeyPrefix(collectionName string) string {
+	return collectionName + "/segment/"
+}
+
+func (engine *KVStorageEngine) store(databaseName string) (*kvStore, error) {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+
+	if store, exists := engine.stores[databaseName]; exists {
+		return store, nil
+	}
+
+	store, err := openKVStore(filepath.Join(common.GetDatabaseFolderPath(engine.dataPath, databaseName), global_constants.KV_STORE_FILE_NAME))
+	if err != nil {
+		return nil, err
+	}
+
+	engine.stores[databaseName] = store
+	return store, nil
+}
+
+func (engine *KVStorageEngine) ListCollections(databaseName string) ([]string, error) {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	var collectionNames = make([]string, 0)
+	for _, key := range store.keys("") {
+		if collectionName, found := strings.CutSuffix(key, "/meta"); found {
+			collectionNames = append(collectionNames, collectionName)
+		}
+	}
+	return collectionNames, nil
+}
+
+func (engine *KVStorageEngine) LoadCollection(databaseName string, collectionName string) (StoredCollection, error) {
+	var storedCollection = StoredCollection{Batches: make(map[string][]byte), Segments: make(map[string][]byte)}
+
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return storedCollection, err
+	}
+
+	if metadata, err := store.get(metadataKey(collectionName)); err == nil {
+		storedCollection.Metadata = metadata
+	}
+
+	for _, key := range store.keys(batchKeyPrefix(collectionName)) {
+		batchGobData, err := store.get(key)
+		if err != nil {
+			storageLogger.Error("read error", "file", store.filePath, "key", key, "error", err)
+			continue
+		}
+		storedCollection.Batches[strings.TrimPrefix(key, batchKeyPrefix(collectionName))] = batchGobData
+	}
+
+	for _, key := range store.keys(segmentKeyPrefix(collectionName)) {
+		segmentGobData, err := store.get(key)
+		if err != nil {
+			storageLogger.Error("read error", "file", store.filePath, "key", key, "error", err)
+			continue
+		}
+		storedCollection.Segments[strings.TrimPrefix(key, segmentKeyPrefix(collectionName))] = segmentGobData
+	}
+
+	return storedCollection, nil
+}
+
+func (engine *KVStorageEngine) LoadBatch(databaseName string, collectionName string, batchId string) ([]byte, error) {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return nil, err
+	}
+	return store.get(batchKeyPrefix(collectionName) + batchId)
+}
+
+func (engine *KVStorageEngine) PersistBatch(databaseName string, collectionName string, batchId string, data []byte) error {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return err
+	}
+	return store.put(batchKeyPrefix(collectionName)+batchId, data)
+}
+
+func (engine *KVStorageEngine) PersistMetadata(databaseName string, collectionName string, data []byte) error {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return err
+	}
+	return store.put(metadataKey(collectionName), data)
+}
+
+func (engine *KVStorageEngine) PersistSegment(databaseName string, collectionName string, segmentId string, data []byte) error {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return err
+	}
+	return store.put(segmentKeyPrefix(collectionName)+segmentId, data)
+}
+
+func (engine *KVStorageEngine) DeleteSegments(databaseName string, collectionName string, segmentIds []string) error {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return err
+	}
+
+	var keys = make([]string, 0, len(segmentIds))
+	for _, segmentId := range segmentIds {
+		keys = append(keys, segmentKeyPrefix(collectionName)+segmentId)
+	}
+	return store.delete(keys)
+}
+
+func (engine *KVStorageEngine) DeleteBatches(databaseName string, collectionName string, batchIds []string) error {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return err
+	}
+
+	var keys = make([]string, 0, len(batchIds))
+	for _, batchId := range batchIds {
+		keys = append(keys, batchKeyPrefix(collectionName)+batchId)
+	}
+	return store.delete(keys)
+}
+
+func (engine *KVStorageEngine) DeleteCollection(databaseName string, collectionName string) error {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return err
+	}
+	return store.delete(store.keys(collectionName + "/"))
+}
+
+// Reencrypt rewrites the store of a database once a value isn't sealed with the active data key, it returns the files
+// rewritten
+func (engine *KVStorageEngine) Reencrypt(databaseName string) (int, error) {
+	store, err := engine.store(databaseName)
+	if err != nil {
+		return 0, err
+	}
+	return store.reencrypt()
+}
+
+func (engine *KVStorageEngine) Close(databaseName string) error {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+
+	store, exists := engine.stores[databaseName]
+	if !exists {
+		return nil
+	}
+
+	delete(engine.stores, databaseName)
+	return store.close()
+}
+
+func openKVStore(filePath string) (*kvStore, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &kvStore{filePath: filePath, file: file, values: make(map[string]kvValuePosition)}
+
+	if err := store.scan(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// scan reads every record to find the latest value of each key, the file is cut at the first torn or corrupted record
+func (store *kvStore) scan() error {
+	reader := bufio.NewReader(io.NewSectionReader(store.file, 0, 1<<62))
+	var offset int64 = 0
+
+	for {
+		var header = make([]byte, kvRecordHeaderSize)
+
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err != io.EOF {
+				storageLogger.Warn("torn record dropped", "file", store.filePath, "offset", offset)
+			}
+			break
+		}
+
+		var op = header[4]
+		var keyLength = int64(binary.BigEndian.Uint32(header[5:9]))
+		var valueLength = int64(binary.BigEndian.Uint32(header[9:13]))
+
+		var body = make([]byte, keyLength+valueLength)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			storageLogger.Warn("torn record dropped", "file", store.filePath, "offset", offset)
+			break
+		}
+
+		if binary.BigEndian.Uint32(header[0:4]) != common.Checksum(append(header[4:], body...)) {
+			storageLogger.Error(global_constants.FILE_CHECKSUM_MISMATCH_MSG+", the rest of the file is dropped", "file", store.filePath, "offset", offset)
+			break
+		}
+
+		var key = string(body[:keyLength])
+		var recordSize = kvRecordHeaderSize + keyLength + valueLength
+
+		if previous, exists := store.values[key]; exists {
+			store.liveBytes -= previous.recordSize
+			delete(store.values, key)
+		}
+
+		if op == kvOpPut {
+			store.values[key] = kvValuePosition{offset: offset + kvRecordHeaderSize + keyLength, length: valueLength, recordSize: recordSize}
+			store.liveBytes += recordSize
+		}
+
+		offset += recordSize
+	}
+
+	store.size = offset
+
+	if err := store.file.Truncate(offset); err != nil {
+		return err
+	}
+	_, err := store.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+func encodeKVRecord(op byte, key string, value []byte) []byte {
+	var record = make([]byte, kvRecordHeaderSize, kvRecordHeaderSize+len(key)+len(value))
+
+	record[4] = op
+	binary.BigEndian.PutUint32(record[5:9], uint32(len(key)))
+	binary.BigEndian.PutUint32(record[9:13], uint32(len(value)))
+	record = append(record, key...)
+	record = append(record, value...)
+
+	binary.BigEndian.PutUint32(record[0:4], common.Checksum(record[4:]))
+
+	return record
+}
+
+func (store *kvStore) keys(prefix string) []string {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var keys = make([]string, 0)
+	for key := range store.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (store *kvStore) get(key string) ([]byte, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	position, exists := store.values[key]
+	if !exists || store.file == nil {
+		return nil, os.ErrNotExist
+	}
+
+	var value = make([]byte, position.length)
+	if _, err := store.file.ReadAt(value, position.offset); err != nil {
+		return nil, err
+	}
+	return common.DecryptData(store.keyFolder(), value)
+}
+
+func (store *kvStore) put(key string, value []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.file == nil {
+		return errors.New("kv store is closed")
+	}
+
+	value, err := common.EncryptData(store.keyFolder(), value)
+	if err != nil {
+		return err
+	}
+
+	var record = encodeKVRecord(kvOpPut, key, value)
+
+	if _, err := store.file.Write(record); err != nil {
+		return err
+	}
+	if err := store.file.Sync(); err != nil {
+		return err
+	}
+
+	if previous, exists := store.values[key]; exists {
+		store.liveBytes -= previous.recordSize
+	}
+
+	var recordSize = int64(len(record))
+	store.values[key] = kvValuePosition{offset: store.size + kvRecordHeaderSize + int64(len(key)), length: int64(len(value)), recordSize: recordSize}
+	store.liveBytes += recordSize
+	store.size += recordSize
+
+	return store.compactIfSparse()
+}
+
+func (store *kvStore) delete(keys []string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.file == nil {
+		return errors.New("kv store is closed")
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var records = make([]byte, 0)
+	for _, key := range keys {
+		records = append(records, encodeKVRecord(kvOpDelete, key, nil)...)
+	}
+
+	if _, err := store.file.Write(records); err != nil {
+		return err
+	}
+	if err := store.file.Sync(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if previous, exists := store.values[key]; exists {
+			store.liveBytes -= previous.recordSize
+			delete(store.values, key)
+		}
+	}
+	store.size += int64(len(records))
+
+	return store.compactIfSparse()
+}
+
+// compactIfSparse rewrites the file with only the latest value of each key once more than half of it is garbage.
+// Caller must hold store.mu
+func (store *kvStore) compactIfSparse() error {
+	if store.size < global_constants.KV_COMPACTION_MIN_SIZE || store.size < 2*store.liveBytes {
+		return nil
+	}
+
+	if err := store.rewrite(nil); err != nil {
+		return err
+	}
+
+	storageLogger.Info("file compacted", "file", store.filePath, "bytes", store.size)
+	return nil
+}
+
+// reencrypt rewrites the file with every value sealed with the active data key, values sealed with a previous key or
+// written before encryption was enabled are left nowhere in the file. It returns 1 if the file was rewritten
+func (store *kvStore) reencrypt() (int, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.file == nil {
+		return 0, errors.New("kv store is closed")
+	}
+
+	var isRewriteNeeded = false
+	for _, position := range store.values {
+		var value = make([]byte, position.length)
+		if _, err := store.file.ReadAt(value, position.offset); err != nil {
+			return 0, err
+		}
+
+		isActive, err := common.IsSealedWithActiveKey(store.keyFolder(), value)
+		if err != nil {
+			return 0, err
+		}
+		if !isActive {
+			isRewriteNeeded = true
+			break
+		}
+	}
+
+	// garbage records can hold values sealed with a previous key too, they are dropped only by a rewrite
+	if !isRewriteNeeded && store.size == store.liveBytes {
+		return 0, nil
+	}
+
+	err := store.rewrite(func(value []byte) ([]byte, error) {
+		if isActive, err := common.IsSealedWithActiveKey(store.keyFolder(), value); isActive || err != nil {
+			return value, err
+		}
+
+		data, err := common.DecryptData(store.keyFolder(), value)
+		if err != nil {
+			return nil, err
+		}
+		return common.EncryptData(store.keyFolder(), data)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// rewrite writes only the latest value of each key to a new file, passed through reseal when it is set. The new file
+// replaces the old one by rename so a crash keeps one of them whole. Caller must hold store.mu
+func (store *kvStore) rewrite(reseal func(value []byte) ([]byte, error)) error {
+	var tempFilePath = store.filePath + global_constants.TEMP_FILE_EXTENSION
+
+	tempFile, err := os.Create(tempFilePath)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(tempFile)
+	var values = make(map[string]kvValuePosition)
+	var offset int64 = 0
+
+	for key, position := range store.values {
+		var value = make([]byte, position.length)
+		if _, err := store.file.ReadAt(value, position.offset); err != nil {
+			tempFile.Close()
+			return err
+		}
+
+		if reseal != nil {
+			var err error
+			if value, err = reseal(value); err != nil {
+				tempFile.Close()
+				return err
+			}
+		}
+
+		var record = encodeKVRecord(kvOpPut, key, value)
+		if _, err := writer.Write(record); err != nil {
+			tempFile.Close()
+			return err
+		}
+
+		values[key] = kvValuePosition{offset: offset + kvRecordHeaderSize + int64(len(key)), length: int64(len(value)), recordSize: int64(len(record))}
+		offset += int64(len(record))
+	}
+
+	if err := writer.Flush(); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := os.Rename(tempFilePath, store.filePath); err != nil {
+		tempFile.Close()
+		return err
+	}
+	common.SyncFolder(filepath.Dir(store.filePath))
+
+	store.file.Close()
+	store.file = tempFile
+	store.values = values
+	store.size = offset
+	store.liveBytes = offset
+
+	_, err = store.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+func (store *kvStore) close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.file == nil {
+		return nil
+	}
+
+	err := store.file.Close()
+	store.file = nil
+	return err
+}
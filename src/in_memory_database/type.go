@@ -10,8 +10,9 @@ type Result struct {
 }
 
 type DatabaseCreateRequest struct {
-	DatabaseName string            `json:"databaseName"`
-	Collections  []CollectionInput `json:"collections"`
+	DatabaseName string              `json:"databaseName"`
+	Collections  []CollectionInput   `json:"collections"`
+	Config       DatabaseConfigInput `json:"config"`
 }
 
 type DatabaseCreateResult struct {
@@ -43,6 +44,14 @@ type DatabaseLoadToDiskResult struct {
 	Data string `json:"data"`
 }
 
+type DatabaseRotateKeyRequest struct {
+	DatabaseName string `json:"databaseName"`
+}
+
+type DatabaseRotateKeyResult struct {
+	Data string `json:"data"`
+}
+
 type CollectionCreateRequest struct {
 	DatabaseName string            `json:"databaseName"`
 	Collections  []CollectionInput `json:"collections"`
@@ -78,6 +87,25 @@ type CollectionStatsResult struct {
 	Data CollectionStats
 }
 
+type CollectionCompactRequest struct {
+	DatabaseName   string `json:"databaseName"`
+	CollectionName string `json:"collectionName"`
+}
+
+type CollectionCompactResult struct {
+	Data BatchCompactionStats
+}
+
+type CollectionQueryCacheRequest struct {
+	DatabaseName   string `json:"databaseName"`
+	CollectionName string `json:"collectionName"`
+	QueryCacheMB   int    `json:"queryCacheMB"` // 0 turns the cache off
+}
+
+type CollectionQueryCacheResult struct {
+	Data string `json:"data"`
+}
+
 type DocumentCreateRequest struct {
 	DatabaseName   string   `json:"databaseName"`
 	CollectionName string   `json:"collectionName"`
@@ -102,10 +130,12 @@ type DocumentFilterRequest struct {
 	DatabaseName   string       `json:"databaseName"`
 	CollectionName string       `json:"collectionName"`
 	Filter         MapInterface `json:"filter"`
+	Explain        bool         `json:"explain"` // return the plan of the filter with the documents
 }
 
 type DocumentFilterResult struct {
 	Data []Document `json:"data"`
+	Plan *QueryPlan `json:"plan,omitempty"`
 }
 
 type DocumentUpdateRequest struct {
@@ -137,3 +167,162 @@ type DocumentGetAllRequest struct {
 type DocumentGetAllResult struct {
 	Data []Document `json:"data"`
 }
+
+type WebhookCreateRequest struct {
+	DatabaseName   string       `json:"databaseName"`
+	CollectionName string       `json:"collectionName"`
+	Url            string       `json:"url"`
+	Secret         string       `json:"secret"`
+	Events         []string     `json:"events"`
+	Filter         MapInterface `json:"filter"`
+}
+
+type WebhookCreateResult struct {
+	Data Webhook `json:"data"`
+}
+
+type WebhookDeleteRequest struct {
+	DatabaseName string `json:"databaseName"`
+	WebhookId    string `json:"webhookId"`
+}
+
+type WebhookDeleteResult struct {
+	Data string `json:"data"`
+}
+
+type WebhookGetAllRequest struct {
+	DatabaseName string `json:"databaseName"`
+}
+
+type WebhookGetAllResult struct {
+	Data []Webhook `json:"data"`
+}
+
+type WebhookDeadLettersResult struct {
+	Data []WebhookDeadLetter `json:"data"`
+}
+
+type ReplicationStatsResult struct {
+	Data ReplicationStats `json:"data"`
+}
+
+type ShardMapResult struct {
+	Data ShardMap `json:"data"`
+}
+
+type LoadProgressResult struct {
+	Data LoadProgress `json:"data"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResult struct {
+	Data string `json:"data"` // token to send as "Authorization: Bearer <token>"
+}
+
+// Grant gives a privilege on a database, or on one collection of it. "*" matches every database or every collection,
+// an empty collection means the whole database
+type Grant struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	Privilege  string `json:"privilege"` // read, write or admin
+}
+
+type UserCreateRequest struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles"`
+}
+
+type UserDeleteRequest struct {
+	Username string `json:"username"`
+}
+
+type UserPasswordRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type UserRolesRequest struct {
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+}
+
+type UserResult struct {
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+}
+
+type UserGetAllResult struct {
+	Data []UserResult `json:"data"`
+}
+
+type RoleCreateRequest struct {
+	Name   string  `json:"name"`
+	Grants []Grant `json:"grants"`
+}
+
+type RoleDeleteRequest struct {
+	Name string `json:"name"`
+}
+
+type RoleGrantRequest struct {
+	Name  string `json:"name"`
+	Grant Grant  `json:"grant"`
+}
+
+type RoleResult struct {
+	Name   string  `json:"name"`
+	Grants []Grant `json:"grants"`
+}
+
+type RoleGetAllResult struct {
+	Data []RoleResult `json:"data"`
+}
+
+type UserUpdateResult struct {
+	Data string `json:"data"`
+}
+
+type RoleUpdateResult struct {
+	Data string `json:"data"`
+}
+
+type APIKeyCreateRequest struct {
+	Name      string  `json:"name"`
+	Grants    []Grant `json:"grants"`
+	ExpiresAt string  `json:"expiresAt"` // RFC 3339, Ex: 2025-12-31T00:00:00Z, empty for a key that doesn't expire
+}
+
+type APIKeyCreated struct {
+	KeyId string `json:"keyId"`
+	Key   string `json:"key"` // send as "X-API-Key: <key>", it is shown only once
+}
+
+type APIKeyCreateResult struct {
+	Data APIKeyCreated `json:"data"`
+}
+
+type APIKeyDeleteRequest struct {
+	KeyId string `json:"keyId"`
+}
+
+type APIKeyDeleteResult struct {
+	Data string `json:"data"`
+}
+
+type APIKeyResult struct {
+	KeyId      string  `json:"keyId"`
+	Name       string  `json:"name"`
+	Grants     []Grant `json:"grants"`
+	CreatedAt  string  `json:"createdAt"`
+	ExpiresAt  string  `json:"expiresAt"`
+	LastUsedAt string  `json:"lastUsedAt"`
+}
+
+type APIKeyGetAllResult struct {
+	Data []APIKeyResult `json:"data"`
+}
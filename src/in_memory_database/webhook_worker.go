@@ -0,0 +1,333 @@
+package in_memory_database
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Webhook struct {
+	Id             string       `json:"id"`
+	DatabaseName   string       `json:"databaseName"`
+	CollectionName string       `json:"collectionName"` // empty means every collection of the database
+	Url            string       `json:"url"`
+	Secret         string       `json:"secret,omitempty"`
+	Events         []string     `json:"events"` // Ex: [ "EVENT_CREATE", "EVENT_DELETE"], empty means all events
+	Filter         MapInterface `json:"filter"` // Ex: { "city": "chennai" }, matched against the changed document
+	CreatedAt      string       `json:"createdAt"`
+}
+
+// Redacted returns the webhook without its secret, secrets are write only
+func (webhook Webhook) Redacted() Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+type WebhookPayload struct {
+	DeliveryId     string   `json:"deliveryId"`
+	WebhookId      string   `json:"webhookId"`
+	Type           string   `json:"type"`
+	DatabaseName   string   `json:"databaseName"`
+	CollectionName string   `json:"collectionName"`
+	DocId          string   `json:"docId"`
+	Document       Document `json:"document"`
+	Timestamp      string   `json:"timestamp"`
+}
+
+type WebhookDeadLetter struct {
+	WebhookId    string         `json:"webhookId"`
+	DatabaseName string         `json:"databaseName"`
+	Url          string         `json:"url"`
+	Payload      WebhookPayload `json:"payload"`
+	Attempts     int            `json:"attempts"`
+	Error        string         `json:"error"`
+	FailedAt     string         `json:"failedAt"`
+}
+
+type ChangeEvent struct {
+	DatabaseName   string
+	CollectionName string
+	Type           string
+	DocId          string
+	Document       Document
+}
+
+type webhookSubscriber struct {
+	webhook Webhook
+	queue   chan WebhookPayload
+}
+
+type WebhookDispatcher struct {
+	subscribers  map[string]map[string]*webhookSubscriber // Ex: { databaseName: { webhookId: subscriber } }
+	deadLetters  []WebhookDeadLetter
+	changeEvents chan ChangeEvent
+	client       *http.Client
+	isEnabled    bool
+	mu           sync.RWMutex
+	deadLetterMu sync.RWMutex
+}
+
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		subscribers:  make(map[string]map[string]*webhookSubscriber),
+		deadLetters:  make([]WebhookDeadLetter, 0),
+		changeEvents: make(chan ChangeEvent, global_constants.WEBHOOK_CHANNEL_SIZE),
+		client:       &http.Client{Timeout: global_constants.WEBHOOK_REQUEST_TIMEOUT},
+		isEnabled:    true,
+	}
+}
+
+func init() {
+	gob.Register([]Webhook{})
+}
+
+func (wd *WebhookDispatcher) Register(webhook Webhook) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	if _, exists := wd.subscribers[webhook.DatabaseName]; !exists {
+		wd.subscribers[webhook.DatabaseName] = make(map[string]*webhookSubscriber)
+	}
+
+	if existing, exists := wd.subscribers[webhook.DatabaseName][webhook.Id]; exists {
+		close(existing.queue)
+	}
+
+	subscriber := &webhookSubscriber{
+		webhook: webhook,
+		queue:   make(chan WebhookPayload, global_constants.WEBHOOK_QUEUE_SIZE),
+	}
+	wd.subscribers[webhook.DatabaseName][webhook.Id] = subscriber
+
+	go wd.startDeliveryWorker(subscriber)
+}
+
+func (wd *WebhookDispatcher) Unregister(databaseName string, webhookId string) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	if subscriber, exists := wd.subscribers[databaseName][webhookId]; exists {
+		close(subscriber.queue)
+		delete(wd.subscribers[databaseName], webhookId)
+	}
+}
+
+func (wd *WebhookDispatcher) UnregisterDatabase(databaseName string) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	for _, subscriber := range wd.subscribers[databaseName] {
+		close(subscriber.queue)
+	}
+	delete(wd.subscribers, databaseName)
+}
+
+func (wd *WebhookDispatcher) SetEnabled(isEnabled bool) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	wd.isEnabled = isEnabled
+}
+
+func (wd *WebhookDispatcher) HasSubscribers(databaseName string) bool {
+	wd.mu.RLock()
+	defer wd.mu.RUnlock()
+
+	return wd.isEnabled && len(wd.subscribers[databaseName]) > 0
+}
+
+// Publish is called by the collection mutation worker after a change is applied
+func (wd *WebhookDispatcher) Publish(changeEvent ChangeEvent) {
+	if !wd.HasSubscribers(changeEvent.DatabaseName) {
+		return
+	}
+
+	// Documents are mutable maps, so take a copy before handing it to another goroutine
+	changeEvent.Document = copyDocument(changeEvent.Document)
+
+	select {
+	case wd.changeEvents <- changeEvent:
+	default:
+		webhookLogger.Warn("change event channel is full, event dropped", "database", changeEvent.DatabaseName, "collection", changeEvent.CollectionName, "type", changeEvent.Type, "docId", changeEvent.DocId)
+	}
+}
+
+func (wd *WebhookDispatcher) StartChangeEventWorker() {
+	for changeEvent := range wd.changeEvents {
+		wd.dispatch(changeEvent)
+	}
+}
+
+func (wd *WebhookDispatcher) dispatch(changeEvent ChangeEvent) {
+	wd.mu.RLock()
+	defer wd.mu.RUnlock()
+
+	for _, subscriber := range wd.subscribers[changeEvent.DatabaseName] {
+		if !subscriber.webhook.isMatch(changeEvent) {
+			continue
+		}
+
+		payload := WebhookPayload{
+			DeliveryId:     common.Generate16DigitUUID(),
+			WebhookId:      subscriber.webhook.Id,
+			Type:           changeEvent.Type,
+			DatabaseName:   changeEvent.DatabaseName,
+			CollectionName: changeEvent.CollectionName,
+			DocId:          changeEvent.DocId,
+			Document:       changeEvent.Document,
+			Timestamp:      common.TimeToString(time.Now()),
+		}
+
+		select {
+		case subscriber.queue <- payload:
+		default:
+			wd.addDeadLetter(subscriber.webhook, payload, 0, "webhook queue is full")
+		}
+	}
+}
+
+func (webhook Webhook) isMatch(changeEvent ChangeEvent) bool {
+	if webhook.CollectionName != "" && webhook.CollectionName != changeEvent.CollectionName {
+		return false
+	}
+
+	if len(webhook.Events) > 0 {
+		var isEventMatch = false
+		for _, eventType := range webhook.Events {
+			if eventType == changeEvent.Type {
+				isEventMatch = true
+				break
+			}
+		}
+		if !isEventMatch {
+			return false
+		}
+	}
+
+	if len(webhook.Filter) > 0 {
+		filters := make([]MapInterface, 0)
+		for key, value := range webhook.Filter {
+			filters = append(filters, MapInterface{
+				global_constants.FILTER_KEY:   key,
+				global_constants.FILTER_VALUE: value,
+			})
+		}
+		return IsMatchWithDocument(filters, changeEvent.Document)
+	}
+
+	return true
+}
+
+func (wd *WebhookDispatcher) startDeliveryWorker(subscriber *webhookSubscriber) {
+	for payload := range subscriber.queue {
+		attempts, err := wd.deliverWithRetry(subscriber.webhook, payload)
+		if err != nil {
+			wd.addDeadLetter(subscriber.webhook, payload, attempts, err.Error())
+		}
+	}
+}
+
+func (wd *WebhookDispatcher) deliverWithRetry(webhook Webhook, payload WebhookPayload) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	var backoff = global_constants.WEBHOOK_INITIAL_BACKOFF
+	var attempt int
+
+	for attempt = 1; attempt <= global_constants.WEBHOOK_MAX_ATTEMPTS; attempt++ {
+		if err = wd.deliver(webhook, payload, body); err == nil {
+			return attempt, nil
+		}
+
+		if attempt == global_constants.WEBHOOK_MAX_ATTEMPTS {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff = min(backoff*2, global_constants.WEBHOOK_MAX_BACKOFF)
+	}
+
+	return attempt, err
+}
+
+func (wd *WebhookDispatcher) deliver(webhook Webhook, payload WebhookPayload, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(global_constants.WEBHOOK_EVENT_HEADER, payload.Type)
+	request.Header.Set(global_constants.WEBHOOK_DELIVERY_HEADER, payload.DeliveryId)
+
+	if webhook.Secret != "" {
+		request.Header.Set(global_constants.WEBHOOK_SIGNATURE_HEADER, "sha256="+SignWebhookPayload(webhook.Secret, body))
+	}
+
+	response, err := wd.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook %v responded with status %v", webhook.Url, response.StatusCode)
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns hex encoded HMAC-SHA256 of body, receivers verify it against X-GnoSQL-Signature
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (wd *WebhookDispatcher) addDeadLetter(webhook Webhook, payload WebhookPayload, attempts int, reason string) {
+	wd.deadLetterMu.Lock()
+	defer wd.deadLetterMu.Unlock()
+
+	deadLetter := WebhookDeadLetter{
+		WebhookId:    webhook.Id,
+		DatabaseName: webhook.DatabaseName,
+		Url:          webhook.Url,
+		Payload:      payload,
+		Attempts:     attempts,
+		Error:        reason,
+		FailedAt:     common.TimeToString(time.Now()),
+	}
+
+	wd.deadLetters = append(wd.deadLetters, deadLetter)
+
+	// keep only the latest dead letters
+	if len(wd.deadLetters) > global_constants.WEBHOOK_DEAD_LETTER_SIZE {
+		wd.deadLetters = wd.deadLetters[len(wd.deadLetters)-global_constants.WEBHOOK_DEAD_LETTER_SIZE:]
+	}
+
+	webhookLogger.Warn("delivery moved to dead letters", "webhookId", webhook.Id, "deliveryId", payload.DeliveryId, "reason", reason)
+}
+
+func (wd *WebhookDispatcher) GetDeadLetters(databaseName string) []WebhookDeadLetter {
+	wd.deadLetterMu.RLock()
+	defer wd.deadLetterMu.RUnlock()
+
+	deadLetters := make([]WebhookDeadLetter, 0)
+	for _, deadLetter := range wd.deadLetters {
+		if deadLetter.DatabaseName == databaseName {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+	return deadLetters
+}
@@ -0,0 +1,181 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"gnosql/src/global_constants"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+var subsystems = []string{
+	global_constants.LOG_SERVER, global_constants.LOG_AUTH, global_constants.LOG_DATABASE, global_constants.LOG_QUERY,
+	global_constants.LOG_STORAGE, global_constants.LOG_REPLICATION, global_constants.LOG_RAFT, global_constants.LOG_WEBHOOK,
+}
+
+// handler every subsystem logger writes through and the level of each subsystem, replaced by Configure. Loggers
+// are created when their packages initialize, before the settings are read, so they look these up on every record
+var state = struct {
+	mu           sync.RWMutex
+	handler      slog.Handler
+	defaultLevel slog.Level
+	levels       map[string]slog.Level
+}{
+	handler:      newHandler(global_constants.LOG_FORMAT_JSON, os.Stdout),
+	defaultLevel: slog.LevelInfo,
+	levels:       make(map[string]slog.Level),
+}
+
+type requestIdKey struct{}
+
+// Configure sets the level of every subsystem and the output format, json or text. subsystemLevels overrides the
+// level of some subsystems, Ex: raft=debug,storage=warn
+func Configure(level string, format string, subsystemLevels string) error {
+	defaultLevel, err := parseLevel(level)
+	if err != nil {
+		return fmt.Errorf("logLevel: %v", err)
+	}
+
+	if format != global_constants.LOG_FORMAT_JSON && format != global_constants.LOG_FORMAT_TEXT {
+		return fmt.Errorf("logFormat: unknown format %v, use json or text", format)
+	}
+
+	var levels = make(map[string]slog.Level)
+	for _, subsystemLevel := range strings.Split(subsystemLevels, ",") {
+		if strings.TrimSpace(subsystemLevel) == "" {
+			continue
+		}
+
+		subsystem, levelName, found := strings.Cut(strings.TrimSpace(subsystemLevel), "=")
+		if !found || !isSubsystem(subsystem) {
+			return fmt.Errorf("logLevels: %v, use subsystem=level with a subsystem of %v", subsystemLevel, strings.Join(subsystems, ", "))
+		}
+
+		if levels[subsystem], err = parseLevel(levelName); err != nil {
+			return fmt.Errorf("logLevels: %v", err)
+		}
+	}
+
+	state.mu.Lock()
+	state.handler = newHandler(format, os.Stdout)
+	state.defaultLevel = defaultLevel
+	state.levels = levels
+	state.mu.Unlock()
+
+	// log and slog calls of libraries, like grpc, go through the same handler
+	slog.SetDefault(Get(global_constants.LOG_SERVER))
+
+	return nil
+}
+
+// Get returns the logger of a subsystem, its records carry the subsystem and the request id of the context
+func Get(subsystem string) *slog.Logger {
+	return slog.New(&subsystemHandler{subsystem: subsystem})
+}
+
+// WithRequestId returns a context carrying the id of the request it serves, records logged with it carry the id
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId returns the id of the request served with ctx, empty outside of a request
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// NewRequestId returns an id for a request that came without one
+func NewRequestId() string {
+	return uuid.NewString()
+}
+
+func newHandler(format string, writer io.Writer) slog.Handler {
+	// subsystemHandler checks the levels, everything it passes is written
+	var options = &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		// durations read like 1.5s instead of nanoseconds
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Value.Kind() == slog.KindDuration {
+				attr.Value = slog.StringValue(attr.Value.Duration().String())
+			}
+			return attr
+		},
+	}
+
+	if format == global_constants.LOG_FORMAT_TEXT {
+		return slog.NewTextHandler(writer, options)
+	}
+	return slog.NewJSONHandler(writer, options)
+}
+
+func parseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return level, fmt.Errorf("unknown level %v, use debug, info, warn or error", name)
+	}
+	return level, nil
+}
+
+func isSubsystem(name string) bool {
+	for _, subsystem := range subsystems {
+		if subsystem == name {
+			return true
+		}
+	}
+	return false
+}
+
+// subsystemHandler filters records by the level of its subsystem and adds the subsystem and the request id before
+// passing them to the handler set by Configure
+type subsystemHandler struct {
+	subsystem string
+	// WithAttrs and WithGroup calls, replayed on the current handler for every record
+	derive []func(slog.Handler) slog.Handler
+}
+
+func (handler *subsystemHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+
+	minLevel, exists := state.levels[handler.subsystem]
+	if !exists {
+		minLevel = state.defaultLevel
+	}
+	return level >= minLevel
+}
+
+func (handler *subsystemHandler) Handle(ctx context.Context, record slog.Record) error {
+	state.mu.RLock()
+	var target = state.handler.WithAttrs([]slog.Attr{slog.String("subsystem", handler.subsystem)})
+	state.mu.RUnlock()
+
+	for _, derive := range handler.derive {
+		target = derive(target)
+	}
+
+	if requestId := RequestId(ctx); requestId != "" {
+		record.AddAttrs(slog.String("requestId", requestId))
+	}
+
+	return target.Handle(ctx, record)
+}
+
+func (handler *subsystemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handler.with(func(target slog.Handler) slog.Handler { return target.WithAttrs(attrs) })
+}
+
+func (handler *subsystemHandler) WithGroup(name string) slog.Handler {
+	return handler.with(func(target slog.Handler) slog.Handler { return target.WithGroup(name) })
+}
+
+func (handler *subsystemHandler) with(derive func(slog.Handler) slog.Handler) slog.Handler {
+	return &subsystemHandler{
+		subsystem: handler.subsystem,
+		derive:    append(append([]func(slog.Handler) slog.Handler{}, handler.derive...), derive),
+	}
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registry served on /metrics, with the Go runtime and process metrics next to the gnosql ones
+var registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gnosql_http_requests_total",
+		Help: "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gnosql_http_request_duration_seconds",
+		Help:    "HTTP request latency by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	GrpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gnosql_grpc_requests_total",
+		Help: "gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	GrpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gnosql_grpc_request_duration_seconds",
+		Help:    "gRPC call latency by method, streams are timed until they end.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	DiskWriteDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gnosql_disk_write_duration_seconds",
+		Help:    "Time to write a file to disk by kind: segment, batch, collection or database.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+	}, []string{"kind"})
+
+	DiskWriteFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gnosql_disk_write_failures_total",
+		Help: "Failed file writes by kind, the changes are written again by the next save.",
+	}, []string{"kind"})
+
+	CollectionLoadDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gnosql_collection_load_seconds",
+		Help: "Time taken to read the files of a collection into memory.",
+	}, []string{"database", "collection"})
+
+	StartupLoadDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gnosql_startup_load_seconds",
+		Help: "Time taken to load or register every database at startup.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests, HTTPRequestDuration,
+		GrpcRequests, GrpcRequestDuration,
+		DiskWriteDuration, DiskWriteFailures,
+		CollectionLoadDuration, StartupLoadDuration,
+	)
+}
+
+// Register adds a collector read on every scrape, like the queue depths and sizes of the collections
+func Register(collector prometheus.Collector) {
+	registry.MustRegister(collector)
+}
+
+// Handler serves the registered metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveDiskWrite records a file write started at startedAt and passes its error through
+func ObserveDiskWrite(kind string, startedAt time.Time, err error) error {
+	DiskWriteDuration.WithLabelValues(kind).Observe(time.Since(startedAt).Seconds())
+	if err != nil {
+		DiskWriteFailures.WithLabelValues(kind).Inc()
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	pb "gnosql/proto"
+	"gnosql/src/auth"
+	"gnosql/src/global_constants"
+	"gnosql/src/tls_config"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcTransport sends raft RPCs over the RaftService of the other members, one connection per member
+type GrpcTransport struct {
+	clients map[string]pb.RaftServiceClient
+	mu      sync.Mutex
+}
+
+func NewGrpcTransport() *GrpcTransport {
+	return &GrpcTransport{clients: make(map[string]pb.RaftServiceClient)}
+}
+
+func (transport *GrpcTransport) client(peer Peer) (pb.RaftServiceClient, error) {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	if client, exists := transport.clients[peer.Address]; exists {
+		return client, nil
+	}
+
+	conn, err := grpc.NewClient(peer.Address, tls_config.NodeDialOption(), auth.NodeDialOption())
+	if err != nil {
+		return nil, err
+	}
+
+	client := pb.NewRaftServiceClient(conn)
+	transport.clients[peer.Address] = client
+
+	return client, nil
+}
+
+func (transport *GrpcTransport) RequestVote(peer Peer, request VoteRequest) (VoteResponse, error) {
+	client, err := transport.client(peer)
+	if err != nil {
+		return VoteResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), global_constants.RAFT_RPC_TIMEOUT)
+	defer cancel()
+
+	response, err := client.RequestVote(ctx, &pb.RaftVoteRequest{
+		Term:         request.Term,
+		CandidateId:  request.CandidateId,
+		LastLogIndex: request.LastLogIndex,
+		LastLogTerm:  request.LastLogTerm,
+	})
+	if err != nil {
+		return VoteResponse{}, err
+	}
+
+	return VoteResponse{Term: response.Term, VoteGranted: response.VoteGranted}, nil
+}
+
+func (transport *GrpcTransport) AppendEntries(peer Peer, request AppendRequest) (AppendResponse, error) {
+	client, err := transport.client(peer)
+	if err != nil {
+		return AppendResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), global_constants.RAFT_RPC_TIMEOUT)
+	defer cancel()
+
+	response, err := client.AppendEntries(ctx, &pb.RaftAppendRequest{
+		Term:         request.Term,
+		LeaderId:     request.LeaderId,
+		PrevLogIndex: request.PrevLogIndex,
+		PrevLogTerm:  request.PrevLogTerm,
+		Entries:      EntriesToProto(request.Entries),
+		LeaderCommit: request.LeaderCommit,
+	})
+	if err != nil {
+		return AppendResponse{}, err
+	}
+
+	return AppendResponse{Term: response.Term, Success: response.Success, ConflictIndex: response.ConflictIndex}, nil
+}
+
+// InstallSnapshot streams the snapshot in chunks, the receiver installs it once the stream is closed
+func (transport *GrpcTransport) InstallSnapshot(peer Peer, request SnapshotRequest) (SnapshotResponse, error) {
+	client, err := transport.client(peer)
+	if err != nil {
+		return SnapshotResponse{}, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.InstallSnapshot(ctx)
+	if err != nil {
+		return SnapshotResponse{}, err
+	}
+
+	for start := 0; start == 0 || start < len(request.Data); start += global_constants.REPLICATION_SNAPSHOT_CHUNK_SIZE {
+		end := min(start+global_constants.REPLICATION_SNAPSHOT_CHUNK_SIZE, len(request.Data))
+
+		chunk := &pb.RaftSnapshotChunk{
+			Term:              request.Term,
+			LeaderId:          request.LeaderId,
+			LastIncludedIndex: request.LastIncludedIndex,
+			LastIncludedTerm:  request.LastIncludedTerm,
+			Data:              request.Data[start:end],
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return SnapshotResponse{}, err
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return SnapshotResponse{}, err
+	}
+
+	return SnapshotResponse{Term: response.Term}, nil
+}
+
+// Propose forwards a command received by a follower to the leader
+func (transport *GrpcTransport) Propose(peer Peer, command []byte) (uint64, error) {
+	client, err := transport.client(peer)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), global_constants.RAFT_PROPOSE_TIMEOUT+global_constants.RAFT_RPC_TIMEOUT)
+	defer cancel()
+
+	response, err := client.Propose(ctx, &pb.RaftProposeRequest{Data: command})
+	if err != nil {
+		// leader's error message, without the gRPC status prefix
+		return 0, errors.New(status.Convert(err).Message())
+	}
+
+	return response.Index, nil
+}
+
+func EntriesToProto(entries []LogEntry) []*pb.RaftLogEntry {
+	protoEntries := make([]*pb.RaftLogEntry, 0)
+
+	for _, entry := range entries {
+		protoEntries = append(protoEntries, &pb.RaftLogEntry{Index: entry.Index, Term: entry.Term, Data: entry.Data})
+	}
+
+	return protoEntries
+}
+
+func EntriesFromProto(protoEntries []*pb.RaftLogEntry) []LogEntry {
+	entries := make([]LogEntry, 0)
+
+	for _, protoEntry := range protoEntries {
+		entries = append(entries, LogEntry{Index: protoEntry.Index, Term: protoEntry.Term, Data: protoEntry.Data})
+	}
+
+	return entries
+}
@@ -0,0 +1,777 @@
+package raft
+
+import (
+	"gnosql/src/global_constants"
+	"gnosql/src/logging"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var logger = logging.Get(global_constants.LOG_RAFT)
+
+type proposal struct {
+	term   uint64
+	result chan error
+}
+
+// Node is a member of a raft replica group. Commands are committed once a majority stored them,
+// then every member hands them to its StateMachine in the same order.
+type Node struct {
+	Id           string
+	peers        []Peer
+	transport    Transport
+	stateMachine StateMachine
+	storage      *Storage
+
+	state       State
+	currentTerm uint64
+	votedFor    string
+	leaderId    string
+
+	// log[0] is a sentinel holding the index and term of the last entry included in the snapshot
+	log         []LogEntry
+	commitIndex uint64
+	lastApplied uint64
+	nextIndex   map[string]uint64
+	matchIndex  map[string]uint64
+
+	electionDeadline time.Time
+	applyNotify      chan struct{}
+	appliedNotify    chan struct{} // closed whenever lastApplied moves
+	replicateNotify  map[string]chan struct{}
+	proposals        map[uint64]proposal
+
+	// OnLeaderChange is called whenever this node gains or loses leadership, with the node lock held
+	OnLeaderChange func(isLeader bool)
+
+	mu      sync.Mutex
+	applyMu sync.Mutex
+}
+
+func NewNode(id string, peers []Peer, transport Transport, stateMachine StateMachine, storage *Storage) (*Node, error) {
+	node := &Node{
+		Id:              id,
+		peers:           peers,
+		transport:       transport,
+		stateMachine:    stateMachine,
+		storage:         storage,
+		state:           Follower,
+		log:             []LogEntry{{Index: 0, Term: 0}},
+		nextIndex:       make(map[string]uint64),
+		matchIndex:      make(map[string]uint64),
+		applyNotify:     make(chan struct{}, 1),
+		appliedNotify:   make(chan struct{}),
+		replicateNotify: make(map[string]chan struct{}),
+		proposals:       make(map[uint64]proposal),
+		OnLeaderChange:  func(isLeader bool) {},
+	}
+
+	for _, peer := range peers {
+		node.replicateNotify[peer.Id] = make(chan struct{}, 1)
+	}
+
+	persistentState, err := storage.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	node.currentTerm = persistentState.CurrentTerm
+	node.votedFor = persistentState.VotedFor
+
+	snapshot, err := storage.LoadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.LastIncludedIndex > 0 {
+		if err := stateMachine.Restore(snapshot.Data); err != nil {
+			return nil, err
+		}
+		node.log[0] = LogEntry{Index: snapshot.LastIncludedIndex, Term: snapshot.LastIncludedTerm}
+		node.commitIndex = snapshot.LastIncludedIndex
+		node.lastApplied = snapshot.LastIncludedIndex
+	}
+
+	entries, err := storage.LoadLog()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Index > node.lastIndex() {
+			node.log = append(node.log, entry)
+		}
+	}
+
+	return node, nil
+}
+
+func (node *Node) Start() {
+	node.mu.Lock()
+	node.resetElectionDeadline()
+	node.mu.Unlock()
+
+	go node.runTicker()
+	go node.runApplier()
+
+	for _, peer := range node.peers {
+		go node.runReplicator(peer)
+	}
+}
+
+func (node *Node) snapshotIndex() uint64 {
+	return node.log[0].Index
+}
+
+func (node *Node) lastIndex() uint64 {
+	return node.log[len(node.log)-1].Index
+}
+
+func (node *Node) lastTerm() uint64 {
+	return node.log[len(node.log)-1].Term
+}
+
+// termAt expects snapshotIndex <= index <= lastIndex
+func (node *Node) termAt(index uint64) uint64 {
+	return node.log[index-node.snapshotIndex()].Term
+}
+
+func (node *Node) entriesFrom(index uint64, limit int) []LogEntry {
+	var start = index - node.snapshotIndex()
+	var end = min(uint64(len(node.log)), start+uint64(limit))
+
+	entries := make([]LogEntry, end-start)
+	copy(entries, node.log[start:end])
+	return entries
+}
+
+func (node *Node) resetElectionDeadline() {
+	var spread = global_constants.RAFT_ELECTION_TIMEOUT_MAX - global_constants.RAFT_ELECTION_TIMEOUT_MIN
+	node.electionDeadline = time.Now().Add(global_constants.RAFT_ELECTION_TIMEOUT_MIN + time.Duration(rand.Int63n(int64(spread))))
+}
+
+func (node *Node) persistState() {
+	if err := node.storage.SaveState(PersistentState{CurrentTerm: node.currentTerm, VotedFor: node.votedFor}); err != nil {
+		logger.Error("state save error", "node", node.Id, "error", err)
+	}
+}
+
+func (node *Node) majority() int {
+	return (len(node.peers)+1)/2 + 1
+}
+
+func (node *Node) findPeer(id string) (Peer, bool) {
+	for _, peer := range node.peers {
+		if peer.Id == id {
+			return peer, true
+		}
+	}
+	return Peer{}, false
+}
+
+func (node *Node) runTicker() {
+	for range time.Tick(global_constants.RAFT_TICK_INTERVAL) {
+		node.mu.Lock()
+		if node.state != Leader && time.Now().After(node.electionDeadline) {
+			node.startElection()
+		}
+		node.mu.Unlock()
+	}
+}
+
+// startElection expects node.mu to be held
+func (node *Node) startElection() {
+	node.state = Candidate
+	node.currentTerm++
+	node.votedFor = node.Id
+	node.leaderId = ""
+	node.persistState()
+	node.resetElectionDeadline()
+
+	var term = node.currentTerm
+	var votes = 1
+
+	logger.Info("starting election", "node", node.Id, "term", term)
+
+	if votes >= node.majority() {
+		node.becomeLeader()
+		return
+	}
+
+	request := VoteRequest{
+		Term:         term,
+		CandidateId:  node.Id,
+		LastLogIndex: node.lastIndex(),
+		LastLogTerm:  node.lastTerm(),
+	}
+
+	for _, peer := range node.peers {
+		go func(peer Peer) {
+			response, err := node.transport.RequestVote(peer, request)
+			if err != nil {
+				return
+			}
+
+			node.mu.Lock()
+			defer node.mu.Unlock()
+
+			if response.Term > node.currentTerm {
+				node.becomeFollower(response.Term)
+				return
+			}
+
+			if node.state != Candidate || node.currentTerm != term || !response.VoteGranted {
+				return
+			}
+
+			votes++
+			if votes >= node.majority() {
+				node.becomeLeader()
+			}
+		}(peer)
+	}
+}
+
+// becomeLeader expects node.mu to be held
+func (node *Node) becomeLeader() {
+	node.state = Leader
+	node.leaderId = node.Id
+
+	for _, peer := range node.peers {
+		node.nextIndex[peer.Id] = node.lastIndex() + 1
+		node.matchIndex[peer.Id] = 0
+	}
+
+	// entries of previous terms only commit together with an entry of the current term
+	node.appendEntries([]LogEntry{{Index: node.lastIndex() + 1, Term: node.currentTerm}})
+
+	logger.Info("became leader", "node", node.Id, "term", node.currentTerm)
+
+	node.OnLeaderChange(true)
+	node.advanceCommitIndex()
+	node.notifyReplicators()
+}
+
+// becomeFollower expects node.mu to be held
+func (node *Node) becomeFollower(term uint64) {
+	var wasLeader = node.state == Leader
+
+	if term > node.currentTerm {
+		node.currentTerm = term
+		node.votedFor = ""
+		node.persistState()
+	}
+
+	node.state = Follower
+
+	if wasLeader {
+		logger.Info("stepped down", "node", node.Id, "term", node.currentTerm)
+
+		for index, pending := range node.proposals {
+			pending.result <- ErrNotLeader
+			delete(node.proposals, index)
+		}
+		node.OnLeaderChange(false)
+	}
+}
+
+// appendEntries expects node.mu to be held
+func (node *Node) appendEntries(entries []LogEntry) {
+	node.log = append(node.log, entries...)
+
+	if err := node.storage.AppendLog(entries); err != nil {
+		logger.Error("log append error", "node", node.Id, "error", err)
+	}
+}
+
+func (node *Node) notifyReplicators() {
+	for _, notify := range node.replicateNotify {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (node *Node) notifyApplier() {
+	select {
+	case node.applyNotify <- struct{}{}:
+	default:
+	}
+}
+
+// advanceCommitIndex expects node.mu to be held
+func (node *Node) advanceCommitIndex() {
+	for index := node.lastIndex(); index > node.commitIndex && index > node.snapshotIndex(); index-- {
+		if node.termAt(index) != node.currentTerm {
+			break
+		}
+
+		var replicated = 1
+		for _, peer := range node.peers {
+			if node.matchIndex[peer.Id] >= index {
+				replicated++
+			}
+		}
+
+		if replicated >= node.majority() {
+			node.commitIndex = index
+			node.notifyApplier()
+			return
+		}
+	}
+}
+
+func (node *Node) runReplicator(peer Peer) {
+	heartbeat := time.NewTicker(global_constants.RAFT_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-node.replicateNotify[peer.Id]:
+		case <-heartbeat.C:
+		}
+
+		node.replicateTo(peer)
+	}
+}
+
+func (node *Node) replicateTo(peer Peer) {
+	node.mu.Lock()
+
+	if node.state != Leader {
+		node.mu.Unlock()
+		return
+	}
+
+	var term = node.currentTerm
+	var nextIndex = node.nextIndex[peer.Id]
+
+	if nextIndex <= node.snapshotIndex() {
+		node.mu.Unlock()
+		node.sendSnapshot(peer, term)
+		return
+	}
+
+	request := AppendRequest{
+		Term:         term,
+		LeaderId:     node.Id,
+		PrevLogIndex: nextIndex - 1,
+		PrevLogTerm:  node.termAt(nextIndex - 1),
+		Entries:      node.entriesFrom(nextIndex, global_constants.RAFT_MAX_APPEND_ENTRIES),
+		LeaderCommit: node.commitIndex,
+	}
+	node.mu.Unlock()
+
+	response, err := node.transport.AppendEntries(peer, request)
+	if err != nil {
+		return
+	}
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	if response.Term > node.currentTerm {
+		node.becomeFollower(response.Term)
+		return
+	}
+
+	if node.state != Leader || node.currentTerm != term {
+		return
+	}
+
+	if response.Success {
+		var matchIndex = request.PrevLogIndex + uint64(len(request.Entries))
+
+		if matchIndex > node.matchIndex[peer.Id] {
+			node.matchIndex[peer.Id] = matchIndex
+		}
+		node.nextIndex[peer.Id] = node.matchIndex[peer.Id] + 1
+		node.advanceCommitIndex()
+	} else {
+		node.nextIndex[peer.Id] = max(1, min(response.ConflictIndex, nextIndex-1))
+	}
+
+	// keep sending until the peer caught up
+	if node.nextIndex[peer.Id] <= node.lastIndex() {
+		select {
+		case node.replicateNotify[peer.Id] <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (node *Node) sendSnapshot(peer Peer, term uint64) {
+	snapshot, err := node.storage.LoadSnapshot()
+	if err != nil {
+		logger.Error("snapshot read error", "node", node.Id, "peer", peer.Id, "error", err)
+		return
+	}
+
+	response, err := node.transport.InstallSnapshot(peer, SnapshotRequest{
+		Term:              term,
+		LeaderId:          node.Id,
+		LastIncludedIndex: snapshot.LastIncludedIndex,
+		LastIncludedTerm:  snapshot.LastIncludedTerm,
+		Data:              snapshot.Data,
+	})
+	if err != nil {
+		return
+	}
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	if response.Term > node.currentTerm {
+		node.becomeFollower(response.Term)
+		return
+	}
+
+	if node.state == Leader && node.currentTerm == term {
+		node.matchIndex[peer.Id] = max(node.matchIndex[peer.Id], snapshot.LastIncludedIndex)
+		node.nextIndex[peer.Id] = node.matchIndex[peer.Id] + 1
+	}
+}
+
+func (node *Node) runApplier() {
+	for range node.applyNotify {
+		node.applyCommitted()
+	}
+}
+
+func (node *Node) applyCommitted() {
+	node.applyMu.Lock()
+	defer node.applyMu.Unlock()
+
+	for {
+		node.mu.Lock()
+		if node.lastApplied >= node.commitIndex {
+			node.mu.Unlock()
+			break
+		}
+		entries := node.entriesFrom(node.lastApplied+1, int(node.commitIndex-node.lastApplied))
+		node.mu.Unlock()
+
+		for _, entry := range entries {
+			if len(entry.Data) > 0 {
+				node.stateMachine.Apply(entry.Data)
+			}
+
+			node.mu.Lock()
+			node.setLastApplied(entry.Index)
+			if pending, exists := node.proposals[entry.Index]; exists {
+				if pending.term == entry.Term {
+					pending.result <- nil
+				} else {
+					pending.result <- ErrNotLeader
+				}
+				delete(node.proposals, entry.Index)
+			}
+			node.mu.Unlock()
+		}
+	}
+
+	node.takeSnapshotIfNeeded()
+}
+
+// setLastApplied expects node.mu to be held
+func (node *Node) setLastApplied(index uint64) {
+	node.lastApplied = index
+
+	close(node.appliedNotify)
+	node.appliedNotify = make(chan struct{})
+}
+
+// waitApplied blocks until this node applied the entry at index
+func (node *Node) waitApplied(index uint64) error {
+	timeout := time.After(global_constants.RAFT_PROPOSE_TIMEOUT)
+
+	for {
+		node.mu.Lock()
+		var isApplied = node.lastApplied >= index
+		var appliedNotify = node.appliedNotify
+		node.mu.Unlock()
+
+		if isApplied {
+			return nil
+		}
+
+		select {
+		case <-appliedNotify:
+		case <-timeout:
+			return ErrProposeTimeout
+		}
+	}
+}
+
+// takeSnapshotIfNeeded compacts the log once enough entries are applied, expects node.applyMu to be held
+func (node *Node) takeSnapshotIfNeeded() {
+	node.mu.Lock()
+	var lastApplied = node.lastApplied
+	var isNeeded = lastApplied-node.snapshotIndex() >= global_constants.RAFT_SNAPSHOT_THRESHOLD
+	node.mu.Unlock()
+
+	if !isNeeded {
+		return
+	}
+
+	data, err := node.stateMachine.Snapshot()
+	if err != nil {
+		logger.Error("snapshot error", "node", node.Id, "error", err)
+		return
+	}
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	snapshot := PersistentSnapshot{LastIncludedIndex: lastApplied, LastIncludedTerm: node.termAt(lastApplied), Data: data}
+
+	if err := node.storage.SaveSnapshot(snapshot); err != nil {
+		logger.Error("snapshot save error", "node", node.Id, "error", err)
+		return
+	}
+
+	node.compactLog(snapshot.LastIncludedIndex, snapshot.LastIncludedTerm)
+}
+
+// compactLog drops entries up to index, expects node.mu to be held
+func (node *Node) compactLog(index uint64, term uint64) {
+	var remaining = []LogEntry{{Index: index, Term: term}}
+
+	if index < node.lastIndex() && index >= node.snapshotIndex() && node.termAt(index) == term {
+		remaining = append(remaining, node.log[index-node.snapshotIndex()+1:]...)
+	}
+
+	node.log = remaining
+
+	if err := node.storage.RewriteLog(node.log[1:]); err != nil {
+		logger.Error("log compaction error", "node", node.Id, "error", err)
+	}
+}
+
+// Propose replicates command through the leader and returns once it is applied on this node,
+// a follower forwards the command to the leader
+func (node *Node) Propose(command []byte) (uint64, error) {
+	node.mu.Lock()
+
+	if node.state != Leader {
+		var leaderId = node.leaderId
+		node.mu.Unlock()
+
+		peer, exists := node.findPeer(leaderId)
+		if !exists {
+			return 0, ErrNoLeader
+		}
+
+		index, err := node.transport.Propose(peer, command)
+		if err != nil {
+			return index, err
+		}
+		return index, node.waitApplied(index)
+	}
+
+	entry := LogEntry{Index: node.lastIndex() + 1, Term: node.currentTerm, Data: command}
+	node.appendEntries([]LogEntry{entry})
+
+	result := make(chan error, 1)
+	node.proposals[entry.Index] = proposal{term: entry.Term, result: result}
+
+	node.advanceCommitIndex()
+	node.notifyReplicators()
+	node.mu.Unlock()
+
+	select {
+	case err := <-result:
+		return entry.Index, err
+	case <-time.After(global_constants.RAFT_PROPOSE_TIMEOUT):
+		node.mu.Lock()
+		delete(node.proposals, entry.Index)
+		node.mu.Unlock()
+		return entry.Index, ErrProposeTimeout
+	}
+}
+
+func (node *Node) HandleRequestVote(request VoteRequest) VoteResponse {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	if request.Term > node.currentTerm {
+		node.becomeFollower(request.Term)
+	}
+
+	response := VoteResponse{Term: node.currentTerm}
+
+	if request.Term < node.currentTerm {
+		return response
+	}
+
+	var isLogUpToDate = request.LastLogTerm > node.lastTerm() ||
+		(request.LastLogTerm == node.lastTerm() && request.LastLogIndex >= node.lastIndex())
+
+	if (node.votedFor == "" || node.votedFor == request.CandidateId) && isLogUpToDate {
+		node.votedFor = request.CandidateId
+		node.persistState()
+		node.resetElectionDeadline()
+		response.VoteGranted = true
+	}
+
+	return response
+}
+
+func (node *Node) HandleAppendEntries(request AppendRequest) AppendResponse {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	if request.Term < node.currentTerm {
+		return AppendResponse{Term: node.currentTerm}
+	}
+
+	if request.Term > node.currentTerm || node.state != Follower {
+		node.becomeFollower(request.Term)
+	}
+	node.leaderId = request.LeaderId
+	node.resetElectionDeadline()
+
+	response := AppendResponse{Term: node.currentTerm}
+
+	// entries already covered by the snapshot are skipped
+	for len(request.Entries) > 0 && request.Entries[0].Index <= node.snapshotIndex() {
+		request.PrevLogIndex = request.Entries[0].Index
+		request.PrevLogTerm = request.Entries[0].Term
+		request.Entries = request.Entries[1:]
+	}
+
+	if request.PrevLogIndex < node.snapshotIndex() {
+		response.ConflictIndex = node.snapshotIndex() + 1
+		return response
+	}
+
+	if request.PrevLogIndex > node.lastIndex() {
+		response.ConflictIndex = node.lastIndex() + 1
+		return response
+	}
+
+	if conflictTerm := node.termAt(request.PrevLogIndex); conflictTerm != request.PrevLogTerm {
+		var conflictIndex = request.PrevLogIndex
+		for conflictIndex > node.snapshotIndex()+1 && node.termAt(conflictIndex-1) == conflictTerm {
+			conflictIndex--
+		}
+		response.ConflictIndex = conflictIndex
+		return response
+	}
+
+	var newEntries = make([]LogEntry, 0)
+
+	for i, entry := range request.Entries {
+		if entry.Index <= node.lastIndex() {
+			if node.termAt(entry.Index) == entry.Term {
+				continue
+			}
+
+			// conflicting suffix is replaced by the leader's entries
+			node.log = node.log[:entry.Index-node.snapshotIndex()]
+			if err := node.storage.RewriteLog(node.log[1:]); err != nil {
+				logger.Error("log truncate error", "node", node.Id, "error", err)
+			}
+		}
+		newEntries = request.Entries[i:]
+		break
+	}
+
+	if len(newEntries) > 0 {
+		node.appendEntries(newEntries)
+	}
+
+	// entries past the ones this request matched may be a stale suffix of an older term, they are not committed yet
+	var lastMatchedIndex = request.PrevLogIndex + uint64(len(request.Entries))
+
+	if commitIndex := min(request.LeaderCommit, lastMatchedIndex); commitIndex > node.commitIndex {
+		node.commitIndex = commitIndex
+		node.notifyApplier()
+	}
+
+	response.Success = true
+	return response
+}
+
+func (node *Node) HandleInstallSnapshot(request SnapshotRequest) SnapshotResponse {
+	node.mu.Lock()
+
+	if request.Term < node.currentTerm {
+		defer node.mu.Unlock()
+		return SnapshotResponse{Term: node.currentTerm}
+	}
+
+	if request.Term > node.currentTerm || node.state != Follower {
+		node.becomeFollower(request.Term)
+	}
+	node.leaderId = request.LeaderId
+	node.resetElectionDeadline()
+
+	var term = node.currentTerm
+	var isStale = request.LastIncludedIndex <= node.lastApplied
+	node.mu.Unlock()
+
+	if isStale {
+		return SnapshotResponse{Term: term}
+	}
+
+	// applier is paused while the state machine is replaced
+	node.applyMu.Lock()
+	defer node.applyMu.Unlock()
+
+	if err := node.stateMachine.Restore(request.Data); err != nil {
+		logger.Error("snapshot restore error", "node", node.Id, "error", err)
+		return SnapshotResponse{Term: term}
+	}
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	snapshot := PersistentSnapshot{
+		LastIncludedIndex: request.LastIncludedIndex,
+		LastIncludedTerm:  request.LastIncludedTerm,
+		Data:              request.Data,
+	}
+
+	if err := node.storage.SaveSnapshot(snapshot); err != nil {
+		logger.Error("snapshot save error", "node", node.Id, "error", err)
+	}
+
+	node.compactLog(snapshot.LastIncludedIndex, snapshot.LastIncludedTerm)
+	node.commitIndex = max(node.commitIndex, snapshot.LastIncludedIndex)
+	node.setLastApplied(snapshot.LastIncludedIndex)
+
+	logger.Info("installed snapshot", "node", node.Id, "index", snapshot.LastIncludedIndex)
+
+	return SnapshotResponse{Term: node.currentTerm}
+}
+
+func (node *Node) IsLeader() bool {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	return node.state == Leader
+}
+
+func (node *Node) Stats() NodeStats {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	peers := make([]string, 0)
+	for _, peer := range node.peers {
+		peers = append(peers, peer.Id+"="+peer.Address)
+	}
+
+	return NodeStats{
+		NodeId:        node.Id,
+		State:         string(node.state),
+		LeaderId:      node.leaderId,
+		Term:          node.currentTerm,
+		LastIndex:     node.lastIndex(),
+		CommitIndex:   node.commitIndex,
+		LastApplied:   node.lastApplied,
+		SnapshotIndex: node.snapshotIndex(),
+		Peers:         peers,
+	}
+}
@@ -0,0 +1,343 @@
+package raft
+
+import (
+	"errors"
+	"fmt"
+	"gnosql/src/common"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errNodeDown = errors.New("node is down")
+
+// memoryNetwork connects the nodes of a test group without sockets, a killed node neither sends nor receives
+type memoryNetwork struct {
+	mu    sync.Mutex
+	nodes map[string]*memoryTransport // node id: transport of its running instance
+}
+
+// memoryTransport is the Transport of one node instance
+type memoryTransport struct {
+	network  *memoryNetwork
+	node     *Node
+	isKilled atomic.Bool
+}
+
+func (network *memoryNetwork) target(from *memoryTransport, peer Peer) (*Node, error) {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	to, exists := network.nodes[peer.Id]
+	if from.isKilled.Load() || !exists || to.isKilled.Load() {
+		return nil, errNodeDown
+	}
+	return to.node, nil
+}
+
+func (transport *memoryTransport) RequestVote(peer Peer, request VoteRequest) (VoteResponse, error) {
+	node, err := transport.network.target(transport, peer)
+	if err != nil {
+		return VoteResponse{}, err
+	}
+	return node.HandleRequestVote(request), nil
+}
+
+func (transport *memoryTransport) AppendEntries(peer Peer, request AppendRequest) (AppendResponse, error) {
+	node, err := transport.network.target(transport, peer)
+	if err != nil {
+		return AppendResponse{}, err
+	}
+	return node.HandleAppendEntries(request), nil
+}
+
+func (transport *memoryTransport) InstallSnapshot(peer Peer, request SnapshotRequest) (SnapshotResponse, error) {
+	node, err := transport.network.target(transport, peer)
+	if err != nil {
+		return SnapshotResponse{}, err
+	}
+	return node.HandleInstallSnapshot(request), nil
+}
+
+func (transport *memoryTransport) Propose(peer Peer, command []byte) (uint64, error) {
+	node, err := transport.network.target(transport, peer)
+	if err != nil {
+		return 0, err
+	}
+	return node.Propose(command)
+}
+
+// memoryStateMachine keeps the applied commands in order
+type memoryStateMachine struct {
+	mu       sync.Mutex
+	commands []string
+}
+
+func (stateMachine *memoryStateMachine) Apply(command []byte) {
+	stateMachine.mu.Lock()
+	defer stateMachine.mu.Unlock()
+
+	stateMachine.commands = append(stateMachine.commands, string(command))
+}
+
+func (stateMachine *memoryStateMachine) Snapshot() ([]byte, error) {
+	stateMachine.mu.Lock()
+	defer stateMachine.mu.Unlock()
+
+	return common.EncodeGob(stateMachine.commands)
+}
+
+func (stateMachine *memoryStateMachine) Restore(snapshot []byte) error {
+	stateMachine.mu.Lock()
+	defer stateMachine.mu.Unlock()
+
+	return common.DecodeGob(snapshot, &stateMachine.commands)
+}
+
+func (stateMachine *memoryStateMachine) Commands() []string {
+	stateMachine.mu.Lock()
+	defer stateMachine.mu.Unlock()
+
+	return slices.Clone(stateMachine.commands)
+}
+
+// testGroup is a replica group over a memoryNetwork, every node keeps its storage in its own folder
+type testGroup struct {
+	t             *testing.T
+	network       *memoryNetwork
+	peers         []Peer
+	folders       map[string]string
+	nodes         map[string]*Node
+	stateMachines map[string]*memoryStateMachine
+}
+
+func newTestGroup(t *testing.T, size int) *testGroup {
+	group := &testGroup{
+		t:             t,
+		network:       &memoryNetwork{nodes: make(map[string]*memoryTransport)},
+		folders:       make(map[string]string),
+		nodes:         make(map[string]*Node),
+		stateMachines: make(map[string]*memoryStateMachine),
+	}
+
+	for i := 1; i <= size; i++ {
+		group.peers = append(group.peers, Peer{Id: fmt.Sprintf("node%v", i)})
+	}
+
+	for _, peer := range group.peers {
+		// nodes can't be stopped and may still persist their term while the test ends, so removal errors are ignored
+		folder, err := os.MkdirTemp("", "raft-"+peer.Id+"-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(folder) })
+
+		group.folders[peer.Id] = folder
+		group.start(peer.Id)
+	}
+
+	t.Cleanup(func() {
+		for id := range group.nodes {
+			group.kill(id)
+		}
+	})
+
+	return group
+}
+
+// start runs a node from the storage in its folder, with an empty state machine
+func (group *testGroup) start(id string) {
+	var otherPeers = make([]Peer, 0)
+	for _, peer := range group.peers {
+		if peer.Id != id {
+			otherPeers = append(otherPeers, peer)
+		}
+	}
+
+	storage, err := NewStorage(group.folders[id])
+	if err != nil {
+		group.t.Fatal(err)
+	}
+
+	transport := &memoryTransport{network: group.network}
+	stateMachine := &memoryStateMachine{}
+
+	node, err := NewNode(id, otherPeers, transport, stateMachine, storage)
+	if err != nil {
+		group.t.Fatal(err)
+	}
+	transport.node = node
+
+	group.network.mu.Lock()
+	group.network.nodes[id] = transport
+	group.network.mu.Unlock()
+
+	group.nodes[id] = node
+	group.stateMachines[id] = stateMachine
+
+	node.Start()
+}
+
+// kill cuts a node off the network, its instance is left behind and a restart starts a new one
+func (group *testGroup) kill(id string) {
+	group.network.mu.Lock()
+	defer group.network.mu.Unlock()
+
+	group.network.nodes[id].isKilled.Store(true)
+}
+
+// waitForLeader returns the only leader among the running nodes once there is one with a term after minTerm
+func (group *testGroup) waitForLeader(minTerm uint64, except string) *Node {
+	group.t.Helper()
+
+	var deadline = time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		var leaders = make([]*Node, 0)
+		for id, node := range group.nodes {
+			if id != except && node.IsLeader() && node.Stats().Term > minTerm {
+				leaders = append(leaders, node)
+			}
+		}
+
+		if len(leaders) == 1 {
+			return leaders[0]
+		}
+		if len(leaders) > 1 {
+			group.t.Fatalf("%v leaders in the same term", len(leaders))
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	group.t.Fatal("no leader elected")
+	return nil
+}
+
+func (group *testGroup) propose(leader *Node, commands []string) {
+	group.t.Helper()
+
+	for _, command := range commands {
+		if _, err := leader.Propose([]byte(command)); err != nil {
+			group.t.Fatalf("%v not committed: %v", command, err)
+		}
+	}
+}
+
+func testCommands(from int, to int) []string {
+	var commands = make([]string, 0)
+	for i := from; i <= to; i++ {
+		commands = append(commands, fmt.Sprintf("write-%v", i))
+	}
+	return commands
+}
+
+// TestLeaderFailover kills the leader of a 3 node group, checks that the others elect a new one keeping every
+// committed write, and that the killed node catches up once restarted from its storage
+func TestLeaderFailover(t *testing.T) {
+	group := newTestGroup(t, 3)
+
+	firstLeader := group.waitForLeader(0, "")
+	var firstTerm = firstLeader.Stats().Term
+
+	var committed = testCommands(1, 20)
+	group.propose(firstLeader, committed)
+
+	group.kill(firstLeader.Id)
+
+	secondLeader := group.waitForLeader(firstTerm, firstLeader.Id)
+
+	var afterFailover = testCommands(21, 40)
+	group.propose(secondLeader, afterFailover)
+	committed = append(committed, afterFailover...)
+
+	// a write returns once applied on the leader, so the new leader holds every committed write already
+	if commands := group.stateMachines[secondLeader.Id].Commands(); !slices.Equal(commands, committed) {
+		t.Fatalf("new leader applied %v, expected %v", commands, committed)
+	}
+
+	group.start(firstLeader.Id)
+
+	var deadline = time.Now().Add(15 * time.Second)
+	for _, peer := range group.peers {
+		for {
+			commands := group.stateMachines[peer.Id].Commands()
+			if slices.Equal(commands, committed) {
+				break
+			}
+			if len(commands) > len(committed) || !slices.Equal(commands, committed[:len(commands)]) {
+				t.Fatalf("%v applied %v, expected %v", peer.Id, commands, committed)
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%v applied %v of %v committed writes", peer.Id, len(commands), len(committed))
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	var leaderStats = secondLeader.Stats()
+	for _, peer := range group.peers {
+		if stats := group.nodes[peer.Id].Stats(); stats.Term != leaderStats.Term || stats.LeaderId != secondLeader.Id {
+			t.Errorf("%v at term %v following %q, leader %v at term %v", peer.Id, stats.Term, stats.LeaderId, secondLeader.Id, leaderStats.Term)
+		}
+	}
+}
+
+// TestFollowerKeepsStaleSuffixUncommitted gives a follower entries of an old term the leader doesn't have, a heartbeat
+// matching only the entries before them must not commit them, the leader's entries replace them instead
+func TestFollowerKeepsStaleSuffixUncommitted(t *testing.T) {
+	storage, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// written by a leader of term 1 that lost its leadership before replicating entries 2 and 3
+	if err := storage.SaveState(PersistentState{CurrentTerm: 1}); err != nil {
+		t.Fatal(err)
+	}
+	err = storage.AppendLog([]LogEntry{
+		{Index: 1, Term: 1, Data: []byte("write-1")},
+		{Index: 2, Term: 1, Data: []byte("stale-2")},
+		{Index: 3, Term: 1, Data: []byte("stale-3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateMachine := &memoryStateMachine{}
+	transport := &memoryTransport{network: &memoryNetwork{nodes: make(map[string]*memoryTransport)}}
+
+	node, err := NewNode("node1", []Peer{{Id: "node2"}, {Id: "node3"}}, transport, stateMachine, storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the leader of term 2 committed its own entries 2 and 3, its heartbeat only matches entry 1
+	response := node.HandleAppendEntries(AppendRequest{Term: 2, LeaderId: "node2", PrevLogIndex: 1, PrevLogTerm: 1, LeaderCommit: 3})
+	if !response.Success {
+		t.Fatal("heartbeat matching entry 1 rejected")
+	}
+	node.applyCommitted()
+
+	if commands := stateMachine.Commands(); !slices.Equal(commands, []string{"write-1"}) {
+		t.Fatalf("applied %v, entries of the stale suffix must not be committed", commands)
+	}
+
+	response = node.HandleAppendEntries(AppendRequest{
+		Term:         2,
+		LeaderId:     "node2",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []LogEntry{{Index: 2, Term: 2, Data: []byte("write-2")}, {Index: 3, Term: 2, Data: []byte("write-3")}},
+		LeaderCommit: 3,
+	})
+	if !response.Success {
+		t.Fatal("entries of the leader rejected")
+	}
+	node.applyCommitted()
+
+	if commands := stateMachine.Commands(); !slices.Equal(commands, []string{"write-1", "write-2", "write-3"}) {
+		t.Fatalf("applied %v, expected the entries of the leader", commands)
+	}
+}
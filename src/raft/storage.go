@@ -0,0 +1,233 @@
+package raft
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"gnosql/src/common"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const stateFileName = "raft-state.gob"
+const logFileName = "raft-log.dat"
+const snapshotFileName = "raft-snapshot.gob"
+
+type PersistentState struct {
+	CurrentTerm uint64
+	VotedFor    string
+}
+
+type PersistentSnapshot struct {
+	LastIncludedIndex uint64
+	LastIncludedTerm  uint64
+	Data              []byte
+}
+
+// Storage keeps term, vote, log and snapshot of a node on disk.
+// Log entries are appended as length prefixed gob records, so an append never rewrites the file.
+// While encryption is enabled the state, the snapshot and every record are sealed with a data key kept in the storage
+// folder. Files and records written before still read.
+type Storage struct {
+	dir     string
+	logFile *os.File
+}
+
+func NewStorage(dir string) (*Storage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	logFile, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Storage{dir: dir, logFile: logFile}, nil
+}
+
+func (storage *Storage) LoadState() (PersistentState, error) {
+	var state PersistentState
+
+	data, err := os.ReadFile(filepath.Join(storage.dir, stateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if data, err = common.DecryptData(storage.dir, data); err != nil {
+		return state, err
+	}
+
+	err = common.DecodeGob(data, &state)
+	return state, err
+}
+
+func (storage *Storage) SaveState(state PersistentState) error {
+	data, err := common.EncodeGob(state)
+	if err != nil {
+		return err
+	}
+	return writeFileSync(filepath.Join(storage.dir, stateFileName), data)
+}
+
+func (storage *Storage) LoadSnapshot() (PersistentSnapshot, error) {
+	var snapshot PersistentSnapshot
+
+	data, err := os.ReadFile(filepath.Join(storage.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return snapshot, err
+	}
+
+	if data, err = common.DecryptData(storage.dir, data); err != nil {
+		return snapshot, err
+	}
+
+	err = common.DecodeGob(data, &snapshot)
+	return snapshot, err
+}
+
+func (storage *Storage) SaveSnapshot(snapshot PersistentSnapshot) error {
+	data, err := common.EncodeGob(snapshot)
+	if err != nil {
+		return err
+	}
+	return writeFileSync(filepath.Join(storage.dir, snapshotFileName), data)
+}
+
+// LoadLog reads every complete record, a record torn by a crash at the end of the file is truncated. A record that
+// can't be decrypted fails the load instead, the log is kept for the right master key
+func (storage *Storage) LoadLog() ([]LogEntry, error) {
+	entries := make([]LogEntry, 0)
+
+	if _, err := storage.logFile.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(storage.logFile)
+	var validOffset int64
+
+	for {
+		var length uint32
+		if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+			break
+		}
+
+		record := make([]byte, length)
+		if _, err := io.ReadFull(reader, record); err != nil {
+			break
+		}
+
+		record, err := common.DecryptData(storage.dir, record)
+		if err != nil {
+			return nil, err
+		}
+
+		var entry LogEntry
+		if err := common.DecodeGob(record, &entry); err != nil {
+			break
+		}
+
+		entries = append(entries, entry)
+		validOffset += 4 + int64(length)
+	}
+
+	if err := storage.logFile.Truncate(validOffset); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (storage *Storage) AppendLog(entries []LogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	writer := bufio.NewWriter(storage.logFile)
+
+	for _, entry := range entries {
+		record, err := common.EncodeGob(entry)
+		if err != nil {
+			return err
+		}
+		if record, err = common.EncryptData(storage.dir, record); err != nil {
+			return err
+		}
+		if err := binary.Write(writer, binary.BigEndian, uint32(len(record))); err != nil {
+			return err
+		}
+		if _, err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return storage.logFile.Sync()
+}
+
+// RewriteLog replaces the whole log, used after a conflicting suffix is truncated or the log is compacted
+func (storage *Storage) RewriteLog(entries []LogEntry) error {
+	var logFilePath = filepath.Join(storage.dir, logFileName)
+	var tempFilePath = logFilePath + ".tmp"
+
+	tempFile, err := os.OpenFile(tempFilePath, os.O_CREATE|os.O_TRUNC|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	previousLogFile := storage.logFile
+	storage.logFile = tempFile
+
+	if err := storage.AppendLog(entries); err != nil {
+		storage.logFile = previousLogFile
+		tempFile.Close()
+		return err
+	}
+
+	if err := os.Rename(tempFilePath, logFilePath); err != nil {
+		storage.logFile = previousLogFile
+		tempFile.Close()
+		return err
+	}
+
+	previousLogFile.Close()
+	return nil
+}
+
+// writeFileSync seals data with the key of its folder and replaces filePath with it by rename
+func writeFileSync(filePath string, data []byte) error {
+	var tempFilePath = filePath + ".tmp"
+
+	data, err := common.EncryptData(filepath.Dir(filePath), data)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(tempFilePath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
+}
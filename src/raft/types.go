@@ -0,0 +1,97 @@
+package raft
+
+import (
+	"errors"
+	"gnosql/src/global_constants"
+)
+
+type State string
+
+const (
+	Follower  State = "follower"
+	Candidate State = "candidate"
+	Leader    State = "leader"
+)
+
+type Peer struct {
+	Id      string
+	Address string
+}
+
+type LogEntry struct {
+	Index uint64
+	Term  uint64
+	Data  []byte // nil for the no-op entry a new leader appends
+}
+
+type VoteRequest struct {
+	Term         uint64
+	CandidateId  string
+	LastLogIndex uint64
+	LastLogTerm  uint64
+}
+
+type VoteResponse struct {
+	Term        uint64
+	VoteGranted bool
+}
+
+type AppendRequest struct {
+	Term         uint64
+	LeaderId     string
+	PrevLogIndex uint64
+	PrevLogTerm  uint64
+	Entries      []LogEntry
+	LeaderCommit uint64
+}
+
+type AppendResponse struct {
+	Term          uint64
+	Success       bool
+	ConflictIndex uint64 // first index the follower needs, lets the leader skip back a whole term at once
+}
+
+type SnapshotRequest struct {
+	Term              uint64
+	LeaderId          string
+	LastIncludedIndex uint64
+	LastIncludedTerm  uint64
+	Data              []byte
+}
+
+type SnapshotResponse struct {
+	Term uint64
+}
+
+type NodeStats struct {
+	NodeId        string   `json:"nodeId"`
+	State         string   `json:"state"`
+	LeaderId      string   `json:"leaderId"`
+	Term          uint64   `json:"term"`
+	LastIndex     uint64   `json:"lastIndex"`
+	CommitIndex   uint64   `json:"commitIndex"`
+	LastApplied   uint64   `json:"lastApplied"`
+	SnapshotIndex uint64   `json:"snapshotIndex"`
+	Peers         []string `json:"peers"`
+}
+
+// StateMachine receives committed commands in log order
+type StateMachine interface {
+	Apply(command []byte)
+	Snapshot() ([]byte, error)
+	Restore(snapshot []byte) error
+}
+
+// Transport sends raft RPCs to other members of the group
+type Transport interface {
+	RequestVote(peer Peer, request VoteRequest) (VoteResponse, error)
+	AppendEntries(peer Peer, request AppendRequest) (AppendResponse, error)
+	InstallSnapshot(peer Peer, request SnapshotRequest) (SnapshotResponse, error)
+	Propose(peer Peer, command []byte) (uint64, error)
+}
+
+var (
+	ErrNotLeader      = errors.New(global_constants.RAFT_NOT_LEADER_MSG)
+	ErrNoLeader       = errors.New(global_constants.RAFT_NO_LEADER_MSG)
+	ErrProposeTimeout = errors.New(global_constants.RAFT_PROPOSE_TIMEOUT_MSG)
+)
@@ -0,0 +1,130 @@
+package replication
+
+import (
+	"fmt"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/raft"
+	"strings"
+)
+
+// RaftStateMachine applies committed raft entries to the in memory databases
+type RaftStateMachine struct {
+	GnoSQL *in_memory_database.GnoSQL
+}
+
+func (stateMachine *RaftStateMachine) Apply(command []byte) {
+	var entry in_memory_database.ReplicationEntry
+
+	if err := common.DecodeGob(command, &entry); err != nil {
+		logger.Error("raft entry skipped, decoding error", "error", err)
+		return
+	}
+
+	stateMachine.GnoSQL.ApplyReplicationEntry(entry)
+}
+
+func (stateMachine *RaftStateMachine) Snapshot() ([]byte, error) {
+	_, snapshotFiles, err := stateMachine.GnoSQL.CreateSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return common.EncodeGob(snapshotFiles)
+}
+
+func (stateMachine *RaftStateMachine) Restore(snapshot []byte) error {
+	var snapshotFiles []in_memory_database.SnapshotFile
+
+	if err := common.DecodeGob(snapshot, &snapshotFiles); err != nil {
+		return err
+	}
+
+	return installSnapshot(stateMachine.GnoSQL, snapshotFiles)
+}
+
+// RaftConsensus proposes replication entries to the raft replica group
+type RaftConsensus struct {
+	Node *raft.Node
+}
+
+func (consensus *RaftConsensus) Propose(entry in_memory_database.ReplicationEntry) error {
+	command, err := common.EncodeGob(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = consensus.Node.Propose(command)
+	return err
+}
+
+func (consensus *RaftConsensus) Stats() raft.NodeStats {
+	return consensus.Node.Stats()
+}
+
+// StartRaft joins gnoSQL to the replica group, peers lists every member including this node.
+// Databases are rebuilt from the raft snapshot and log kept in storageDir, writes are committed through the group.
+func StartRaft(gnoSQL *in_memory_database.GnoSQL, nodeId string, peers []raft.Peer, storageDir string) (*raft.Node, error) {
+	var otherPeers = make([]raft.Peer, 0)
+	var isMember = false
+
+	for _, peer := range peers {
+		if peer.Id == nodeId {
+			isMember = true
+			continue
+		}
+		otherPeers = append(otherPeers, peer)
+	}
+
+	if !isMember {
+		return nil, fmt.Errorf("raft node %v is not in the peer list", nodeId)
+	}
+
+	storage, err := raft.NewStorage(storageDir)
+	if err != nil {
+		return nil, err
+	}
+
+	gnoSQL.Role = global_constants.ROLE_RAFT
+
+	// files left in the data folder may be ahead of or behind the raft log, start from an empty state
+	gnoSQL.ResetAllDBs()
+
+	// only the leader delivers webhooks, followers would send duplicates
+	gnoSQL.WebhookDispatcher.SetEnabled(false)
+
+	node, err := raft.NewNode(nodeId, otherPeers, raft.NewGrpcTransport(), &RaftStateMachine{GnoSQL: gnoSQL}, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	node.OnLeaderChange = func(isLeader bool) {
+		gnoSQL.WebhookDispatcher.SetEnabled(isLeader)
+	}
+
+	gnoSQL.Consensus = &RaftConsensus{Node: node}
+
+	return node, nil
+}
+
+// ParseRaftPeers parses a member list like "n1=host1:5455,n2=host2:5455"
+func ParseRaftPeers(value string) ([]raft.Peer, error) {
+	var peers = make([]raft.Peer, 0)
+
+	for _, member := range strings.Split(value, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+
+		id, address, found := strings.Cut(member, "=")
+		if !found || id == "" || address == "" {
+			return nil, fmt.Errorf("invalid raft peer %q, expected id=host:port", member)
+		}
+
+		peers = append(peers, raft.Peer{Id: id, Address: address})
+	}
+
+	return peers, nil
+}
@@ -0,0 +1,160 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	pb "gnosql/proto"
+	"gnosql/src/auth"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
+	"gnosql/src/tls_config"
+	"io"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Get(global_constants.LOG_REPLICATION)
+
+// StartReplica bootstraps from the primary's snapshot and keeps tailing its mutation log, reconnecting on failure
+func StartReplica(gnoSQL *in_memory_database.GnoSQL, leaderAddress string) {
+	for {
+		err := runReplica(gnoSQL, leaderAddress)
+
+		gnoSQL.ReplicaStatus.SetConnected(false)
+		logger.Warn("replication from primary stopped", "primary", leaderAddress, "error", err, "retryIn", global_constants.REPLICATION_RETRY_INTERVAL)
+
+		time.Sleep(global_constants.REPLICATION_RETRY_INTERVAL)
+	}
+}
+
+func runReplica(gnoSQL *in_memory_database.GnoSQL, leaderAddress string) error {
+	conn, err := grpc.NewClient(leaderAddress, tls_config.NodeDialOption(), auth.NodeDialOption())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pb.NewReplicationServiceClient(conn)
+
+	logId, replicatedSeq := gnoSQL.ReplicaStatus.GetReplicatedSeq()
+
+	if logId == "" {
+		if logId, replicatedSeq, err = bootstrap(gnoSQL, client); err != nil {
+			return err
+		}
+	}
+
+	stream, err := client.StreamMutations(context.Background(), &pb.ReplicationStreamRequest{FromSeq: replicatedSeq + 1, LogId: logId})
+	if err != nil {
+		return err
+	}
+
+	gnoSQL.ReplicaStatus.SetConnected(true)
+
+	for {
+		response, err := stream.Recv()
+
+		if err != nil {
+			if status.Code(err) == codes.OutOfRange {
+				// primary restarted or this replica fell behind the primary's log, bootstrap again
+				gnoSQL.ReplicaStatus.Bootstrapped("", 0)
+			}
+			return err
+		}
+
+		if response.LogId != logId {
+			gnoSQL.ReplicaStatus.Bootstrapped("", 0)
+			return errors.New(global_constants.REPLICATION_LOG_TRUNCATED_MSG)
+		}
+
+		if len(response.Data) > 0 {
+			var entry in_memory_database.ReplicationEntry
+
+			if err := common.DecodeGob(response.Data, &entry); err != nil {
+				return err
+			}
+
+			gnoSQL.ApplyReplicationEntry(entry)
+			gnoSQL.ReplicaStatus.Replicated(entry)
+		}
+
+		gnoSQL.ReplicaStatus.Contact(response.LeaderSeq)
+	}
+}
+
+// bootstrap replaces every local database with the primary's snapshot and returns the snapshot position
+func bootstrap(gnoSQL *in_memory_database.GnoSQL, client pb.ReplicationServiceClient) (string, uint64, error) {
+	stream, err := client.Snapshot(context.Background(), &pb.SnapshotRequest{})
+	if err != nil {
+		return "", 0, err
+	}
+
+	var logId string
+	var seq uint64
+	var snapshotFiles = make([]in_memory_database.SnapshotFile, 0)
+	var files = make(map[string]*bytes.Buffer)
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", 0, err
+		}
+
+		logId, seq = chunk.LogId, chunk.Seq
+
+		if chunk.Path == "" {
+			continue
+		}
+
+		if _, exists := files[chunk.Path]; !exists {
+			files[chunk.Path] = new(bytes.Buffer)
+			snapshotFiles = append(snapshotFiles, in_memory_database.SnapshotFile{Path: chunk.Path})
+		}
+		files[chunk.Path].Write(chunk.Data)
+	}
+
+	for i := range snapshotFiles {
+		snapshotFiles[i].Data = files[snapshotFiles[i].Path].Bytes()
+	}
+
+	logger.Info("installing snapshot of primary", "logId", logId, "seq", seq, "files", len(snapshotFiles))
+
+	if err := installSnapshot(gnoSQL, snapshotFiles); err != nil {
+		return "", 0, err
+	}
+
+	gnoSQL.ReplicaStatus.Bootstrapped(logId, seq)
+
+	return logId, seq, nil
+}
+
+// installSnapshot replaces every local database with the snapshot files
+func installSnapshot(gnoSQL *in_memory_database.GnoSQL, snapshotFiles []in_memory_database.SnapshotFile) error {
+	for _, snapshotFile := range snapshotFiles {
+		if !filepath.IsLocal(snapshotFile.Path) {
+			return fmt.Errorf("snapshot file %v is outside of the database folder", snapshotFile.Path)
+		}
+	}
+
+	gnoSQL.ResetAllDBs()
+
+	if err := in_memory_database.WriteSnapshotFiles(gnoSQL.Settings.DataPath, snapshotFiles); err != nil {
+		return err
+	}
+
+	gnoSQL.LoadAllDBs()
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	pb "gnosql/proto"
+	"gnosql/src/config"
+	"gnosql/src/global_constants"
+	"gnosql/src/grpc_handler"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/service"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// newTestGnoSQL creates a GnoSQL over its own temporary data folder
+func newTestGnoSQL(t *testing.T) *in_memory_database.GnoSQL {
+	settings := config.Default()
+	settings.DataPath = t.TempDir()
+
+	gnoSQL := in_memory_database.CreateGnoSQL(&settings)
+	gnoSQL.LoadAllDBs()
+
+	return gnoSQL
+}
+
+// startPrimary serves the replication service of gnoSQL and returns its address
+func startPrimary(t *testing.T, gnoSQL *in_memory_database.GnoSQL) (string, *grpc.Server) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterReplicationServiceServer(server, &grpc_handler.ReplicationServer{GnoSQL: gnoSQL})
+
+	go server.Serve(listener)
+
+	return listener.Addr().String(), server
+}
+
+// documentNames returns docId: name of every document of a collection
+func documentNames(t *testing.T, gnoSQL *in_memory_database.GnoSQL, databaseName string, collectionName string) map[string]string {
+	result, err := service.DocumentGetAll(context.Background(), gnoSQL, databaseName, collectionName)
+	if err != nil {
+		return nil
+	}
+
+	var names = make(map[string]string)
+	for _, document := range result.Data {
+		names[fmt.Sprint(document[global_constants.DOC_ID])] = fmt.Sprint(document["name"])
+	}
+	return names
+}
+
+// waitFor polls condition until it holds or the timeout is reached
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+
+	var deadline = time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %v", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func createDocument(t *testing.T, gnoSQL *in_memory_database.GnoSQL, databaseName string, name string) string {
+	t.Helper()
+
+	result, err := service.DocumentCreate(context.Background(), gnoSQL, databaseName, "users",
+		in_memory_database.Document{"name": name, "city": "paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fmt.Sprint(result.Data[global_constants.DOC_ID])
+}
+
+// TestReplicaConverges bootstraps a replica from the snapshot of a primary in the same process, then checks that the
+// writes made on the primary after the snapshot reach it through the mutation stream
+func TestReplicaConverges(t *testing.T) {
+	var ctx = context.Background()
+	var databaseNames = []string{"shop", "stock"}
+	var storageEngines = []string{global_constants.STORAGE_ENGINE_GOB, global_constants.STORAGE_ENGINE_KV}
+
+	primary := newTestGnoSQL(t)
+
+	for i, databaseName := range databaseNames {
+		_, err := service.CreateDatabase(ctx, primary, databaseName,
+			[]in_memory_database.CollectionInput{{CollectionName: "users", IndexKeys: []string{"city"}}},
+			in_memory_database.DatabaseConfigInput{StorageEngine: storageEngines[i]})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var snapshotIds = make(map[string][]string)
+	for _, databaseName := range databaseNames {
+		for _, name := range []string{"alice", "bob", "carol"} {
+			snapshotIds[databaseName] = append(snapshotIds[databaseName], createDocument(t, primary, databaseName, name))
+		}
+		waitFor(t, "primary to apply the writes", func() bool {
+			return len(documentNames(t, primary, databaseName, "users")) == 3
+		})
+	}
+
+	address, server := startPrimary(t, primary)
+
+	replica := newTestGnoSQL(t)
+	replica.StartAsReplica(address)
+
+	var replicaStopped = make(chan error, 1)
+	go func() {
+		replicaStopped <- runReplica(replica, address)
+	}()
+
+	t.Cleanup(func() {
+		server.Stop()
+		<-replicaStopped
+
+		replica.Shutdown()
+		primary.Shutdown()
+	})
+
+	waitFor(t, "replica to install the snapshot", func() bool {
+		return replica.GetReplicationStats().Connected
+	})
+
+	for _, databaseName := range databaseNames {
+		if names := documentNames(t, replica, databaseName, "users"); len(names) != 3 {
+			t.Fatalf("%v: snapshot holds %v documents, expected 3", databaseName, len(names))
+		}
+	}
+
+	// writes after the snapshot, only the mutation stream carries them
+	for _, databaseName := range databaseNames {
+		createDocument(t, primary, databaseName, "dave")
+
+		_, err := service.DocumentUpdate(ctx, primary, databaseName, "users", snapshotIds[databaseName][0],
+			in_memory_database.Document{"name": "alice2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := service.DocumentDelete(ctx, primary, databaseName, "users", snapshotIds[databaseName][1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := service.CreateDatabase(ctx, primary, "orders",
+		[]in_memory_database.CollectionInput{{CollectionName: "users"}}, in_memory_database.DatabaseConfigInput{}); err != nil {
+		t.Fatal(err)
+	}
+	createDocument(t, primary, "orders", "erin")
+
+	for _, databaseName := range append(databaseNames, "orders") {
+		waitFor(t, databaseName+" to converge", func() bool {
+			primaryNames := documentNames(t, primary, databaseName, "users")
+			replicaNames := documentNames(t, replica, databaseName, "users")
+
+			if len(primaryNames) == 0 || len(primaryNames) != len(replicaNames) {
+				return false
+			}
+			for id, name := range primaryNames {
+				if replicaNames[id] != name {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	for _, databaseName := range databaseNames {
+		names := documentNames(t, replica, databaseName, "users")
+
+		if names[snapshotIds[databaseName][0]] != "alice2" {
+			t.Errorf("%v: update after the snapshot not replicated", databaseName)
+		}
+		if _, exists := names[snapshotIds[databaseName][1]]; exists {
+			t.Errorf("%v: delete after the snapshot not replicated", databaseName)
+		}
+	}
+
+	// schema changes reach the replica while requests read its databases
+	if _, err := service.DeleteCollections(ctx, primary, "shop", []string{"users"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.DeleteDatabase(ctx, primary, "orders"); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "replica to delete the collection and the database", func() bool {
+		documentNames(t, replica, "stock", "users")
+		return replica.GetDB("shop").GetColl("users") == nil && replica.GetDB("orders") == nil
+	})
+
+	waitFor(t, "replica to reach the last seq of the primary", func() bool {
+		return replica.GetReplicationStats().ReplicatedSeq == primary.ReplicationLog.LastSeq()
+	})
+}
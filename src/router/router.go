@@ -1,18 +1,28 @@
 package router
 
 import (
+	"errors"
 	_ "gnosql/docs"
+	"gnosql/src/auth"
+	"gnosql/src/global_constants"
 	"gnosql/src/handler"
 	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
+	"gnosql/src/metrics"
 	"gnosql/src/seed"
 	"gnosql/src/service"
 
 	// "html/template"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+var logger = logging.Get(global_constants.LOG_SERVER)
+
 type FilterQuery struct {
 	DatabaseName   string `form:"databaseName"`
 	CollectionName string `form:"collectionName"`
@@ -21,13 +31,117 @@ type FilterQuery struct {
 }
 
 func RouterInit(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	ginRouter.Use(AssignRequestId())
+	ginRouter.Use(RecordMetrics())
+	ginRouter.Use(ReadinessGate(gnoSQL))
+	ginRouter.Use(Authenticate(gnoSQL))
+
 	SeedRoute(ginRouter, gnoSQL)
 	DatabaseRoutes(ginRouter, gnoSQL)
 	CollectionRoutes(ginRouter, gnoSQL)
 	DocumentRoutes(ginRouter, gnoSQL)
+	WebhookRoutes(ginRouter, gnoSQL)
+	ReplicationRoutes(ginRouter, gnoSQL)
+	ShardRoutes(ginRouter, gnoSQL)
+	HealthRoutes(ginRouter, gnoSQL)
+	MetricsRoutes(ginRouter)
+	AuthRoutes(ginRouter, gnoSQL)
+	UserRoutes(ginRouter, gnoSQL)
+	RoleRoutes(ginRouter, gnoSQL)
+	APIKeyRoutes(ginRouter, gnoSQL)
 	UIRoutes(ginRouter, gnoSQL)
 }
 
+// AssignRequestId gives every request an id, the one sent in X-Request-Id or a new one. The id is sent back in
+// X-Request-Id and carried by the request context, so the logs of the service and the database calls show it
+func AssignRequestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(global_constants.REQUEST_ID_HEADER)
+		if requestId == "" || len(requestId) > global_constants.REQUEST_ID_MAX_LENGTH {
+			requestId = logging.NewRequestId()
+		}
+
+		c.Header(global_constants.REQUEST_ID_HEADER, requestId)
+		c.Request = c.Request.WithContext(logging.WithRequestId(c.Request.Context(), requestId))
+
+		startedAt := time.Now()
+
+		c.Next()
+
+		logger.DebugContext(c.Request.Context(), "request", "method", c.Request.Method, "path", c.Request.URL.Path,
+			"status", c.Writer.Status(), "took", time.Since(startedAt), "clientIp", c.ClientIP())
+	}
+}
+
+// RecordMetrics counts and times every request by method, route and status code, requests refused by the
+// readiness gate or authentication included
+func RecordMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startedAt := time.Now()
+
+		c.Next()
+
+		// the route pattern, Ex: /document/add, so ids in paths don't make a series per request
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(startedAt).Seconds())
+	}
+}
+
+// ReadinessGate answers 503 until the databases are loaded, /health, /metrics and /swagger stay reachable meanwhile
+func ReadinessGate(gnoSQL *in_memory_database.GnoSQL) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+
+		if strings.HasPrefix(path, "/health/") || strings.HasPrefix(path, "/swagger/") || path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		if result, _ := service.GetLoadProgress(c.Request.Context(), gnoSQL); !result.Data.Ready {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, result)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Authenticate requires a token in the Authorization header, or an api key in the X-API-Key header, while
+// authentication is on, /health, /metrics, /swagger and /auth/login stay reachable without one
+func Authenticate(gnoSQL *in_memory_database.GnoSQL) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+
+		if !auth.IsEnabled() || strings.HasPrefix(path, "/health/") || strings.HasPrefix(path, "/swagger/") || path == "/auth/login" || path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		var claims auth.Claims
+		var err = errors.New(global_constants.AUTHENTICATION_REQUIRED_MSG)
+		if apiKey := c.GetHeader(global_constants.API_KEY_HEADER); apiKey != "" {
+			claims, err = service.AuthenticateAPIKey(c.Request.Context(), gnoSQL, apiKey)
+		} else if token := auth.BearerToken(c.GetHeader(global_constants.AUTHORIZATION_HEADER)); token != "" {
+			claims, err = auth.VerifyToken(token)
+		}
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, in_memory_database.Result{Error: err.Error()})
+			return
+		}
+
+		// the service checks the privileges of the caller from the request context
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+
+		c.Next()
+	}
+}
+
 func UIRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
 	ginRouter.GET("/gnosql-ui", func(c *gin.Context) {
 		c.HTML(http.StatusOK, "index.html", nil)
@@ -50,14 +164,14 @@ func UIRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
 		}
 
 		if len(filterQuery.CollectionName) > 0 {
-			result, _ := service.DocumentFilter(gnoSQL, filterQuery.DatabaseName, filterQuery.CollectionName, filter)
+			result, _ := service.DocumentFilter(c.Request.Context(), gnoSQL, filterQuery.DatabaseName, filterQuery.CollectionName, filter, false)
 
 			if len(result.Data) > 0 {
 				response = result.Data
 			}
 
 		} else {
-			result, _ := service.GetAllCollections(gnoSQL, filterQuery.DatabaseName)
+			result, _ := service.GetAllCollections(c.Request.Context(), gnoSQL, filterQuery.DatabaseName)
 
 			if len(result.Data) > 0 {
 
@@ -79,7 +193,27 @@ func UIRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
 // @Router       /generate-seed-data [get]
 func SeedRoute(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
 	ginRouter.GET("/generate-seed-data", func(c *gin.Context) {
-		var database *in_memory_database.Database = seed.SeedData(gnoSQL)
+		if gnoSQL.IsReadOnly() {
+			c.JSON(http.StatusBadRequest, gin.H{"status": global_constants.REPLICA_READ_ONLY_MSG})
+			return
+		}
+		if gnoSQL.Consensus != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": global_constants.RAFT_SEED_NOT_SUPPORTED_MSG})
+			return
+		}
+		if gnoSQL.ShardRouter != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": global_constants.ROUTER_NOT_SUPPORTED_MSG})
+			return
+		}
+		if err := service.Authorize(c.Request.Context(), gnoSQL, global_constants.GRANT_WILDCARD, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+			c.JSON(handler.GetResponse(nil, err))
+			return
+		}
+		database, err := seed.SeedData(gnoSQL)
+		if err != nil {
+			c.JSON(handler.GetResponse(nil, err))
+			return
+		}
 		if database == nil {
 			c.JSON(http.StatusBadRequest, gin.H{"status": "Seed database and routes exists already"})
 			return
@@ -113,6 +247,15 @@ func DatabaseRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
 			handler.LoadDatabaseToDisk(c, gnoSQL)
 		})
 
+		DatabaseRoutesGroup.GET("/backup", func(c *gin.Context) {
+			handler.BackupDatabase(c, gnoSQL)
+		})
+
+		// Re-encrypt database files with a new data key
+		DatabaseRoutesGroup.POST("/rotate-key", func(c *gin.Context) {
+			handler.RotateDatabaseKey(c, gnoSQL)
+		})
+
 	}
 }
 
@@ -138,6 +281,16 @@ func CollectionRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL)
 		CollectionRoutesGroup.POST("/stats", func(c *gin.Context) {
 			handler.CollectionStats(c, gnoSQL)
 		})
+
+		// Merge under-filled batches
+		CollectionRoutesGroup.POST("/compact", func(c *gin.Context) {
+			handler.CompactCollection(c, gnoSQL)
+		})
+
+		// Cap or turn off the query cache
+		CollectionRoutesGroup.POST("/query-cache", func(c *gin.Context) {
+			handler.SetQueryCache(c, gnoSQL)
+		})
 	}
 
 }
@@ -179,3 +332,157 @@ func DocumentRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
 	}
 
 }
+
+func WebhookRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/webhook"
+
+	WebhookRoutesGroup := ginRouter.Group(path)
+	{
+		WebhookRoutesGroup.POST("/add", func(c *gin.Context) {
+			handler.CreateWebhook(c, gnoSQL)
+		})
+
+		WebhookRoutesGroup.POST("/delete", func(c *gin.Context) {
+			handler.DeleteWebhook(c, gnoSQL)
+		})
+
+		WebhookRoutesGroup.POST("/get-all", func(c *gin.Context) {
+			handler.GetAllWebhooks(c, gnoSQL)
+		})
+
+		// Deliveries failed after all retries
+		WebhookRoutesGroup.POST("/dead-letters", func(c *gin.Context) {
+			handler.GetWebhookDeadLetters(c, gnoSQL)
+		})
+	}
+
+}
+
+func ReplicationRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/replication"
+
+	ReplicationRoutesGroup := ginRouter.Group(path)
+	{
+		ReplicationRoutesGroup.GET("/stats", func(c *gin.Context) {
+			handler.ReplicationStats(c, gnoSQL)
+		})
+	}
+
+}
+
+func ShardRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	ginRouter.GET("/shard-map", func(c *gin.Context) {
+		handler.GetShardMap(c, gnoSQL)
+	})
+}
+
+func HealthRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/health"
+
+	HealthRoutesGroup := ginRouter.Group(path)
+	{
+		// Orchestrators route traffic here only once it returns 200
+		HealthRoutesGroup.GET("/ready", func(c *gin.Context) {
+			handler.HealthReady(c, gnoSQL)
+		})
+	}
+
+}
+
+func MetricsRoutes(ginRouter *gin.Engine) {
+	// Prometheus scrapes here
+	ginRouter.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
+
+func AuthRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/auth"
+
+	AuthRoutesGroup := ginRouter.Group(path)
+	{
+		// Exchange a username and password for a token
+		AuthRoutesGroup.POST("/login", func(c *gin.Context) {
+			handler.Login(c, gnoSQL)
+		})
+	}
+
+}
+
+func UserRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/user"
+
+	UserRoutesGroup := ginRouter.Group(path)
+	{
+		UserRoutesGroup.POST("/add", func(c *gin.Context) {
+			handler.CreateUser(c, gnoSQL)
+		})
+
+		UserRoutesGroup.POST("/delete", func(c *gin.Context) {
+			handler.DeleteUser(c, gnoSQL)
+		})
+
+		UserRoutesGroup.GET("/get-all", func(c *gin.Context) {
+			handler.GetAllUsers(c, gnoSQL)
+		})
+
+		UserRoutesGroup.POST("/password", func(c *gin.Context) {
+			handler.SetUserPassword(c, gnoSQL)
+		})
+
+		// Replace the roles of a user
+		UserRoutesGroup.POST("/roles", func(c *gin.Context) {
+			handler.SetUserRoles(c, gnoSQL)
+		})
+	}
+
+}
+
+func RoleRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/role"
+
+	RoleRoutesGroup := ginRouter.Group(path)
+	{
+		RoleRoutesGroup.POST("/add", func(c *gin.Context) {
+			handler.CreateRole(c, gnoSQL)
+		})
+
+		RoleRoutesGroup.POST("/delete", func(c *gin.Context) {
+			handler.DeleteRole(c, gnoSQL)
+		})
+
+		RoleRoutesGroup.GET("/get-all", func(c *gin.Context) {
+			handler.GetAllRoles(c, gnoSQL)
+		})
+
+		// Add a grant to a role
+		RoleRoutesGroup.POST("/grant", func(c *gin.Context) {
+			handler.GrantRole(c, gnoSQL)
+		})
+
+		// Remove a grant from a role
+		RoleRoutesGroup.POST("/revoke", func(c *gin.Context) {
+			handler.RevokeRole(c, gnoSQL)
+		})
+	}
+
+}
+
+func APIKeyRoutes(ginRouter *gin.Engine, gnoSQL *in_memory_database.GnoSQL) {
+	path := "/api-key"
+
+	APIKeyRoutesGroup := ginRouter.Group(path)
+	{
+		APIKeyRoutesGroup.POST("/add", func(c *gin.Context) {
+			handler.CreateAPIKey(c, gnoSQL)
+		})
+
+		// Revoke a key, requests made with it are refused right away
+		APIKeyRoutesGroup.POST("/delete", func(c *gin.Context) {
+			handler.DeleteAPIKey(c, gnoSQL)
+		})
+
+		APIKeyRoutesGroup.GET("/get-all", func(c *gin.Context) {
+			handler.GetAllAPIKeys(c, gnoSQL)
+		})
+	}
+
+}
@@ -2,12 +2,16 @@ package seed
 
 import (
 	"fmt"
+	"gnosql/src/global_constants"
 	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
 	"math/rand"
 	"strconv"
 )
 
-func SeedData(gnoSQL *in_memory_database.GnoSQL) *in_memory_database.Database {
+var logger = logging.Get(global_constants.LOG_DATABASE)
+
+func SeedData(gnoSQL *in_memory_database.GnoSQL) (*in_memory_database.Database, error) {
 	testDBName := "test"
 
 	UserCollectionInput := in_memory_database.CollectionInput{
@@ -23,11 +27,14 @@ func SeedData(gnoSQL *in_memory_database.GnoSQL) *in_memory_database.Database {
 	collectionsInput := []in_memory_database.CollectionInput{UserCollectionInput, OrderCollectionInput}
 
 	if dbExists := gnoSQL.GetDB(testDBName); dbExists != nil {
-		fmt.Printf("\nSeed %s database already exists\n", testDBName)
-		return nil
+		logger.Info("seed database already exists", "database", testDBName)
+		return nil, nil
 	}
 
-	db := gnoSQL.CreateDB(testDBName, collectionsInput)
+	db, err := gnoSQL.CreateDB(testDBName, collectionsInput, in_memory_database.DatabaseConfigInput{})
+	if err != nil {
+		return nil, err
+	}
 
 	type City map[string]interface{}
 	type Pincode map[string]int
@@ -91,6 +98,6 @@ func SeedData(gnoSQL *in_memory_database.GnoSQL) *in_memory_database.Database {
 	// manually write seed test database to disk
 	go db.SaveDatabaseToFile()
 
-	return db
+	return db, nil
 
 }
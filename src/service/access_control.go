@@ -0,0 +1,581 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gnosql/src/auth"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"slices"
+)
+
+// privilegeRanks orders the privileges, a grant of a privilege allows the ones ranked below it
+var privilegeRanks = map[string]int{
+	global_constants.PRIVILEGE_READ:  1,
+	global_constants.PRIVILEGE_WRITE: 2,
+	global_constants.PRIVILEGE_ADMIN: 3,
+}
+
+// adminGrant is the only grant of the built in admin role
+var adminGrant = in_memory_database.Grant{
+	Database:   global_constants.GRANT_WILDCARD,
+	Collection: global_constants.GRANT_WILDCARD,
+	Privilege:  global_constants.PRIVILEGE_ADMIN,
+}
+
+// Authorize returns auth.ErrPermissionDenied unless a role of the caller grants privilege on CollectionName of
+// DatabaseName. An empty CollectionName asks for the privilege on the whole database, a "*" DatabaseName asks for
+// it on the server, like managing users
+func Authorize(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, CollectionName string, privilege string) error {
+	grants, allowAll, err := callerGrants(ctx, gnoSQL)
+	if err != nil || allowAll {
+		return err
+	}
+
+	for _, grant := range grants {
+		if grantAllows(grant, DatabaseName, CollectionName, privilege) {
+			return nil
+		}
+	}
+
+	return auth.ErrPermissionDenied
+}
+
+// authorizeServerAdmin is Authorize for requests on the whole server
+func authorizeServerAdmin(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) error {
+	return Authorize(ctx, gnoSQL, global_constants.GRANT_WILDCARD, "", global_constants.PRIVILEGE_ADMIN)
+}
+
+// visibleTo returns a filter keeping the databases, or the collections of DatabaseName when it is given, the caller
+// holds any grant on
+func visibleTo(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (func(name string) bool, error) {
+	grants, allowAll, err := callerGrants(ctx, gnoSQL)
+	if err != nil || allowAll {
+		return func(name string) bool { return true }, err
+	}
+
+	return func(name string) bool {
+		for _, grant := range grants {
+			if DatabaseName == "" && (grant.Database == global_constants.GRANT_WILDCARD || grant.Database == name) {
+				return true
+			}
+			if DatabaseName != "" && grantAllows(grant, DatabaseName, name, global_constants.PRIVILEGE_READ) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// authorizeConnect allows connecting to a database with any grant on it, creating the database or collections
+// missing from it needs the admin privilege
+func authorizeConnect(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput) error {
+	var collectionNames []string
+	var err error
+
+	if gnoSQL.ShardRouter != nil {
+		collectionNames, err = gnoSQL.ShardRouter.GetAllCollections(DatabaseName)
+	} else if db := gnoSQL.GetDB(DatabaseName); db != nil {
+		collectionNames = db.GetCollectionNames()
+	} else {
+		err = errors.New(global_constants.DATABASE_NOT_FOUND_MSG)
+	}
+
+	var isCreating = err != nil
+	for _, collectionInput := range collectionsInput {
+		isCreating = isCreating || !slices.Contains(collectionNames, collectionInput.CollectionName)
+	}
+
+	if isCreating {
+		return Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN)
+	}
+
+	isVisible, err := visibleTo(ctx, gnoSQL, "")
+	if err != nil {
+		return err
+	}
+	if !isVisible(DatabaseName) {
+		return auth.ErrPermissionDenied
+	}
+	return nil
+}
+
+func filterNames(names []string, isVisible func(name string) bool) []string {
+	var visibleNames = make([]string, 0)
+	for _, name := range names {
+		if isVisible(name) {
+			visibleNames = append(visibleNames, name)
+		}
+	}
+	return visibleNames
+}
+
+// callerGrants returns the grants of the roles of the caller. Every request is allowed while authentication is off,
+// for the calls nodes make to each other and for users with the admin role
+func callerGrants(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) ([]in_memory_database.Grant, bool, error) {
+	if !auth.IsEnabled() {
+		return nil, true, nil
+	}
+
+	claims, ok := auth.ClaimsFrom(ctx)
+	if !ok {
+		return nil, false, auth.ErrPermissionDenied
+	}
+
+	if claims.Username == global_constants.NODE_USERNAME {
+		return nil, true, nil
+	}
+
+	// an api key holds its grants itself, a revoked key isn't found
+	if claims.APIKeyId != "" {
+		apiKey, exists := findAPIKey(gnoSQL, claims.APIKeyId)
+		if !exists {
+			return nil, false, auth.ErrPermissionDenied
+		}
+		return apiKeyGrants(apiKey), false, nil
+	}
+
+	user, exists := findUser(gnoSQL, claims.Username)
+	if !exists {
+		return nil, false, auth.ErrPermissionDenied
+	}
+
+	var grants []in_memory_database.Grant
+
+	for _, roleName := range userRoles(user) {
+		if roleName == global_constants.ADMIN_ROLE_NAME {
+			return nil, true, nil
+		}
+		if role, exists := findRole(gnoSQL, roleName); exists {
+			grants = append(grants, roleGrants(role)...)
+		}
+	}
+
+	return grants, false, nil
+}
+
+// grantAllows reports whether grant gives privilege on CollectionName of DatabaseName, a grant without a collection
+// or with "*" covers the whole database
+func grantAllows(grant in_memory_database.Grant, DatabaseName string, CollectionName string, privilege string) bool {
+	if grant.Database != global_constants.GRANT_WILDCARD && grant.Database != DatabaseName {
+		return false
+	}
+
+	if grant.Collection != "" && grant.Collection != global_constants.GRANT_WILDCARD && grant.Collection != CollectionName {
+		return false
+	}
+
+	return privilegeRanks[grant.Privilege] >= privilegeRanks[privilege]
+}
+
+func UserCreate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.UserCreateRequest) (in_memory_database.UserUpdateResult, error) {
+	var result = in_memory_database.UserUpdateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if request.Username == "" || request.Username == global_constants.NODE_USERNAME {
+		return result, errors.New(global_constants.USERNAME_RESERVED_MSG)
+	}
+
+	if request.Password == "" {
+		return result, errors.New(global_constants.PASSWORD_REQUIRED_MSG)
+	}
+
+	if _, exists := findUser(gnoSQL, request.Username); exists {
+		return result, errors.New(global_constants.USER_ALREADY_EXISTS_MSG)
+	}
+
+	if err := validateRoles(gnoSQL, request.Roles); err != nil {
+		return result, err
+	}
+
+	passwordHash, err := auth.HashPassword(request.Password)
+	if err != nil {
+		return result, err
+	}
+
+	user := in_memory_database.Document{
+		global_constants.DOC_ID:             common.Generate16DigitUUID(),
+		global_constants.USER_USERNAME:      request.Username,
+		global_constants.USER_PASSWORD_HASH: passwordHash,
+		global_constants.USER_ROLES:         toDocumentValue(append([]string{}, request.Roles...)),
+	}
+
+	if err := submitSystemEvent(ctx, gnoSQL, global_constants.USERS_COLLECTION_NAME, GenerateCreateEvent(user)); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.USER_CREATE_SUCCESS_MSG
+
+	return result, nil
+}
+
+func UserDelete(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, username string) (in_memory_database.UserUpdateResult, error) {
+	var result = in_memory_database.UserUpdateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	user, exists := findUser(gnoSQL, username)
+	if !exists {
+		return result, errors.New(global_constants.USER_NOT_FOUND_MSG)
+	}
+
+	deleteEvent := GenerateDeleteEvent(user[global_constants.DOC_ID].(string))
+
+	if err := submitSystemEvent(ctx, gnoSQL, global_constants.USERS_COLLECTION_NAME, deleteEvent); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.USER_DELETE_SUCCESS_MSG
+
+	return result, nil
+}
+
+// UserGetAll returns every user with its roles, password hashes stay on the server
+func UserGetAll(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.UserGetAllResult, error) {
+	var result = in_memory_database.UserGetAllResult{Data: make([]in_memory_database.UserResult, 0)}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	_, users := gnoSQL.GetDatabaseAndCollection(global_constants.SYSTEM_DATABASE_NAME, global_constants.USERS_COLLECTION_NAME)
+
+	if err := validateCollection(users); err != nil {
+		return result, err
+	}
+
+	for _, user := range users.GetAllData() {
+		username, _ := user[global_constants.USER_USERNAME].(string)
+		result.Data = append(result.Data, in_memory_database.UserResult{Username: username, Roles: userRoles(user)})
+	}
+
+	return result, nil
+}
+
+// UserSetPassword changes the password of a user, users can change their own password without the admin privilege
+func UserSetPassword(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.UserPasswordRequest) (in_memory_database.UserUpdateResult, error) {
+	var result = in_memory_database.UserUpdateResult{}
+
+	if claims, _ := auth.ClaimsFrom(ctx); claims.Username != request.Username || request.Username == "" {
+		if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+			return result, err
+		}
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if request.Password == "" {
+		return result, errors.New(global_constants.PASSWORD_REQUIRED_MSG)
+	}
+
+	user, exists := findUser(gnoSQL, request.Username)
+	if !exists {
+		return result, errors.New(global_constants.USER_NOT_FOUND_MSG)
+	}
+
+	passwordHash, err := auth.HashPassword(request.Password)
+	if err != nil {
+		return result, err
+	}
+
+	if err := updateUser(ctx, gnoSQL, user, global_constants.USER_PASSWORD_HASH, passwordHash); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.USER_UPDATE_SUCCESS_MSG
+
+	return result, nil
+}
+
+// UserSetRoles replaces the roles of a user
+func UserSetRoles(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.UserRolesRequest) (in_memory_database.UserUpdateResult, error) {
+	var result = in_memory_database.UserUpdateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	user, exists := findUser(gnoSQL, request.Username)
+	if !exists {
+		return result, errors.New(global_constants.USER_NOT_FOUND_MSG)
+	}
+
+	if err := validateRoles(gnoSQL, request.Roles); err != nil {
+		return result, err
+	}
+
+	if err := updateUser(ctx, gnoSQL, user, global_constants.USER_ROLES, toDocumentValue(append([]string{}, request.Roles...))); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.USER_UPDATE_SUCCESS_MSG
+
+	return result, nil
+}
+
+func RoleCreate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.RoleCreateRequest) (in_memory_database.RoleUpdateResult, error) {
+	var result = in_memory_database.RoleUpdateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if request.Name == global_constants.ADMIN_ROLE_NAME {
+		return result, errors.New(global_constants.ROLE_BUILT_IN_MSG)
+	}
+
+	if _, exists := findRole(gnoSQL, request.Name); exists || request.Name == "" {
+		return result, errors.New(global_constants.ROLE_ALREADY_EXISTS_MSG)
+	}
+
+	for _, grant := range request.Grants {
+		if err := validateGrant(grant); err != nil {
+			return result, err
+		}
+	}
+
+	role := in_memory_database.Document{
+		global_constants.DOC_ID:      common.Generate16DigitUUID(),
+		global_constants.ROLE_NAME:   request.Name,
+		global_constants.ROLE_GRANTS: toDocumentValue(append([]in_memory_database.Grant{}, request.Grants...)),
+	}
+
+	if err := submitSystemEvent(ctx, gnoSQL, global_constants.ROLES_COLLECTION_NAME, GenerateCreateEvent(role)); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.ROLE_CREATE_SUCCESS_MSG
+
+	return result, nil
+}
+
+// RoleDelete deletes a role and takes it away from the users holding it
+func RoleDelete(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, name string) (in_memory_database.RoleUpdateResult, error) {
+	var result = in_memory_database.RoleUpdateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	role, exists := findRole(gnoSQL, name)
+	if !exists {
+		return result, errors.New(global_constants.ROLE_NOT_FOUND_MSG)
+	}
+
+	_, users := gnoSQL.GetDatabaseAndCollection(global_constants.SYSTEM_DATABASE_NAME, global_constants.USERS_COLLECTION_NAME)
+
+	if err := validateCollection(users); err != nil {
+		return result, err
+	}
+
+	for _, user := range users.GetAllData() {
+		var roles = make([]string, 0)
+		for _, roleName := range userRoles(user) {
+			if roleName != name {
+				roles = append(roles, roleName)
+			}
+		}
+
+		if len(roles) != len(userRoles(user)) {
+			if err := updateUser(ctx, gnoSQL, user, global_constants.USER_ROLES, toDocumentValue(roles)); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	deleteEvent := GenerateDeleteEvent(role[global_constants.DOC_ID].(string))
+
+	if err := submitSystemEvent(ctx, gnoSQL, global_constants.ROLES_COLLECTION_NAME, deleteEvent); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.ROLE_DELETE_SUCCESS_MSG
+
+	return result, nil
+}
+
+// RoleGetAll returns every role with its grants, the built in admin role included
+func RoleGetAll(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.RoleGetAllResult, error) {
+	var result = in_memory_database.RoleGetAllResult{
+		Data: []in_memory_database.RoleResult{
+			{Name: global_constants.ADMIN_ROLE_NAME, Grants: []in_memory_database.Grant{adminGrant}},
+		},
+	}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	_, roles := gnoSQL.GetDatabaseAndCollection(global_constants.SYSTEM_DATABASE_NAME, global_constants.ROLES_COLLECTION_NAME)
+
+	if err := validateCollection(roles); err != nil {
+		return result, err
+	}
+
+	for _, role := range roles.GetAllData() {
+		name, _ := role[global_constants.ROLE_NAME].(string)
+		result.Data = append(result.Data, in_memory_database.RoleResult{Name: name, Grants: roleGrants(role)})
+	}
+
+	return result, nil
+}
+
+// RoleGrant adds a grant to a role, a grant the role holds already is left as it is
+func RoleGrant(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.RoleGrantRequest) (in_memory_database.RoleUpdateResult, error) {
+	return updateRoleGrants(ctx, gnoSQL, request, func(grants []in_memory_database.Grant) []in_memory_database.Grant {
+		for _, grant := range grants {
+			if grant == request.Grant {
+				return grants
+			}
+		}
+		return append(grants, request.Grant)
+	})
+}
+
+// RoleRevoke removes a grant from a role
+func RoleRevoke(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.RoleGrantRequest) (in_memory_database.RoleUpdateResult, error) {
+	return updateRoleGrants(ctx, gnoSQL, request, func(grants []in_memory_database.Grant) []in_memory_database.Grant {
+		var remainingGrants = make([]in_memory_database.Grant, 0)
+		for _, grant := range grants {
+			if grant != request.Grant {
+				remainingGrants = append(remainingGrants, grant)
+			}
+		}
+		return remainingGrants
+	})
+}
+
+func updateRoleGrants(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.RoleGrantRequest,
+	change func([]in_memory_database.Grant) []in_memory_database.Grant) (in_memory_database.RoleUpdateResult, error) {
+
+	var result = in_memory_database.RoleUpdateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if request.Name == global_constants.ADMIN_ROLE_NAME {
+		return result, errors.New(global_constants.ROLE_BUILT_IN_MSG)
+	}
+
+	if err := validateGrant(request.Grant); err != nil {
+		return result, err
+	}
+
+	role, exists := findRole(gnoSQL, request.Name)
+	if !exists {
+		return result, errors.New(global_constants.ROLE_NOT_FOUND_MSG)
+	}
+
+	grants := change(roleGrants(role))
+
+	if err := updateSystemDocument(ctx, gnoSQL, global_constants.ROLES_COLLECTION_NAME, role, global_constants.ROLE_GRANTS, toDocumentValue(grants)); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.ROLE_UPDATE_SUCCESS_MSG
+
+	return result, nil
+}
+
+func findRole(gnoSQL *in_memory_database.GnoSQL, name string) (in_memory_database.Document, bool) {
+	return findSystemDocument(gnoSQL, global_constants.ROLES_COLLECTION_NAME, global_constants.ROLE_NAME, name)
+}
+
+func updateUser(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, user in_memory_database.Document, key string, value interface{}) error {
+	return updateSystemDocument(ctx, gnoSQL, global_constants.USERS_COLLECTION_NAME, user, key, value)
+}
+
+// updateSystemDocument sets key of a stored document of a system collection, the change is made on a copy and
+// applied by the mutation worker
+func updateSystemDocument(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, CollectionName string, document in_memory_database.Document, key string, value interface{}) error {
+	var updatedDocument = make(in_memory_database.Document)
+
+	for documentKey, documentValue := range document {
+		updatedDocument[documentKey] = documentValue
+	}
+	updatedDocument[key] = value
+
+	return submitSystemEvent(ctx, gnoSQL, CollectionName, GenerateUpdateEvent(updatedDocument))
+}
+
+func userRoles(user in_memory_database.Document) []string {
+	var roles = make([]string, 0)
+	fromDocumentValue(user[global_constants.USER_ROLES], &roles)
+	return roles
+}
+
+func roleGrants(role in_memory_database.Document) []in_memory_database.Grant {
+	var grants = make([]in_memory_database.Grant, 0)
+	fromDocumentValue(role[global_constants.ROLE_GRANTS], &grants)
+	return grants
+}
+
+// toDocumentValue turns roles and grants into the []interface{} and map[string]interface{} values a document
+// holds after a JSON request, so they are stored and replicated like any other document
+func toDocumentValue(value interface{}) interface{} {
+	var documentValue interface{}
+
+	if jsonData, err := json.Marshal(value); err == nil {
+		json.Unmarshal(jsonData, &documentValue)
+	}
+
+	return documentValue
+}
+
+// fromDocumentValue reads roles or grants back from a document, value is left as it is when the field is missing
+// or empty, gob reads an empty list back as nil
+func fromDocumentValue(documentValue interface{}, value interface{}) {
+	if jsonData, err := json.Marshal(documentValue); err == nil && string(jsonData) != "null" {
+		json.Unmarshal(jsonData, value)
+	}
+}
+
+func validateRoles(gnoSQL *in_memory_database.GnoSQL, roles []string) error {
+	for _, roleName := range roles {
+		if _, exists := findRole(gnoSQL, roleName); !exists && roleName != global_constants.ADMIN_ROLE_NAME {
+			return errors.New(global_constants.ROLE_NOT_FOUND_MSG)
+		}
+	}
+	return nil
+}
+
+func validateGrant(grant in_memory_database.Grant) error {
+	if _, exists := privilegeRanks[grant.Privilege]; !exists || grant.Database == "" {
+		return errors.New(global_constants.INVALID_GRANT_MSG)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gnosql/src/auth"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/logging"
+	"sync"
+	"time"
+)
+
+var authLogger = logging.Get(global_constants.LOG_AUTH)
+
+// when lastUsedAt of each key was written, so a busy key doesn't write its document on every request
+var apiKeyLastUsed = struct {
+	mu sync.Mutex
+	at map[string]time.Time
+}{at: make(map[string]time.Time)}
+
+// AuthenticateAPIKey checks an api key sent with a request and returns the claims the service authorizes it with
+func AuthenticateAPIKey(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, key string) (auth.Claims, error) {
+	var claims = auth.Claims{}
+
+	keyId, isValid := auth.APIKeyId(key)
+	if !isValid {
+		return claims, errors.New(global_constants.INVALID_API_KEY_MSG)
+	}
+
+	apiKey, exists := findAPIKey(gnoSQL, keyId)
+
+	secretHash, _ := apiKey[global_constants.API_KEY_SECRET_HASH].(string)
+	if !exists || !auth.CheckAPIKey(secretHash, key) {
+		return claims, errors.New(global_constants.INVALID_API_KEY_MSG)
+	}
+
+	if expiresAt, _ := apiKey[global_constants.API_KEY_EXPIRES_AT].(string); expiresAt != "" {
+		if expiryTime, err := time.Parse(time.RFC3339, expiresAt); err != nil || !time.Now().Before(expiryTime) {
+			return claims, errors.New(global_constants.API_KEY_EXPIRED_MSG)
+		}
+	}
+
+	recordAPIKeyUse(ctx, gnoSQL, apiKey)
+
+	claims.APIKeyId = keyId
+
+	return claims, nil
+}
+
+// recordAPIKeyUse sets lastUsedAt of a key, at most once per API_KEY_LAST_USED_INTERVAL. Replicas can't write,
+// keys used on a replica keep the lastUsedAt of the primary
+func recordAPIKeyUse(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, apiKey in_memory_database.Document) {
+	if gnoSQL.IsReadOnly() {
+		return
+	}
+
+	keyId, _ := apiKey[global_constants.API_KEY_ID].(string)
+	now := time.Now()
+
+	apiKeyLastUsed.mu.Lock()
+	if now.Sub(apiKeyLastUsed.at[keyId]) < global_constants.API_KEY_LAST_USED_INTERVAL {
+		apiKeyLastUsed.mu.Unlock()
+		return
+	}
+	apiKeyLastUsed.at[keyId] = now
+	apiKeyLastUsed.mu.Unlock()
+
+	go func() {
+		err := updateSystemDocument(ctx, gnoSQL, global_constants.API_KEYS_COLLECTION_NAME, apiKey,
+			global_constants.API_KEY_LAST_USED_AT, now.UTC().Format(time.RFC3339))
+		if err != nil {
+			authLogger.ErrorContext(ctx, "api key use not recorded", "keyId", keyId, "error", err)
+		}
+	}()
+}
+
+// APIKeyCreate creates an api key with grants like a role, the key is returned once and only a hash of it is kept
+func APIKeyCreate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.APIKeyCreateRequest) (in_memory_database.APIKeyCreateResult, error) {
+	var result = in_memory_database.APIKeyCreateResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if request.Name == "" {
+		return result, errors.New(global_constants.API_KEY_NAME_REQUIRED_MSG)
+	}
+
+	for _, grant := range request.Grants {
+		if err := validateGrant(grant); err != nil {
+			return result, err
+		}
+	}
+
+	var expiresAt = ""
+	if request.ExpiresAt != "" {
+		expiryTime, err := time.Parse(time.RFC3339, request.ExpiresAt)
+		if err != nil {
+			return result, err
+		}
+		expiresAt = expiryTime.UTC().Format(time.RFC3339)
+	}
+
+	keyId, key := auth.GenerateAPIKey()
+
+	apiKey := in_memory_database.Document{
+		global_constants.DOC_ID:               common.Generate16DigitUUID(),
+		global_constants.API_KEY_ID:           keyId,
+		global_constants.API_KEY_NAME:         request.Name,
+		global_constants.API_KEY_SECRET_HASH:  auth.HashAPIKey(key),
+		global_constants.API_KEY_GRANTS:       toDocumentValue(append([]in_memory_database.Grant{}, request.Grants...)),
+		global_constants.API_KEY_EXPIRES_AT:   expiresAt,
+		global_constants.API_KEY_LAST_USED_AT: "",
+	}
+
+	if err := submitSystemEvent(ctx, gnoSQL, global_constants.API_KEYS_COLLECTION_NAME, GenerateCreateEvent(apiKey)); err != nil {
+		return result, err
+	}
+
+	result.Data = in_memory_database.APIKeyCreated{KeyId: keyId, Key: key}
+
+	return result, nil
+}
+
+// APIKeyDelete revokes an api key, requests made with it are refused from then on
+func APIKeyDelete(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, keyId string) (in_memory_database.APIKeyDeleteResult, error) {
+	var result = in_memory_database.APIKeyDeleteResult{}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	apiKey, exists := findAPIKey(gnoSQL, keyId)
+	if !exists {
+		return result, errors.New(global_constants.API_KEY_NOT_FOUND_MSG)
+	}
+
+	deleteEvent := GenerateDeleteEvent(apiKey[global_constants.DOC_ID].(string))
+
+	if err := submitSystemEvent(ctx, gnoSQL, global_constants.API_KEYS_COLLECTION_NAME, deleteEvent); err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.API_KEY_DELETE_SUCCESS_MSG
+
+	return result, nil
+}
+
+// APIKeyGetAll returns every api key with its grants and timestamps, the keys themselves aren't kept
+func APIKeyGetAll(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.APIKeyGetAllResult, error) {
+	var result = in_memory_database.APIKeyGetAllResult{Data: make([]in_memory_database.APIKeyResult, 0)}
+
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	_, apiKeys := gnoSQL.GetDatabaseAndCollection(global_constants.SYSTEM_DATABASE_NAME, global_constants.API_KEYS_COLLECTION_NAME)
+
+	if err := validateCollection(apiKeys); err != nil {
+		return result, err
+	}
+
+	for _, apiKey := range apiKeys.GetAllData() {
+		var apiKeyResult = in_memory_database.APIKeyResult{Grants: apiKeyGrants(apiKey)}
+
+		apiKeyResult.KeyId, _ = apiKey[global_constants.API_KEY_ID].(string)
+		apiKeyResult.Name, _ = apiKey[global_constants.API_KEY_NAME].(string)
+		apiKeyResult.CreatedAt, _ = apiKey[global_constants.DOC_CREATED_AT].(string)
+		apiKeyResult.ExpiresAt, _ = apiKey[global_constants.API_KEY_EXPIRES_AT].(string)
+		apiKeyResult.LastUsedAt, _ = apiKey[global_constants.API_KEY_LAST_USED_AT].(string)
+
+		result.Data = append(result.Data, apiKeyResult)
+	}
+
+	return result, nil
+}
+
+func findAPIKey(gnoSQL *in_memory_database.GnoSQL, keyId string) (in_memory_database.Document, bool) {
+	return findSystemDocument(gnoSQL, global_constants.API_KEYS_COLLECTION_NAME, global_constants.API_KEY_ID, keyId)
+}
+
+func apiKeyGrants(apiKey in_memory_database.Document) []in_memory_database.Grant {
+	var grants = make([]in_memory_database.Grant, 0)
+	fromDocumentValue(apiKey[global_constants.API_KEY_GRANTS], &grants)
+	return grants
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gnosql/src/auth"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/raft"
+	"time"
+)
+
+// compared against when a username doesn't exist, so a login takes as long for unknown users
+var unknownUserHash, _ = auth.HashPassword(auth.GeneratePassword())
+
+// Login checks the password of a user and returns a token for it
+func Login(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, username string, password string) (in_memory_database.LoginResult, error) {
+	var result = in_memory_database.LoginResult{}
+
+	user, exists := findUser(gnoSQL, username)
+
+	passwordHash, _ := user[global_constants.USER_PASSWORD_HASH].(string)
+	if !exists {
+		passwordHash = unknownUserHash
+	}
+
+	if !auth.CheckPassword(passwordHash, password) || !exists {
+		return result, errors.New(global_constants.INVALID_CREDENTIALS_MSG)
+	}
+
+	token, err := auth.IssueToken(username)
+	if err != nil {
+		return result, err
+	}
+
+	result.Data = token
+
+	return result, nil
+}
+
+// BootstrapAdmin creates the system database and an admin user when there are no users yet. It returns the password
+// of the admin when it was generated, adminPassword is used when given. In a raft replica group the admin is created
+// by the leader once it applied every entry in its log
+func BootstrapAdmin(gnoSQL *in_memory_database.GnoSQL, adminPassword string) (string, error) {
+	var ctx = context.Background()
+
+	for gnoSQL.Consensus != nil && !isCaughtUpLeader(gnoSQL.Consensus.Stats()) {
+		time.Sleep(global_constants.RAFT_HEARTBEAT_INTERVAL)
+	}
+
+	var collectionsInput = []in_memory_database.CollectionInput{
+		{CollectionName: global_constants.USERS_COLLECTION_NAME, IndexKeys: []string{global_constants.USER_USERNAME}},
+		{CollectionName: global_constants.ROLES_COLLECTION_NAME, IndexKeys: []string{global_constants.ROLE_NAME}},
+		{CollectionName: global_constants.API_KEYS_COLLECTION_NAME, IndexKeys: []string{global_constants.API_KEY_ID}},
+	}
+
+	if db := gnoSQL.GetDB(global_constants.SYSTEM_DATABASE_NAME); db == nil {
+		entry := in_memory_database.ReplicationEntry{
+			DatabaseName:     global_constants.SYSTEM_DATABASE_NAME,
+			Event:            in_memory_database.Event{Type: global_constants.EVENT_CREATE_DATABASE},
+			CollectionsInput: collectionsInput,
+		}
+
+		var createErr error
+		err := submitEntry(gnoSQL, entry, func() {
+			_, createErr = gnoSQL.CreateDB(global_constants.SYSTEM_DATABASE_NAME, collectionsInput, in_memory_database.DatabaseConfigInput{})
+		})
+		if err == nil {
+			err = createErr
+		}
+		if err != nil {
+			return "", err
+		}
+	} else {
+		// system database created by an older version, before roles or api keys
+		var missingCollections []in_memory_database.CollectionInput
+		for _, collectionInput := range collectionsInput {
+			if db.GetColl(collectionInput.CollectionName) == nil {
+				missingCollections = append(missingCollections, collectionInput)
+			}
+		}
+
+		if len(missingCollections) > 0 {
+			entry := in_memory_database.ReplicationEntry{
+				DatabaseName:     global_constants.SYSTEM_DATABASE_NAME,
+				Event:            in_memory_database.Event{Type: global_constants.EVENT_CREATE_COLLECTIONS},
+				CollectionsInput: missingCollections,
+			}
+
+			err := submitEntry(gnoSQL, entry, func() {
+				db.CreateColls(missingCollections)
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	_, users := gnoSQL.GetDatabaseAndCollection(global_constants.SYSTEM_DATABASE_NAME, global_constants.USERS_COLLECTION_NAME)
+
+	if err := validateCollection(users); err != nil {
+		return "", err
+	}
+
+	if len(users.GetAllData()) > 0 {
+		// an admin created before access control has no roles yet
+		if admin, exists := findUser(gnoSQL, global_constants.ADMIN_USERNAME); exists && admin[global_constants.USER_ROLES] == nil {
+			return "", updateUser(ctx, gnoSQL, admin, global_constants.USER_ROLES, []interface{}{global_constants.ADMIN_ROLE_NAME})
+		}
+		return "", nil
+	}
+
+	var generatedPassword = ""
+	if adminPassword == "" {
+		adminPassword = auth.GeneratePassword()
+		generatedPassword = adminPassword
+	}
+
+	passwordHash, err := auth.HashPassword(adminPassword)
+	if err != nil {
+		return "", err
+	}
+
+	admin := in_memory_database.Document{
+		global_constants.DOC_ID:             common.Generate16DigitUUID(),
+		global_constants.USER_USERNAME:      global_constants.ADMIN_USERNAME,
+		global_constants.USER_PASSWORD_HASH: passwordHash,
+		global_constants.USER_ROLES:         []interface{}{global_constants.ADMIN_ROLE_NAME},
+	}
+
+	err = submitSystemEvent(ctx, gnoSQL, global_constants.USERS_COLLECTION_NAME, GenerateCreateEvent(admin))
+
+	return generatedPassword, err
+}
+
+// submitSystemEvent changes a collection of the system database like submitEvent, and saves it to disk right after
+// so a user or a role isn't lost when the server stops before the next sync
+func submitSystemEvent(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, CollectionName string, event in_memory_database.Event) error {
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName:   global_constants.SYSTEM_DATABASE_NAME,
+		CollectionName: CollectionName,
+		Event:          event,
+	}
+
+	return submitEntry(gnoSQL, entry, func() {
+		// one worker hands incoming requests to the collections, in order
+		gnoSQL.QueueIncomingRequests(ctx, entry.DatabaseName, entry.CollectionName, event, in_memory_database.Event{Type: global_constants.EVENT_SAVE_TO_DISK})
+	})
+}
+
+func findUser(gnoSQL *in_memory_database.GnoSQL, username string) (in_memory_database.Document, bool) {
+	return findSystemDocument(gnoSQL, global_constants.USERS_COLLECTION_NAME, global_constants.USER_USERNAME, username)
+}
+
+// findSystemDocument returns the document of a system collection whose key field is value, the stored document
+// is returned so it must not be changed
+func findSystemDocument(gnoSQL *in_memory_database.GnoSQL, CollectionName string, key string, value string) (in_memory_database.Document, bool) {
+	_, collection := gnoSQL.GetDatabaseAndCollection(global_constants.SYSTEM_DATABASE_NAME, CollectionName)
+
+	if collection == nil || value == "" {
+		return nil, false
+	}
+
+	for _, document := range collection.Filter(context.Background(), in_memory_database.MapInterface{key: value}) {
+		return document, true
+	}
+	return nil, false
+}
+
+func isCaughtUpLeader(stats raft.NodeStats) bool {
+	return stats.State == string(raft.Leader) && stats.LastApplied == stats.LastIndex
+}
+
+// validateDatabaseName returns an error for the system database, it is only reached through the auth requests
+func validateDatabaseName(DatabaseName string) error {
+	if DatabaseName == global_constants.SYSTEM_DATABASE_NAME {
+		return errors.New(global_constants.SYSTEM_DATABASE_RESERVED_MSG)
+	}
+	return nil
+}
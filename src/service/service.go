@@ -1,70 +1,208 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"gnosql/src/common"
 	"gnosql/src/global_constants"
 	"gnosql/src/in_memory_database"
 )
 
-func ConnectDatabase(gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput) in_memory_database.DatabaseConnectResult {
+func ConnectDatabase(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput) (in_memory_database.DatabaseConnectResult, error) {
 	var result = in_memory_database.DatabaseConnectResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := authorizeConnect(ctx, gnoSQL, DatabaseName, collectionsInput); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		databaseResult, err := gnoSQL.ShardRouter.ConnectDatabase(DatabaseName, collectionsInput)
+		result.Data = databaseResult
+		return result, err
+	}
+
 	db := gnoSQL.GetDB(DatabaseName)
 
-	if db == nil {
-		db = gnoSQL.CreateDB(DatabaseName, collectionsInput)
-	} else {
-		db.CreateColls(collectionsInput)
+	// a reconnect finding everything in place creates nothing, so it adds no entry to the log
+	var collectionsToCreate = missingCollections(db, collectionsInput)
+
+	if gnoSQL.IsReadOnly() {
+		// replicas only connect to databases replicated from the primary
+		if err := validateDatabase(db); err != nil {
+			return result, err
+		}
+	} else if db == nil || len(collectionsToCreate) > 0 {
+		entry := in_memory_database.ReplicationEntry{
+			DatabaseName:     DatabaseName,
+			Event:            in_memory_database.Event{Type: global_constants.EVENT_CREATE_DATABASE},
+			CollectionsInput: collectionsToCreate,
+		}
+
+		var createErr error
+		err := submitEntry(gnoSQL, entry, func() {
+			if db == nil {
+				_, createErr = gnoSQL.CreateDB(DatabaseName, collectionsToCreate, in_memory_database.DatabaseConfigInput{})
+			} else {
+				db.CreateColls(collectionsToCreate)
+			}
+		})
+		if err == nil {
+			err = createErr
+		}
+		if err != nil {
+			return result, err
+		}
+
+		db = gnoSQL.GetDB(DatabaseName)
+		if err := validateDatabase(db); err != nil {
+			return result, err
+		}
+	}
+
+	isVisible, err := visibleTo(ctx, gnoSQL, DatabaseName)
+	if err != nil {
+		return result, err
 	}
 
 	result.Data = in_memory_database.DatabaseResult{
 		DatabaseName: db.DatabaseName,
-		Collections:  db.GetCollectionNames(),
+		Collections:  filterNames(db.GetCollectionNames(), isVisible),
 	}
 
-	return result
+	return result, nil
 }
 
-func CreateDatabase(gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput) (in_memory_database.DatabaseCreateResult, error) {
+func CreateDatabase(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput, configInput in_memory_database.DatabaseConfigInput) (in_memory_database.DatabaseCreateResult, error) {
 	var result = in_memory_database.DatabaseCreateResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if _, exists := in_memory_database.GetStorageEngine(configInput.StorageEngine); !exists {
+		return result, errors.New(global_constants.STORAGE_ENGINE_NOT_FOUND_MSG)
+	}
+
+	if !common.IsCompressionCodec(configInput.Compression) {
+		return result, errors.New(global_constants.COMPRESSION_NOT_FOUND_MSG)
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		if err := gnoSQL.ShardRouter.CreateDatabase(DatabaseName, collectionsInput, configInput); err != nil {
+			return result, err
+		}
+		result.Data = global_constants.DATABASE_CREATE_SUCCESS_MSG
+		return result, nil
+	}
+
 	db := gnoSQL.GetDB(DatabaseName)
 
 	if db != nil {
 		return result, errors.New("Database already exists")
 	}
 
-	gnoSQL.CreateDB(DatabaseName, collectionsInput)
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName:     DatabaseName,
+		Event:            in_memory_database.Event{Type: global_constants.EVENT_CREATE_DATABASE},
+		CollectionsInput: collectionsInput,
+		DatabaseConfig:   configInput,
+	}
+
+	var createErr error
+	err := submitEntry(gnoSQL, entry, func() {
+		_, createErr = gnoSQL.CreateDB(DatabaseName, collectionsInput, configInput)
+	})
+	if err == nil {
+		err = createErr
+	}
+	if err != nil {
+		return result, err
+	}
 
 	result.Data = global_constants.DATABASE_CREATE_SUCCESS_MSG
 
 	return result, nil
 }
 
-func DeleteDatabase(gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.DatabaseDeleteResult, error) {
+func DeleteDatabase(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.DatabaseDeleteResult, error) {
 	var result = in_memory_database.DatabaseDeleteResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		if err := gnoSQL.ShardRouter.DeleteDatabase(DatabaseName); err != nil {
+			return result, err
+		}
+		result.Data = global_constants.DATABASE_DELETE_SUCCESS_MSG
+		return result, nil
+	}
+
 	db := gnoSQL.GetDB(DatabaseName)
 
 	if err := validateDatabase(db); err != nil {
 		return result, err
 	}
 
-	gnoSQL.DeleteDB(db)
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName: DatabaseName,
+		Event:        in_memory_database.Event{Type: global_constants.EVENT_DELETE_DATABASE},
+	}
+
+	err := submitEntry(gnoSQL, entry, func() {
+		gnoSQL.DeleteDB(db)
+	})
+	if err != nil {
+		return result, err
+	}
 
 	result.Data = global_constants.DATABASE_DELETE_SUCCESS_MSG
 
 	return result, nil
 }
 
-func GetAllDatabase(gnoSQL *in_memory_database.GnoSQL) (in_memory_database.DatabaseGetAllResult, error) {
+func GetAllDatabase(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.DatabaseGetAllResult, error) {
 	var result = in_memory_database.DatabaseGetAllResult{}
 
+	// databases the caller holds no grant on are left out
+	isVisible, err := visibleTo(ctx, gnoSQL, "")
+	if err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		allDatabaseNames, err := gnoSQL.ShardRouter.GetAllDatabases()
+		result.Data = filterNames(allDatabaseNames, isVisible)
+		return result, err
+	}
+
 	databaseNames := make([]string, 0)
 
-	for _, database := range gnoSQL.Databases {
-		databaseNames = append(databaseNames, database.DatabaseName)
+	for _, database := range gnoSQL.GetAllDBs() {
+		if validateDatabaseName(database.DatabaseName) == nil && isVisible(database.DatabaseName) {
+			databaseNames = append(databaseNames, database.DatabaseName)
+		}
 	}
 
 	result.Data = databaseNames
@@ -72,62 +210,174 @@ func GetAllDatabase(gnoSQL *in_memory_database.GnoSQL) (in_memory_database.Datab
 	return result, nil
 }
 
-func LoadToDisk(gnoSQL *in_memory_database.GnoSQL) (in_memory_database.DatabaseLoadToDiskResult, error) {
+func LoadToDisk(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.DatabaseLoadToDiskResult, error) {
 	var result = in_memory_database.DatabaseLoadToDiskResult{}
 
+	if err := authorizeServerAdmin(ctx, gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		if err := gnoSQL.ShardRouter.LoadToDisk(); err != nil {
+			return result, err
+		}
+		result.Data = global_constants.DATABASE_LOAD_TO_DISK_MSG
+		return result, nil
+	}
+
 	go gnoSQL.WriteAllDBs()
 
 	result.Data = global_constants.DATABASE_LOAD_TO_DISK_MSG
 	return result, nil
 }
 
-func CreateCollections(gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput) (in_memory_database.CollectionCreateResult, error) {
+// CreateBackup captures a consistent copy of DatabaseName, or of every database when it is empty
+func CreateBackup(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.Backup, error) {
+	var backupDatabaseName = DatabaseName
+	if backupDatabaseName == "" {
+		backupDatabaseName = global_constants.GRANT_WILDCARD
+	}
+
+	if err := Authorize(ctx, gnoSQL, backupDatabaseName, "", global_constants.PRIVILEGE_READ); err != nil {
+		return in_memory_database.Backup{}, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return in_memory_database.Backup{}, err
+	}
+
+	return gnoSQL.CreateBackup(DatabaseName)
+}
+
+func CreateCollections(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collectionsInput []in_memory_database.CollectionInput) (in_memory_database.CollectionCreateResult, error) {
 	var result = in_memory_database.CollectionCreateResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		if err := gnoSQL.ShardRouter.CreateCollections(DatabaseName, collectionsInput); err != nil {
+			return result, err
+		}
+		result.Data = global_constants.COLLECTION_CREATE_SUCCESS_MSG
+		return result, nil
+	}
+
 	db := gnoSQL.GetDB(DatabaseName)
 
 	if err := validateDatabase(db); err != nil {
 		return result, err
 	}
 
-	db.CreateColls(collectionsInput)
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName:     DatabaseName,
+		Event:            in_memory_database.Event{Type: global_constants.EVENT_CREATE_COLLECTIONS},
+		CollectionsInput: collectionsInput,
+	}
+
+	err := submitEntry(gnoSQL, entry, func() {
+		db.CreateColls(collectionsInput)
+	})
+	if err != nil {
+		return result, err
+	}
 
 	result.Data = global_constants.COLLECTION_CREATE_SUCCESS_MSG
 
 	return result, nil
 }
 
-func DeleteCollections(gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collections []string) (in_memory_database.CollectionDeleteResult, error) {
+func DeleteCollections(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, collections []string) (in_memory_database.CollectionDeleteResult, error) {
 	var result = in_memory_database.CollectionDeleteResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	for _, CollectionName := range collections {
+		if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_ADMIN); err != nil {
+			return result, err
+		}
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		if err := gnoSQL.ShardRouter.DeleteCollections(DatabaseName, collections); err != nil {
+			return result, err
+		}
+		result.Data = global_constants.COLLECTION_DELETE_SUCCESS_MSG
+		return result, nil
+	}
+
 	db := gnoSQL.GetDB(DatabaseName)
 
 	if err := validateDatabase(db); err != nil {
 		return result, err
 	}
 
-	db.DeleteColls(collections)
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName:    DatabaseName,
+		Event:           in_memory_database.Event{Type: global_constants.EVENT_DELETE_COLLECTIONS},
+		CollectionNames: collections,
+	}
+
+	err := submitEntry(gnoSQL, entry, func() {
+		db.DeleteColls(collections)
+	})
+	if err != nil {
+		return result, err
+	}
 
 	result.Data = global_constants.COLLECTION_DELETE_SUCCESS_MSG
 
 	return result, nil
 }
 
-func GetAllCollections(gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.CollectionGetAllResult, error) {
+func GetAllCollections(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.CollectionGetAllResult, error) {
 	var result = in_memory_database.CollectionGetAllResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	// collections the caller holds no grant on are left out
+	isVisible, err := visibleTo(ctx, gnoSQL, DatabaseName)
+	if err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		collections, err := gnoSQL.ShardRouter.GetAllCollections(DatabaseName)
+		result.Data = filterNames(collections, isVisible)
+		return result, err
+	}
+
 	db := gnoSQL.GetDB(DatabaseName)
 
 	if err := validateDatabase(db); err != nil {
 		return result, err
 	}
 
-	allCollections := db.Collections
+	allCollections := db.GetAllColls()
 
 	collections := make([]string, 0)
 
 	for _, collection := range allCollections {
-		collections = append(collections, collection.CollectionName)
+		if isVisible(collection.CollectionName) {
+			collections = append(collections, collection.CollectionName)
+		}
 	}
 
 	result.Data = collections
@@ -135,12 +385,33 @@ func GetAllCollections(gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (
 	return result, nil
 }
 
-func GetCollectionStats(gnoSQL *in_memory_database.GnoSQL, DatabaseName string, CollectionName string) (in_memory_database.CollectionStatsResult, error) {
+func GetCollectionStats(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, CollectionName string) (in_memory_database.CollectionStatsResult, error) {
 	var result = in_memory_database.CollectionStatsResult{}
 
-	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
 
-	if err := validateDatabaseAndCollection(db, collection); err != nil {
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_READ); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		stats, err := gnoSQL.ShardRouter.GetCollectionStats(DatabaseName, CollectionName)
+		result.Data = stats
+		return result, err
+	}
+
+	// looked up without loading it, a collection not loaded yet reports Ready false
+	db := gnoSQL.GetDB(DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	collection := db.GetColl(CollectionName)
+
+	if err := validateCollection(collection); err != nil {
 		return result, err
 	}
 
@@ -150,11 +421,134 @@ func GetCollectionStats(gnoSQL *in_memory_database.GnoSQL, DatabaseName string,
 	return result, nil
 }
 
-func DocumentCreate(gnoSQL *in_memory_database.GnoSQL,
+// RotateDatabaseKey makes a new data key the active one of a database and re-encrypts its files in the background,
+// files not rewritten yet still read with the previous key
+func RotateDatabaseKey(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.DatabaseRotateKeyResult, error) {
+	var result = in_memory_database.DatabaseRotateKeyResult{}
+
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	db := gnoSQL.GetDB(DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	if !common.IsEncryptionEnabled() {
+		return result, errors.New(global_constants.ENCRYPTION_NOT_ENABLED_MSG)
+	}
+
+	if err := common.RotateDataKey(common.GetDatabaseFolderPath(gnoSQL.Settings.DataPath, db.DatabaseName)); err != nil {
+		return result, err
+	}
+
+	go db.ReencryptFiles()
+
+	result.Data = global_constants.KEY_ROTATION_STARTED_MSG
+
+	return result, nil
+}
+
+// CompactCollection merges the under-filled batches of a collection, the layout on disk is local to this node
+func CompactCollection(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, CollectionName string) (in_memory_database.CollectionCompactResult, error) {
+	var result = in_memory_database.CollectionCompactResult{}
+
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
+
+	if err := validateDatabaseAndCollection(db, collection); err != nil {
+		return result, err
+	}
+
+	result.Data = collection.CompactBatches()
+
+	return result, nil
+}
+
+// SetQueryCache caps the query cache of a collection, 0 turns it off. Like the memory budget it is local to this node
+func SetQueryCache(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, CollectionName string, queryCacheMB int) (in_memory_database.CollectionQueryCacheResult, error) {
+	var result = in_memory_database.CollectionQueryCacheResult{}
+
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if queryCacheMB < 0 {
+		return result, errors.New(global_constants.INVALID_QUERY_CACHE_SIZE_MSG)
+	}
+
+	// looked up without loading it, the cache is used once the collection is loaded
+	db := gnoSQL.GetDB(DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	collection := db.GetColl(CollectionName)
+
+	if err := validateCollection(collection); err != nil {
+		return result, err
+	}
+
+	db.SetQueryCache(collection, queryCacheMB)
+
+	result.Data = global_constants.QUERY_CACHE_UPDATE_SUCCESS_MSG
+
+	return result, nil
+}
+
+func DocumentCreate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL,
 	DatabaseName string, CollectionName string, document in_memory_database.Document) (in_memory_database.DocumentCreateResult, error) {
 
 	var result = in_memory_database.DocumentCreateResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_WRITE); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		createdDocument, err := gnoSQL.ShardRouter.DocumentCreate(DatabaseName, CollectionName, document)
+		result.Data = createdDocument
+		return result, err
+	}
+
 	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
 
 	if err := validateDatabaseAndCollection(db, collection); err != nil {
@@ -167,18 +561,34 @@ func DocumentCreate(gnoSQL *in_memory_database.GnoSQL,
 
 	var createEvent in_memory_database.Event = GenerateCreateEvent(document)
 
-	go in_memory_database.AddIncomingRequest(db.DatabaseName, collection.CollectionName, createEvent)
+	if err := submitEvent(ctx, gnoSQL, db.DatabaseName, collection.CollectionName, createEvent); err != nil {
+		return result, err
+	}
 
 	result.Data = document
 
 	return result, nil
 }
 
-func DocumentRead(gnoSQL *in_memory_database.GnoSQL,
+func DocumentRead(ctx context.Context, gnoSQL *in_memory_database.GnoSQL,
 	DatabaseName string, CollectionName string, id string) (in_memory_database.DocumentReadResult, error) {
 
 	var result = in_memory_database.DocumentReadResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_READ); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		existingDocument, err := gnoSQL.ShardRouter.DocumentRead(DatabaseName, CollectionName, id)
+		result.Data = existingDocument
+		return result, err
+	}
+
 	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
 
 	if err := validateDatabaseAndCollection(db, collection); err != nil {
@@ -196,30 +606,71 @@ func DocumentRead(gnoSQL *in_memory_database.GnoSQL,
 	return result, nil
 }
 
-func DocumentFilter(gnoSQL *in_memory_database.GnoSQL,
-	DatabaseName string, CollectionName string, filter in_memory_database.MapInterface) (in_memory_database.DocumentFilterResult, error) {
+func DocumentFilter(ctx context.Context, gnoSQL *in_memory_database.GnoSQL,
+	DatabaseName string, CollectionName string, filter in_memory_database.MapInterface, explain bool) (in_memory_database.DocumentFilterResult, error) {
 
 	var result = in_memory_database.DocumentFilterResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_READ); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		// every shard runs its own plan, there is no single one to return
+		if explain {
+			return result, errors.New(global_constants.EXPLAIN_NOT_SUPPORTED_ON_ROUTER_MSG)
+		}
+
+		documents, err := gnoSQL.ShardRouter.DocumentFilter(DatabaseName, CollectionName, filter)
+		result.Data = documents
+		return result, err
+	}
+
 	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
 
 	if err := validateDatabaseAndCollection(db, collection); err != nil {
 		return result, err
 	}
 
-	documents := collection.Filter(filter)
+	documents, plan := collection.FilterWithPlan(ctx, filter)
 
 	result.Data = documents
 
+	if explain {
+		result.Plan = &plan
+	}
+
 	return result, nil
 }
 
-func DocumentUpdate(gnoSQL *in_memory_database.GnoSQL,
+func DocumentUpdate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL,
 	DatabaseName string, CollectionName string, id string,
 	document in_memory_database.Document) (in_memory_database.DocumentUpdateResult, error) {
 
 	var result = in_memory_database.DocumentUpdateResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_WRITE); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		updatedDocument, err := gnoSQL.ShardRouter.DocumentUpdate(DatabaseName, CollectionName, id, document)
+		result.Data = updatedDocument
+		return result, err
+	}
+
 	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
 
 	if err := validateDatabaseAndCollection(db, collection); err != nil {
@@ -231,24 +682,53 @@ func DocumentUpdate(gnoSQL *in_memory_database.GnoSQL,
 		return result, errors.New(global_constants.DOCUMENT_NOT_FOUND_MSG)
 	}
 
+	// existingDocument is the stored document, merge into a copy and let the mutation worker apply it
+	var updatedDocument = make(in_memory_database.Document)
+
+	for key, value := range existingDocument {
+		updatedDocument[key] = value
+	}
+
 	for key, value := range document {
-		existingDocument[key] = value
+		updatedDocument[key] = value
 	}
 
-	var updateEvent in_memory_database.Event = GenerateUpdateEvent(existingDocument)
+	var updateEvent in_memory_database.Event = GenerateUpdateEvent(updatedDocument)
 
-	go in_memory_database.AddIncomingRequest(db.DatabaseName, collection.CollectionName, updateEvent)
+	if err := submitEvent(ctx, gnoSQL, db.DatabaseName, collection.CollectionName, updateEvent); err != nil {
+		return result, err
+	}
 
-	result.Data = existingDocument
+	result.Data = updatedDocument
 
 	return result, nil
 }
 
-func DocumentDelete(gnoSQL *in_memory_database.GnoSQL,
+func DocumentDelete(ctx context.Context, gnoSQL *in_memory_database.GnoSQL,
 	DatabaseName string, CollectionName string, id string) (in_memory_database.DocumentDeleteResult, error) {
 
 	var result = in_memory_database.DocumentDeleteResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_WRITE); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		if err := gnoSQL.ShardRouter.DocumentDelete(DatabaseName, CollectionName, id); err != nil {
+			return result, err
+		}
+		result.Data = global_constants.DOCUMENT_DELETE_SUCCESS_MSG
+		return result, nil
+	}
+
 	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
 
 	if err := validateDatabaseAndCollection(db, collection); err != nil {
@@ -263,18 +743,34 @@ func DocumentDelete(gnoSQL *in_memory_database.GnoSQL,
 
 	var deleteEvent in_memory_database.Event = GenerateDeleteEvent(id)
 
-	go in_memory_database.AddIncomingRequest(db.DatabaseName, collection.CollectionName, deleteEvent)
+	if err := submitEvent(ctx, gnoSQL, db.DatabaseName, collection.CollectionName, deleteEvent); err != nil {
+		return result, err
+	}
 
 	result.Data = global_constants.DOCUMENT_DELETE_SUCCESS_MSG
 
 	return result, nil
 }
 
-func DocumentGetAll(gnoSQL *in_memory_database.GnoSQL,
+func DocumentGetAll(ctx context.Context, gnoSQL *in_memory_database.GnoSQL,
 	DatabaseName string, CollectionName string) (in_memory_database.DocumentGetAllResult, error) {
 
 	var result = in_memory_database.DocumentGetAllResult{}
 
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, CollectionName, global_constants.PRIVILEGE_READ); err != nil {
+		return result, err
+	}
+
+	if gnoSQL.ShardRouter != nil {
+		documents, err := gnoSQL.ShardRouter.DocumentGetAll(DatabaseName, CollectionName)
+		result.Data = documents
+		return result, err
+	}
+
 	db, collection := gnoSQL.GetDatabaseAndCollection(DatabaseName, CollectionName)
 
 	if err := validateDatabaseAndCollection(db, collection); err != nil {
@@ -288,6 +784,252 @@ func DocumentGetAll(gnoSQL *in_memory_database.GnoSQL,
 	return result, nil
 }
 
+func WebhookCreate(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, request in_memory_database.WebhookCreateRequest) (in_memory_database.WebhookCreateResult, error) {
+	var result = in_memory_database.WebhookCreateResult{}
+
+	if err := validateDatabaseName(request.DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, request.DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	db := gnoSQL.GetDB(request.DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	if request.CollectionName != "" {
+		if err := validateCollection(db.GetColl(request.CollectionName)); err != nil {
+			return result, err
+		}
+	}
+
+	if request.Url == "" {
+		return result, errors.New(global_constants.WEBHOOK_URL_REQUIRED_MSG)
+	}
+
+	webhook := in_memory_database.Webhook{
+		Id:             common.Generate16DigitUUID(),
+		CollectionName: request.CollectionName,
+		Url:            request.Url,
+		Secret:         request.Secret,
+		Events:         request.Events,
+		Filter:         request.Filter,
+	}
+
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName: db.DatabaseName,
+		Event:        in_memory_database.Event{Type: global_constants.EVENT_CREATE_WEBHOOK, Id: webhook.Id},
+		Webhook:      webhook,
+	}
+
+	err := submitEntry(gnoSQL, entry, func() {
+		db.AddWebhook(webhook)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if webhook, exists := db.GetWebhook(webhook.Id); exists {
+		result.Data = webhook.Redacted()
+	}
+
+	return result, nil
+}
+
+func WebhookDelete(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, webhookId string) (in_memory_database.WebhookDeleteResult, error) {
+	var result = in_memory_database.WebhookDeleteResult{}
+
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	if err := validateWritable(gnoSQL); err != nil {
+		return result, err
+	}
+
+	db := gnoSQL.GetDB(DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	if _, exists := db.GetWebhook(webhookId); !exists {
+		return result, errors.New(global_constants.WEBHOOK_NOT_FOUND_MSG)
+	}
+
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName: db.DatabaseName,
+		Event:        in_memory_database.Event{Type: global_constants.EVENT_DELETE_WEBHOOK, Id: webhookId},
+	}
+
+	err := submitEntry(gnoSQL, entry, func() {
+		db.DeleteWebhook(webhookId)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Data = global_constants.WEBHOOK_DELETE_SUCCESS_MSG
+
+	return result, nil
+}
+
+func WebhookGetAll(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.WebhookGetAllResult, error) {
+	var result = in_memory_database.WebhookGetAllResult{}
+
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	db := gnoSQL.GetDB(DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	webhooks := make([]in_memory_database.Webhook, 0)
+
+	for _, webhook := range db.GetWebhooks() {
+		webhooks = append(webhooks, webhook.Redacted())
+	}
+
+	result.Data = webhooks
+
+	return result, nil
+}
+
+func WebhookDeadLetters(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string) (in_memory_database.WebhookDeadLettersResult, error) {
+	var result = in_memory_database.WebhookDeadLettersResult{}
+
+	if err := validateDatabaseName(DatabaseName); err != nil {
+		return result, err
+	}
+
+	if err := Authorize(ctx, gnoSQL, DatabaseName, "", global_constants.PRIVILEGE_ADMIN); err != nil {
+		return result, err
+	}
+
+	if err := validateNotRouter(gnoSQL); err != nil {
+		return result, err
+	}
+
+	db := gnoSQL.GetDB(DatabaseName)
+
+	if err := validateDatabase(db); err != nil {
+		return result, err
+	}
+
+	result.Data = gnoSQL.WebhookDispatcher.GetDeadLetters(db.DatabaseName)
+
+	return result, nil
+}
+
+func GetReplicationStats(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.ReplicationStatsResult, error) {
+	var result = in_memory_database.ReplicationStatsResult{}
+
+	result.Data = gnoSQL.GetReplicationStats()
+
+	return result, nil
+}
+
+func GetLoadProgress(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.LoadProgressResult, error) {
+	var result = in_memory_database.LoadProgressResult{}
+
+	result.Data = gnoSQL.GetLoadProgress()
+
+	return result, nil
+}
+
+// submitEntry commits entry through the raft replica group when there is one, otherwise runs apply on this node
+func submitEntry(gnoSQL *in_memory_database.GnoSQL, entry in_memory_database.ReplicationEntry, apply func()) error {
+	if gnoSQL.Consensus != nil {
+		return gnoSQL.Consensus.Propose(entry)
+	}
+	apply()
+	return nil
+}
+
+// submitEvent hands a document event to the raft replica group or to the collection mutation worker
+func submitEvent(ctx context.Context, gnoSQL *in_memory_database.GnoSQL, DatabaseName string, CollectionName string, event in_memory_database.Event) error {
+	entry := in_memory_database.ReplicationEntry{
+		DatabaseName:   DatabaseName,
+		CollectionName: CollectionName,
+		Event:          event,
+	}
+
+	return submitEntry(gnoSQL, entry, func() {
+		gnoSQL.QueueIncomingRequests(ctx, DatabaseName, CollectionName, event)
+	})
+}
+
+func GetShardMap(ctx context.Context, gnoSQL *in_memory_database.GnoSQL) (in_memory_database.ShardMapResult, error) {
+	var result = in_memory_database.ShardMapResult{}
+
+	if gnoSQL.ShardRouter == nil {
+		return result, errors.New(global_constants.NOT_A_ROUTER_MSG)
+	}
+
+	result.Data = gnoSQL.ShardRouter.GetShardMap()
+
+	return result, nil
+}
+
+// validateWritable returns an error if gnoSQL is running as a read only replica
+func validateWritable(gnoSQL *in_memory_database.GnoSQL) error {
+	if gnoSQL.IsReadOnly() {
+		return errors.New(global_constants.REPLICA_READ_ONLY_MSG)
+	}
+	return nil
+}
+
+// missingCollections returns the collections of collectionsInput db doesn't have, all of them when db is nil
+func missingCollections(db *in_memory_database.Database, collectionsInput []in_memory_database.CollectionInput) []in_memory_database.CollectionInput {
+	var collectionsToCreate = make([]in_memory_database.CollectionInput, 0)
+
+	for _, collectionInput := range collectionsInput {
+		if db == nil || db.GetColl(collectionInput.CollectionName) == nil {
+			collectionsToCreate = append(collectionsToCreate, collectionInput)
+		}
+	}
+	return collectionsToCreate
+}
+
+// validateNotRouter returns an error for requests a router can't forward to its shards
+func validateNotRouter(gnoSQL *in_memory_database.GnoSQL) error {
+	if gnoSQL.ShardRouter != nil {
+		return errors.New(global_constants.ROUTER_NOT_SUPPORTED_MSG)
+	}
+	return nil
+}
+
 // validateDatabase checks if db is nil, returns an error if it is
 func validateDatabase(db *in_memory_database.Database) error {
 	if db == nil {
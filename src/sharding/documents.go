@@ -0,0 +1,266 @@
+package sharding
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "gnosql/proto"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"slices"
+)
+
+func decodeDocument(data string) (in_memory_database.Document, error) {
+	var document in_memory_database.Document
+
+	if err := json.Unmarshal([]byte(data), &document); err != nil {
+		return nil, errors.New(global_constants.ERROR_WHILE_UNMARSHAL_JSON)
+	}
+	return document, nil
+}
+
+func decodeDocuments(data string) ([]in_memory_database.Document, error) {
+	var documents []in_memory_database.Document
+
+	if err := json.Unmarshal([]byte(data), &documents); err != nil {
+		return nil, errors.New(global_constants.ERROR_WHILE_UNMARSHAL_JSON)
+	}
+	return documents, nil
+}
+
+// docIndexOf returns the docIndex of a document decoded from a shard's JSON response
+func docIndexOf(document in_memory_database.Document) float64 {
+	docIndex, _ := document[global_constants.DOC_INDEX].(float64)
+	return docIndex
+}
+
+func (router *Router) filterLimitOf(filter in_memory_database.MapInterface) int {
+	switch limit := filter[global_constants.FILTER_LIMIT].(type) {
+	case int:
+		return limit
+	case float64:
+		return int(limit)
+	}
+	return router.filterLimit
+}
+
+// mergeByDocIndex merges the sorted results of every shard, limit < 0 keeps all documents
+func mergeByDocIndex(shardDocuments [][]in_memory_database.Document, limit int) []in_memory_database.Document {
+	var merged = make([]in_memory_database.Document, 0)
+	var positions = make([]int, len(shardDocuments))
+
+	for _, documents := range shardDocuments {
+		slices.SortStableFunc(documents, func(a, b in_memory_database.Document) int {
+			return cmp.Compare(docIndexOf(a), docIndexOf(b))
+		})
+	}
+
+	for limit < 0 || len(merged) < limit {
+		var next = -1
+
+		for i, documents := range shardDocuments {
+			if positions[i] >= len(documents) {
+				continue
+			}
+			if next == -1 || docIndexOf(documents[positions[i]]) < docIndexOf(shardDocuments[next][positions[next]]) {
+				next = i
+			}
+		}
+
+		if next == -1 {
+			break
+		}
+
+		merged = append(merged, shardDocuments[next][positions[next]])
+		positions[next]++
+	}
+
+	return merged
+}
+
+func readDocument(ctx context.Context, client pb.GnoSQLServiceClient, databaseName string, collectionName string, id string) (in_memory_database.Document, error) {
+	response, err := client.ReadDocument(ctx, &pb.DocumentReadRequest{DatabaseName: databaseName, CollectionName: collectionName, DocId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// a shard answers null for a missing document
+	return decodeDocument(response.Data)
+}
+
+// findDocument returns the shard holding the document, only collections sharded by docId are looked up on a single shard
+func (router *Router) findDocument(databaseName string, collectionName string, id string) (int, in_memory_database.Document, error) {
+	if router.shardKeyOf(databaseName, collectionName) == global_constants.DOC_ID {
+		var shardIndex = router.shardIndex(id)
+
+		ctx, cancel := callContext()
+		defer cancel()
+
+		document, err := readDocument(ctx, router.clients[shardIndex], databaseName, collectionName, id)
+		if err != nil {
+			return shardIndex, nil, shardError(err)
+		}
+		if document == nil {
+			return shardIndex, nil, errors.New(global_constants.DOCUMENT_NOT_FOUND_MSG)
+		}
+		return shardIndex, document, nil
+	}
+
+	var documents = make([]in_memory_database.Document, len(router.clients))
+
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		document, err := readDocument(ctx, client, databaseName, collectionName, id)
+		documents[shardIndex] = document
+		return err
+	})
+
+	for shardIndex, document := range documents {
+		if document != nil {
+			return shardIndex, document, nil
+		}
+	}
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return -1, nil, errors.New(global_constants.DOCUMENT_NOT_FOUND_MSG)
+}
+
+func (router *Router) DocumentCreate(databaseName string, collectionName string, document in_memory_database.Document) (in_memory_database.Document, error) {
+	var shardKey = router.shardKeyOf(databaseName, collectionName)
+
+	if document[global_constants.DOC_ID] == nil {
+		document[global_constants.DOC_ID] = common.Generate16DigitUUID()
+	}
+
+	shardKeyValue := document[shardKey]
+	if shardKeyValue == nil {
+		return nil, errors.New(global_constants.SHARD_KEY_REQUIRED_MSG)
+	}
+
+	documentJson, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.New(global_constants.ERROR_WHILE_MARSHAL_JSON)
+	}
+
+	ctx, cancel := callContext()
+	defer cancel()
+
+	response, err := router.clients[router.shardIndex(shardKeyValue)].CreateDocument(ctx, &pb.DocumentCreateRequest{
+		DatabaseName:   databaseName,
+		CollectionName: collectionName,
+		Document:       string(documentJson),
+	})
+	if err != nil {
+		return nil, shardError(err)
+	}
+
+	return decodeDocument(response.Data)
+}
+
+func (router *Router) DocumentRead(databaseName string, collectionName string, id string) (in_memory_database.Document, error) {
+	_, document, err := router.findDocument(databaseName, collectionName, id)
+	return document, err
+}
+
+func (router *Router) DocumentUpdate(databaseName string, collectionName string, id string, document in_memory_database.Document) (in_memory_database.Document, error) {
+	shardIndex, existingDocument, err := router.findDocument(databaseName, collectionName, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// moving a document to another shard is not supported
+	var shardKey = router.shardKeyOf(databaseName, collectionName)
+	if value, exists := document[shardKey]; exists && shardKey != global_constants.DOC_ID && fmt.Sprint(value) != fmt.Sprint(existingDocument[shardKey]) {
+		return nil, errors.New(global_constants.SHARD_KEY_IMMUTABLE_MSG)
+	}
+
+	documentJson, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.New(global_constants.ERROR_WHILE_MARSHAL_JSON)
+	}
+
+	ctx, cancel := callContext()
+	defer cancel()
+
+	response, err := router.clients[shardIndex].UpdateDocument(ctx, &pb.DocumentUpdateRequest{
+		DatabaseName:   databaseName,
+		CollectionName: collectionName,
+		DocId:          id,
+		Document:       string(documentJson),
+	})
+	if err != nil {
+		return nil, shardError(err)
+	}
+
+	return decodeDocument(response.Data)
+}
+
+func (router *Router) DocumentDelete(databaseName string, collectionName string, id string) error {
+	shardIndex, _, err := router.findDocument(databaseName, collectionName, id)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := callContext()
+	defer cancel()
+
+	_, err = router.clients[shardIndex].DeleteDocument(ctx, &pb.DocumentDeleteRequest{
+		DatabaseName:   databaseName,
+		CollectionName: collectionName,
+		DocId:          id,
+	})
+
+	return shardError(err)
+}
+
+// DocumentFilter sends the filter to every shard, the limit is applied again after merging the results
+func (router *Router) DocumentFilter(databaseName string, collectionName string, filter in_memory_database.MapInterface) ([]in_memory_database.Document, error) {
+	filterJson, err := json.Marshal(filter)
+	if err != nil {
+		return nil, errors.New(global_constants.ERROR_WHILE_MARSHAL_JSON)
+	}
+
+	var shardDocuments = make([][]in_memory_database.Document, len(router.clients))
+
+	err = router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		response, err := client.FilterDocument(ctx, &pb.DocumentFilterRequest{
+			DatabaseName:   databaseName,
+			CollectionName: collectionName,
+			Filter:         string(filterJson),
+		})
+		if err != nil {
+			return err
+		}
+
+		shardDocuments[shardIndex], err = decodeDocuments(response.Data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeByDocIndex(shardDocuments, router.filterLimitOf(filter)), nil
+}
+
+func (router *Router) DocumentGetAll(databaseName string, collectionName string) ([]in_memory_database.Document, error) {
+	var shardDocuments = make([][]in_memory_database.Document, len(router.clients))
+
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		response, err := client.GetAllDocuments(ctx, &pb.DocumentGetAllRequest{DatabaseName: databaseName, CollectionName: collectionName})
+		if err != nil {
+			return err
+		}
+
+		shardDocuments[shardIndex], err = decodeDocuments(response.Data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeByDocIndex(shardDocuments, -1), nil
+}
@@ -0,0 +1,232 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pb "gnosql/proto"
+	"gnosql/src/auth"
+	"gnosql/src/common"
+	"gnosql/src/global_constants"
+	"gnosql/src/in_memory_database"
+	"gnosql/src/tls_config"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Router forwards database requests to shards, documents are placed by hash of the collection's shard key.
+// Schema changes are sent to every shard, filters are scattered and the sorted results merged.
+type Router struct {
+	dataPath string // the shard map file is kept in the data folder
+	shardMap in_memory_database.ShardMap
+	clients  []pb.GnoSQLServiceClient // same order as shardMap.Shards
+	mu       sync.RWMutex
+
+	filterLimit int // documents returned by a filter without a limit
+}
+
+// NewRouter loads the stored shard map, shards may only be given when there is no stored map or they match it
+func NewRouter(dataPath string, shards []in_memory_database.Shard, filterLimit int) (*Router, error) {
+	shardMap, err := readShardMap(dataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shardMap.Shards) == 0 {
+		shardMap.Shards = shards
+	} else if len(shards) > 0 && !slices.Equal(shards, shardMap.Shards) {
+		return nil, errors.New(global_constants.SHARD_MAP_CHANGED_MSG)
+	}
+
+	if len(shardMap.Shards) == 0 {
+		return nil, errors.New("router needs at least one shard")
+	}
+
+	router := &Router{dataPath: dataPath, shardMap: shardMap, filterLimit: filterLimit}
+
+	for _, shard := range shardMap.Shards {
+		conn, err := grpc.NewClient(shard.Address, tls_config.NodeDialOption(), auth.NodeDialOption())
+		if err != nil {
+			return nil, err
+		}
+		router.clients = append(router.clients, pb.NewGnoSQLServiceClient(conn))
+	}
+
+	if err := router.saveShardMap(); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+}
+
+// ParseShards parses a shard list like "s1=host1:5455,s2=host2:5455"
+func ParseShards(value string) ([]in_memory_database.Shard, error) {
+	var shards = make([]in_memory_database.Shard, 0)
+
+	for _, member := range strings.Split(value, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+
+		id, address, found := strings.Cut(member, "=")
+		if !found || id == "" || address == "" {
+			return nil, fmt.Errorf("invalid shard %q, expected id=host:port", member)
+		}
+
+		shards = append(shards, in_memory_database.Shard{Id: id, Address: address})
+	}
+
+	return shards, nil
+}
+
+func shardMapFilePath(dataPath string) string {
+	return filepath.Join(dataPath, global_constants.SHARD_MAP_FILE_NAME)
+}
+
+func readShardMap(dataPath string) (in_memory_database.ShardMap, error) {
+	var shardMap = in_memory_database.ShardMap{ShardKeys: make(map[string]string)}
+
+	data, err := common.ReadGobFile(dataPath, shardMapFilePath(dataPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return shardMap, nil
+	}
+	if err != nil {
+		return shardMap, err
+	}
+
+	if err := common.DecodeGob(data, &shardMap); err != nil {
+		return shardMap, err
+	}
+	if shardMap.ShardKeys == nil {
+		shardMap.ShardKeys = make(map[string]string)
+	}
+
+	return shardMap, nil
+}
+
+// saveShardMap expects router.mu to be held or the router not to be shared yet
+func (router *Router) saveShardMap() error {
+	data, err := common.EncodeGob(router.shardMap)
+	if err != nil {
+		return err
+	}
+	return common.SaveGobFile(router.dataPath, shardMapFilePath(router.dataPath), data)
+}
+
+func (router *Router) GetShardMap() in_memory_database.ShardMap {
+	router.mu.RLock()
+	defer router.mu.RUnlock()
+
+	shardKeys := make(map[string]string)
+	for collectionKey, shardKey := range router.shardMap.ShardKeys {
+		shardKeys[collectionKey] = shardKey
+	}
+
+	return in_memory_database.ShardMap{Shards: slices.Clone(router.shardMap.Shards), ShardKeys: shardKeys}
+}
+
+func collectionKey(databaseName string, collectionName string) string {
+	return databaseName + "/" + collectionName
+}
+
+func (router *Router) shardKeyOf(databaseName string, collectionName string) string {
+	router.mu.RLock()
+	defer router.mu.RUnlock()
+
+	if shardKey := router.shardMap.ShardKeys[collectionKey(databaseName, collectionName)]; shardKey != "" {
+		return shardKey
+	}
+	return global_constants.DOC_ID
+}
+
+func (router *Router) setShardKeys(databaseName string, collectionsInput []in_memory_database.CollectionInput) error {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+
+	for _, collectionInput := range collectionsInput {
+		var key = collectionKey(databaseName, collectionInput.CollectionName)
+
+		// shard key is fixed once the collection exists
+		if _, exists := router.shardMap.ShardKeys[key]; exists {
+			continue
+		}
+
+		var shardKey = collectionInput.ShardKey
+		if shardKey == "" {
+			shardKey = global_constants.DOC_ID
+		}
+		router.shardMap.ShardKeys[key] = shardKey
+	}
+
+	return router.saveShardMap()
+}
+
+func (router *Router) removeShardKeys(databaseName string, collectionNames []string) error {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+
+	for key := range router.shardMap.ShardKeys {
+		collectionDatabaseName, collectionName, _ := strings.Cut(key, "/")
+
+		if collectionDatabaseName == databaseName && (collectionNames == nil || slices.Contains(collectionNames, collectionName)) {
+			delete(router.shardMap.ShardKeys, key)
+		}
+	}
+
+	return router.saveShardMap()
+}
+
+// shardIndex returns the shard a document with the given shard key value belongs to
+func (router *Router) shardIndex(shardKeyValue interface{}) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(fmt.Sprint(shardKeyValue)))
+
+	return int(hash.Sum32() % uint32(len(router.clients)))
+}
+
+func callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), global_constants.SHARD_RPC_TIMEOUT)
+}
+
+// shardError strips the gRPC status prefix, so the shard's message reaches the client unchanged
+func shardError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.New(status.Convert(err).Message())
+}
+
+// broadcast calls fn on every shard in parallel and returns the first error
+func (router *Router) broadcast(fn func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error) error {
+	var wg sync.WaitGroup
+	var errs = make([]error, len(router.clients))
+
+	for i, client := range router.clients {
+		wg.Add(1)
+		go func(i int, client pb.GnoSQLServiceClient) {
+			defer wg.Done()
+
+			ctx, cancel := callContext()
+			defer cancel()
+
+			errs[i] = shardError(fn(ctx, i, client))
+		}(i, client)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package sharding
+
+import (
+	"context"
+	pb "gnosql/proto"
+	"gnosql/src/in_memory_database"
+	"slices"
+)
+
+func toProtoCollections(collectionsInput []in_memory_database.CollectionInput) []*pb.CollectionInput {
+	var collections = make([]*pb.CollectionInput, 0)
+
+	for _, collectionInput := range collectionsInput {
+		collections = append(collections, &pb.CollectionInput{
+			CollectionName: collectionInput.CollectionName,
+			IndexKeys:      collectionInput.IndexKeys,
+			ShardKey:       collectionInput.ShardKey,
+			QueryCacheMB:   int32(collectionInput.QueryCacheMB),
+		})
+	}
+
+	return collections
+}
+
+// union keeps the order of the first shard, names missing on it are appended
+func union(lists [][]string) []string {
+	var names = make([]string, 0)
+
+	for _, list := range lists {
+		for _, name := range list {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+func (router *Router) ConnectDatabase(databaseName string, collectionsInput []in_memory_database.CollectionInput) (in_memory_database.DatabaseResult, error) {
+	var result = in_memory_database.DatabaseResult{DatabaseName: databaseName}
+	var collectionLists = make([][]string, len(router.clients))
+
+	if err := router.setShardKeys(databaseName, collectionsInput); err != nil {
+		return result, err
+	}
+
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		response, err := client.ConnectDatabase(ctx, &pb.DatabaseCreateRequest{
+			DatabaseName: databaseName,
+			Collections:  toProtoCollections(collectionsInput),
+		})
+		if err == nil {
+			collectionLists[shardIndex] = response.Data.GetCollections()
+		}
+		return err
+	})
+
+	result.Collections = union(collectionLists)
+
+	return result, err
+}
+
+func (router *Router) CreateDatabase(databaseName string, collectionsInput []in_memory_database.CollectionInput, configInput in_memory_database.DatabaseConfigInput) error {
+	if err := router.setShardKeys(databaseName, collectionsInput); err != nil {
+		return err
+	}
+
+	return router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		_, err := client.CreateNewDatabase(ctx, &pb.DatabaseCreateRequest{
+			DatabaseName: databaseName,
+			Collections:  toProtoCollections(collectionsInput),
+			Config:       &pb.DatabaseConfigInput{StorageEngine: configInput.StorageEngine, MemoryBudgetMB: int32(configInput.MemoryBudgetMB), Compression: configInput.Compression},
+		})
+		return err
+	})
+}
+
+func (router *Router) DeleteDatabase(databaseName string) error {
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		_, err := client.DeleteDatabase(ctx, &pb.DatabaseDeleteRequest{DatabaseName: databaseName})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return router.removeShardKeys(databaseName, nil)
+}
+
+func (router *Router) GetAllDatabases() ([]string, error) {
+	var databaseLists = make([][]string, len(router.clients))
+
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		response, err := client.GetAllDatabases(ctx, &pb.NoRequestBody{})
+		if err == nil {
+			databaseLists[shardIndex] = response.Data
+		}
+		return err
+	})
+
+	return union(databaseLists), err
+}
+
+func (router *Router) LoadToDisk() error {
+	return router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		_, err := client.LoadToDisk(ctx, &pb.NoRequestBody{})
+		return err
+	})
+}
+
+func (router *Router) CreateCollections(databaseName string, collectionsInput []in_memory_database.CollectionInput) error {
+	if err := router.setShardKeys(databaseName, collectionsInput); err != nil {
+		return err
+	}
+
+	return router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		_, err := client.CreateNewCollection(ctx, &pb.CollectionCreateRequest{
+			DatabaseName: databaseName,
+			Collections:  toProtoCollections(collectionsInput),
+		})
+		return err
+	})
+}
+
+func (router *Router) DeleteCollections(databaseName string, collectionNames []string) error {
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		_, err := client.DeleteCollections(ctx, &pb.CollectionDeleteRequest{DatabaseName: databaseName, Collections: collectionNames})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return router.removeShardKeys(databaseName, collectionNames)
+}
+
+func (router *Router) GetAllCollections(databaseName string) ([]string, error) {
+	var collectionLists = make([][]string, len(router.clients))
+
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		response, err := client.GetAllCollections(ctx, &pb.CollectionGetAllRequest{DatabaseName: databaseName})
+		if err == nil {
+			collectionLists[shardIndex] = response.Data
+		}
+		return err
+	})
+
+	return union(collectionLists), err
+}
+
+// GetCollectionStats adds up the documents of every shard
+func (router *Router) GetCollectionStats(databaseName string, collectionName string) (in_memory_database.CollectionStats, error) {
+	var shardStats = make([]*pb.CollectionStats, len(router.clients))
+
+	err := router.broadcast(func(ctx context.Context, shardIndex int, client pb.GnoSQLServiceClient) error {
+		response, err := client.GetCollectionStats(ctx, &pb.CollectionStatsRequest{DatabaseName: databaseName, CollectionName: collectionName})
+		if err == nil {
+			shardStats[shardIndex] = response.Data
+		}
+		return err
+	})
+
+	var stats = in_memory_database.CollectionStats{CollectionName: collectionName}
+
+	if err != nil {
+		return stats, err
+	}
+
+	// ready once every shard has loaded its part, a shard not loaded yet doesn't know the index keys
+	stats.Ready = true
+	for _, eachShardStats := range shardStats {
+		if len(stats.IndexKeys) == 0 {
+			stats.IndexKeys = eachShardStats.GetIndexKeys()
+		}
+		stats.Ready = stats.Ready && eachShardStats.GetReady()
+		stats.Documents += int(eachShardStats.GetDocuments())
+		stats.ResidentBatches += int(eachShardStats.GetResidentBatches())
+		stats.EvictedBatches += int(eachShardStats.GetEvictedBatches())
+		stats.CacheHits += eachShardStats.GetCacheHits()
+		stats.CacheMisses += eachShardStats.GetCacheMisses()
+		stats.StoredBytes += eachShardStats.GetStoredBytes()
+		stats.UncompressedBytes += eachShardStats.GetUncompressedBytes()
+		stats.QueryCacheHits += eachShardStats.GetQueryCacheHits()
+		stats.QueryCacheMisses += eachShardStats.GetQueryCacheMisses()
+		stats.QueryCacheInvalidations += eachShardStats.GetQueryCacheInvalidations()
+		stats.QueryCacheEntries += int(eachShardStats.GetQueryCacheEntries())
+		stats.QueryCacheBytes += eachShardStats.GetQueryCacheBytes()
+	}
+
+	if accesses := stats.CacheHits + stats.CacheMisses; accesses > 0 {
+		stats.CacheHitRate = float64(stats.CacheHits) / float64(accesses)
+	}
+	if filters := stats.QueryCacheHits + stats.QueryCacheMisses; filters > 0 {
+		stats.QueryCacheHitRate = float64(stats.QueryCacheHits) / float64(filters)
+	}
+	if stats.StoredBytes > 0 {
+		stats.CompressionRatio = float64(stats.UncompressedBytes) / float64(stats.StoredBytes)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,195 @@
+package tls_config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"gnosql/src/global_constants"
+	"gnosql/src/logging"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var logger = logging.Get(global_constants.LOG_SERVER)
+
+// files and what was read from them, the handshake callbacks read the current certificate so a reload applies
+// to the next connection without restarting the listeners
+var state = struct {
+	mu          sync.RWMutex
+	certFile    string
+	keyFile     string
+	caFile      string
+	clientAuth  string
+	certificate *tls.Certificate
+	caPool      *x509.CertPool
+}{}
+
+// Configure turns TLS on for both listeners when certFile and keyFile are given. caFile is the CA of the cluster,
+// nodes check each other's certificates with it and, with clientAuth grpc or all, the certificates of clients
+func Configure(certFile string, keyFile string, caFile string, clientAuth string) error {
+	switch clientAuth {
+	case global_constants.TLS_CLIENT_AUTH_NONE, global_constants.TLS_CLIENT_AUTH_GRPC, global_constants.TLS_CLIENT_AUTH_ALL:
+	default:
+		return fmt.Errorf("tlsClientAuth: unknown value %v, use none, grpc or all", clientAuth)
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return errors.New("tlsCertFile and tlsKeyFile must be given together")
+	}
+
+	if certFile == "" && (caFile != "" || clientAuth != global_constants.TLS_CLIENT_AUTH_NONE) {
+		return errors.New("tlsCaFile and tlsClientAuth need tlsCertFile and tlsKeyFile")
+	}
+
+	if clientAuth != global_constants.TLS_CLIENT_AUTH_NONE && caFile == "" {
+		return errors.New("tlsClientAuth needs tlsCaFile to verify client certificates")
+	}
+
+	state.mu.Lock()
+	state.certFile = certFile
+	state.keyFile = keyFile
+	state.caFile = caFile
+	state.clientAuth = clientAuth
+	state.mu.Unlock()
+
+	return Reload()
+}
+
+func IsEnabled() bool {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+
+	return state.certificate != nil
+}
+
+// Reload reads the certificate, the key and the CA again, on an error the ones loaded before stay in use
+func Reload() error {
+	state.mu.RLock()
+	certFile, keyFile, caFile := state.certFile, state.keyFile, state.caFile
+	state.mu.RUnlock()
+
+	if certFile == "" {
+		return nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	var caPool *x509.CertPool
+	if caFile != "" {
+		caData, err := os.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caData) {
+			return fmt.Errorf("%v: no PEM certificate found", caFile)
+		}
+	}
+
+	state.mu.Lock()
+	state.certificate = &certificate
+	state.caPool = caPool
+	state.mu.Unlock()
+
+	return nil
+}
+
+// ReloadOnSignal reloads the certificates every time the process gets SIGHUP
+func ReloadOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		if err := Reload(); err != nil {
+			logger.Error("certificate reload error, previous certificates are kept", "error", err)
+		} else {
+			logger.Info("certificates reloaded")
+		}
+	}
+}
+
+// ServerConfig returns the TLS config of a listener, nil while TLS is off. isGrpc tells which listener it is,
+// client certificates are required on it depending on the tlsClientAuth setting
+func ServerConfig(isGrpc bool) *tls.Config {
+	if !IsEnabled() {
+		return nil
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// called on every handshake so a reloaded certificate or CA is used by the next connection
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			state.mu.RLock()
+			defer state.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*state.certificate},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+
+			if state.clientAuth == global_constants.TLS_CLIENT_AUTH_ALL || (isGrpc && state.clientAuth == global_constants.TLS_CLIENT_AUTH_GRPC) {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = state.caPool
+			}
+
+			return config, nil
+		},
+	}
+}
+
+// NodeDialOption secures the connections a node opens to other nodes, they present the node certificate so
+// they pass mTLS and check the other node against the CA of the cluster. Plain connections while TLS is off
+func NodeDialOption() grpc.DialOption {
+	if !IsEnabled() {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			state.mu.RLock()
+			defer state.mu.RUnlock()
+
+			return state.certificate, nil
+		},
+		// the CA can be reloaded, so the certificate of the other node is checked against the current one
+		InsecureSkipVerify: true,
+		VerifyConnection:   verifyNode,
+	}))
+}
+
+// verifyNode checks the certificate of another node like the default verification, against the current CA of the
+// cluster, or the system roots when no CA is given
+func verifyNode(connection tls.ConnectionState) error {
+	state.mu.RLock()
+	caPool := state.caPool
+	state.mu.RUnlock()
+
+	if len(connection.PeerCertificates) == 0 {
+		return errors.New("node presented no certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range connection.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	_, err := connection.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       connection.ServerName,
+		Roots:         caPool,
+		Intermediates: intermediates,
+	})
+	return err
+}